@@ -1,12 +1,207 @@
+CREATE SEQUENCE IF NOT EXISTS series_change_seq;
+
+-- next_change_seq() numbers every write to a series. The first number a
+-- transaction takes stays held as an advisory lock until it ends, so
+-- sync_horizon() knows which numbers may still show up without locking the
+-- series table. The shared gate keeps sync_horizon() from reading the sequence
+-- between nextval() & that lock.
+CREATE OR REPLACE FUNCTION next_change_seq() RETURNS BIGINT AS $$
+DECLARE
+  n BIGINT;
+BEGIN
+  IF current_setting('series_tracker.change_seq_held', true) = 'on' THEN
+    RETURN nextval('series_change_seq');
+  END IF;
+
+  PERFORM pg_advisory_lock_shared(7301, 0);
+  BEGIN
+    n := nextval('series_change_seq');
+    PERFORM pg_advisory_xact_lock_shared(n);
+    PERFORM set_config('series_tracker.change_seq_held', 'on', true);
+  EXCEPTION WHEN OTHERS THEN
+    PERFORM pg_advisory_unlock_shared(7301, 0);
+    RAISE;
+  END;
+  PERFORM pg_advisory_unlock_shared(7301, 0);
+  RETURN n;
+END;
+$$ LANGUAGE plpgsql;
+
+-- sync_horizon() returns the latest change number below which every change is
+-- committed or rolled back, that is the last one handed out capped right below
+-- the oldest one still held by a transaction in flight
+CREATE OR REPLACE FUNCTION sync_horizon() RETURNS BIGINT AS $$
+DECLARE
+  last BIGINT;
+  oldest BIGINT;
+BEGIN
+  PERFORM pg_advisory_lock(7301, 0);
+  BEGIN
+    SELECT CASE WHEN is_called THEN last_value ELSE 0 END INTO last FROM series_change_seq;
+    -- Locks taken with a single bigint key have objsubid 1, the gate's has 2
+    SELECT MIN((classid::bigint << 32) | objid::bigint) INTO oldest
+    FROM pg_locks
+    WHERE locktype = 'advisory' AND objsubid = 1 AND granted
+      AND database = (SELECT oid FROM pg_database WHERE datname = current_database());
+  EXCEPTION WHEN OTHERS THEN
+    PERFORM pg_advisory_unlock(7301, 0);
+    RAISE;
+  END;
+  PERFORM pg_advisory_unlock(7301, 0);
+  RETURN LEAST(last, oldest - 1);
+END;
+$$ LANGUAGE plpgsql;
+
 CREATE TABLE IF NOT EXISTS series (
   id SERIAL PRIMARY KEY,
   title VARCHAR UNIQUE NOT NULL,
   ranking INTEGER NOT NULL CHECK (ranking >= 0),
   status VARCHAR NOT NULL CHECK (status IN ('Watching', 'Plan to Watch', 'Dropped', 'Completed')),
   current_episode INTEGER NOT NULL,
-  total_episodes INTEGER NOT NULL
+  total_episodes INTEGER NOT NULL,
+  year INTEGER NOT NULL DEFAULT 0 CHECK (year >= 0),
+  genres VARCHAR[] NOT NULL DEFAULT '{}',
+  deleted_at TIMESTAMPTZ,
+  created_seq BIGINT,
+  change_seq BIGINT DEFAULT next_change_seq()
+);
+
+CREATE INDEX IF NOT EXISTS series_change_seq_idx ON series (change_seq);
+
+CREATE TABLE IF NOT EXISTS serie_tombstones (
+  serie_id INTEGER PRIMARY KEY,
+  change_seq BIGINT NOT NULL,
+  deleted_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS serie_tombstones_change_seq_idx ON serie_tombstones (change_seq);
+
+CREATE TABLE IF NOT EXISTS sync_resets (
+  seq BIGINT PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+  id SERIAL PRIMARY KEY,
+  action VARCHAR NOT NULL,
+  serie_id INTEGER NOT NULL,
+  actor VARCHAR NOT NULL,
+  request_id VARCHAR NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  before JSONB,
+  after JSONB,
+  changes JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_serie_id_idx ON audit_log (serie_id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+CREATE TABLE IF NOT EXISTS serie_events (
+  seq BIGSERIAL PRIMARY KEY,
+  serie_id INTEGER NOT NULL,
+  version INTEGER NOT NULL,
+  type VARCHAR NOT NULL,
+  data JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (serie_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS serie_snapshots (
+  serie_id INTEGER PRIMARY KEY,
+  version INTEGER NOT NULL,
+  data JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhooks (
+  id SERIAL PRIMARY KEY,
+  url VARCHAR NOT NULL,
+  secret VARCHAR NOT NULL,
+  events VARCHAR[] NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id SERIAL PRIMARY KEY,
+  webhook_id INTEGER NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+  event VARCHAR NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  response_code INTEGER,
+  error VARCHAR NOT NULL DEFAULT '',
+  replay_of INTEGER REFERENCES webhook_deliveries (id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_attempt_at TIMESTAMPTZ,
+  next_attempt_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  key VARCHAR(255) PRIMARY KEY,
+  fingerprint VARCHAR NOT NULL,
+  status INTEGER NOT NULL DEFAULT 0,
+  content_type VARCHAR NOT NULL DEFAULT '',
+  body BYTEA,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+
+CREATE TABLE IF NOT EXISTS airing_schedules (
+  serie_id INTEGER PRIMARY KEY,
+  first_episode INTEGER NOT NULL CHECK (first_episode > 0),
+  first_air_at TIMESTAMPTZ NOT NULL,
+  every_days INTEGER NOT NULL CHECK (every_days > 0),
+  runtime_minutes INTEGER NOT NULL CHECK (runtime_minutes > 0)
+);
+
+CREATE TABLE IF NOT EXISTS watch_sessions (
+  id SERIAL PRIMARY KEY,
+  serie_id INTEGER NOT NULL,
+  starts_at TIMESTAMPTZ NOT NULL,
+  episodes INTEGER NOT NULL CHECK (episodes > 0),
+  minutes INTEGER NOT NULL CHECK (minutes > 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS watch_sessions_serie_id_idx ON watch_sessions (serie_id);
+CREATE INDEX IF NOT EXISTS watch_sessions_starts_at_idx ON watch_sessions (starts_at);
+
+CREATE TABLE IF NOT EXISTS feed_tokens (
+  actor VARCHAR NOT NULL,
+  feed VARCHAR NOT NULL,
+  token_hash VARCHAR UNIQUE NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (actor, feed)
+);
+
+CREATE TABLE IF NOT EXISTS share_links (
+  id SERIAL PRIMARY KEY,
+  actor VARCHAR NOT NULL,
+  token_hash VARCHAR UNIQUE NOT NULL,
+  statuses VARCHAR[] NOT NULL DEFAULT '{}',
+  hide_rankings BOOLEAN NOT NULL DEFAULT FALSE,
+  expires_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS share_links_actor_idx ON share_links (actor);
+
+CREATE TABLE IF NOT EXISTS catalog_titles (
+  id VARCHAR PRIMARY KEY,
+  title VARCHAR NOT NULL,
+  original_title VARCHAR NOT NULL,
+  type VARCHAR NOT NULL,
+  start_year INTEGER NOT NULL DEFAULT 0,
+  end_year INTEGER NOT NULL DEFAULT 0,
+  genres VARCHAR[] NOT NULL DEFAULT '{}',
+  total_episodes INTEGER NOT NULL DEFAULT 0
 );
 
+CREATE INDEX IF NOT EXISTS catalog_titles_title_idx ON catalog_titles (lower(title) text_pattern_ops);
 
 INSERT INTO series (title, ranking, status, current_episode, total_episodes)
 VALUES 
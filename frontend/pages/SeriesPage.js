@@ -1,6 +1,7 @@
 import getSeriesList from '../utils/getSeriesList.js'
 import Filters from '../components/Filters.js'
 import Table from '../components/Table.js'
+import subscribeSeriesEvents from '../utils/subscribeSeriesEvents.js'
 
 const SeriesPage = (parentElement) => {
   // State-like variables to hold series data and filters
@@ -10,6 +11,8 @@ const SeriesPage = (parentElement) => {
     status: '',
     sort: '',
   }
+  // Element holding the rendered page, to tell when it's been navigated away from
+  let pageElement = null
 
   // Function to fetch series data based on current filters
   const fetchSeries = async () => {
@@ -24,6 +27,24 @@ const SeriesPage = (parentElement) => {
     }
   }
 
+  // Live updates, every change made in any tab re-fetches the table
+  const events = subscribeSeriesEvents(() => {
+    // Stop listening once the page was navigated away from
+    if (pageElement && !pageElement.isConnected) {
+      events.close()
+      return
+    }
+    fetchSeries()
+  })
+
+  // Actions in this tab show up through the event stream, only re-fetch right
+  // away when it's down
+  const reRenderTable = () => {
+    if (!events.isOpen()) {
+      fetchSeries()
+    }
+  }
+
   // Callback to handle filter changes
   const handleFilterChange = (newFilters) => {
     // Update the filters object with the new values
@@ -42,7 +63,7 @@ const SeriesPage = (parentElement) => {
     tableContainer.innerHTML = ''
 
     // Create and append the Table component
-    const tableComponent = Table({ data: series, reRenderTable: fetchSeries })
+    const tableComponent = Table({ data: series, reRenderTable })
     tableContainer.appendChild(tableComponent)
   }
 
@@ -54,6 +75,7 @@ const SeriesPage = (parentElement) => {
     // Create a container for the page
     const container = document.createElement('div')
     container.classList.add('series-page')
+    pageElement = container
 
     // Add "Add New" button
     const addNewButton = document.createElement('button')
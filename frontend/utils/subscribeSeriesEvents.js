@@ -0,0 +1,35 @@
+import BASE_URL from './BASE_URL.js'
+
+// Actions streamed by the backend, plus "reset" when changes were missed
+const EVENT_TYPES = [
+  'create',
+  'update',
+  'delete',
+  'restore',
+  'purge',
+  'status',
+  'upvote',
+  'downvote',
+  'episode',
+  'reset',
+]
+
+// Function to listen for series changes, onChange receives the event type and
+// the parsed change. The browser reconnects and resumes on its own.
+const subscribeSeriesEvents = (onChange) => {
+  const source = new EventSource(`${BASE_URL}/series/events`)
+
+  EVENT_TYPES.forEach((type) => {
+    source.addEventListener(type, (event) => {
+      onChange(type, JSON.parse(event.data))
+    })
+  })
+
+  return {
+    // Whether the stream is currently delivering changes
+    isOpen: () => source.readyState === EventSource.OPEN,
+    close: () => source.close(),
+  }
+}
+
+export default subscribeSeriesEvents
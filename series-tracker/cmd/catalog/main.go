@@ -0,0 +1,58 @@
+// Command catalog ingests the IMDb title.basics & title.episode datasets, gzipped
+// or not, into the catalog used to autocomplete & fill in series. The catalog is
+// replaced as a whole. It uses the same DB_* environment variables as the server.
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+
+	"series-tracker/internal/catalog"
+	"series-tracker/internal/database"
+	"series-tracker/internal/repositories"
+)
+
+func main() {
+	basics := flag.String("basics", "title.basics.tsv.gz", "path of the title.basics dataset")
+	episodes := flag.String("episodes", "", "path of the title.episode dataset, episodes aren't counted when empty")
+	types := flag.String("types", strings.Join(catalog.DefaultTypes, ","), "comma-separated title types to keep")
+	flag.Parse()
+
+	file, err := catalog.Open(*basics)
+	if err != nil {
+		log.Fatalf("FATAL: can't open titles: %v", err)
+	}
+	titles, err := catalog.ReadTitles(file, strings.Split(*types, ","))
+	file.Close()
+	if err != nil {
+		log.Fatalf("FATAL: can't read titles: %v", err)
+	}
+
+	if *episodes != "" {
+		file, err := catalog.Open(*episodes)
+		if err != nil {
+			log.Fatalf("FATAL: can't open episodes: %v", err)
+		}
+		counts, err := catalog.CountEpisodes(file)
+		file.Close()
+		if err != nil {
+			log.Fatalf("FATAL: can't read episodes: %v", err)
+		}
+		for i := range titles {
+			titles[i].TotalEpisodes = counts[titles[i].ID]
+		}
+	}
+
+	dbConn, err := database.NewDatabaseConnection()
+	if err != nil {
+		log.Fatalf("FATAL: No db: %v", err)
+	}
+	defer dbConn.Close()
+
+	if err := repositories.NewCatalogRepository(dbConn).ReplaceCatalog(titles); err != nil {
+		log.Fatalf("FATAL: ingestion failed: %v", err)
+	}
+
+	log.Printf("ingested %d titles", len(titles))
+}
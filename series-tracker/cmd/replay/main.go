@@ -0,0 +1,26 @@
+// Command replay rebuilds the series table and snapshots out of the event store,
+// for when the projection drifted or its shape changed. It uses the same DB_*
+// environment variables as the server.
+package main
+
+import (
+	"log"
+
+	"series-tracker/internal/database"
+	"series-tracker/internal/repositories"
+)
+
+func main() {
+	dbConn, err := database.NewDatabaseConnection()
+	if err != nil {
+		log.Fatalf("FATAL: No db: %v", err)
+	}
+	defer dbConn.Close()
+
+	replayed, err := repositories.NewEventSourcedSeriesRepository(dbConn).Replay()
+	if err != nil {
+		log.Fatalf("FATAL: replay failed: %v", err)
+	}
+
+	log.Printf("replayed %d events", replayed)
+}
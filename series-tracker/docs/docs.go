@@ -15,31 +15,61 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/series": {
+        "/api/activity.atom": {
             "get": {
-                "description": "Get a list of all series in the database",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Atom feed of the latest series the owner of the token added, completed or changed the ranking of, most recent first. Opened by the secret token of POST /api/activity/token.",
                 "produces": [
-                    "application/json"
+                    "application/atom+xml"
                 ],
                 "tags": [
-                    "series"
+                    "activity"
+                ],
+                "summary": "Atom feed of an actor's activity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Secret activity feed token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses, only activities leaving their series with one of them",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated kinds of activities: added, completed, ranked",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries, 50 by default and at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Retrieve all series",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Atom feed",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Serie"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid status, kind or limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown or revoked token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -48,7 +78,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -57,48 +87,86 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/activity/token": {
             "post": {
-                "description": "Inserts a new series into the database, make sure the series object includes all the necessary fields.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mints the secret token of the activity feed of the actor given by X-Actor, the returned URL can be shared with friends to follow their progress in a feed reader. Any previous token of the actor stops working. The token isn't shown again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "series"
+                    "activity"
                 ],
-                "summary": "Create a new series",
-                "parameters": [
-                    {
-                        "description": "Series info",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                "summary": "Get a secret activity feed URL",
+                "responses": {
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Serie"
+                            "$ref": "#/definitions/models.FeedToken"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
+                }
+            },
+            "delete": {
+                "description": "The activity feed URL of the actor given by X-Actor stops working",
+                "tags": [
+                    "activity"
                 ],
+                "summary": "Revoke the secret activity feed URL",
                 "responses": {
-                    "201": {
-                        "description": "Newly created series",
+                    "204": {
+                        "description": "No content"
+                    },
+                    "404": {
+                        "description": "The actor has no token",
                         "schema": {
-                            "$ref": "#/definitions/models.Serie"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad request, e.g, invalid input",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/admin/backup": {
+            "get": {
+                "description": "Downloads a zip archive of every table: series, trashed ones included, audit log, event store, webhooks and their deliveries. Each table is a JSON array of rows under tables/, described by manifest.json along with the format, schema version, creation time, row counts and SHA-256 checksums. Every table is read from the same snapshot of the database.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Back up the whole tracker",
+                "responses": {
+                    "200": {
+                        "description": "series-tracker-backup-\u003cdate\u003e.zip",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "500": {
-                        "description": "Internal Server Error, e.g, database error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -109,37 +177,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/series/{id}": {
-            "get": {
-                "description": "Get details of a series using the provided ID",
+        "/api/admin/restore": {
+            "post": {
+                "description": "Replaces every table by the content of a backup archive, sent as the body or as the file of a multipart form. The manifest, checksums and row counts are checked first and backups of older schema versions are migrated forward; a JSON array of series as returned by GET /api/series is read as version 0. Everything is restored in a single transaction, nothing changes when the backup is rejected.",
                 "consumes": [
-                    "application/json"
+                    "application/zip",
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "series"
+                    "admin"
                 ],
-                "summary": "Retrieve a series by ID",
+                "summary": "Restore the whole tracker from a backup",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Series ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "file",
+                        "description": "Backup archive, when sent as a multipart form",
+                        "name": "file",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Rows restored per table",
                         "schema": {
-                            "$ref": "#/definitions/models.Serie"
+                            "$ref": "#/definitions/models.RestoreReport"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Missing, corrupted or unsupported backup",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Backup larger than 100MB",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -148,7 +225,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -157,9 +234,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates details of an existing series",
+            }
+        },
+        "/api/audit": {
+            "get": {
+                "description": "Get the recorded series mutations with before/after snapshots and a diff of the changed fields, most recent first. Every filter is optional.",
                 "consumes": [
                     "application/json"
                 ],
@@ -167,27 +246,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "series"
+                    "audit"
                 ],
-                "summary": "Update an existing series",
+                "summary": "Retrieve the audit log",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Series ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only entries for this series ID",
+                        "name": "serieId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries by this actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries for this action, e.g. create, update, delete, status, upvote",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries triggered by this request ID",
+                        "name": "requestId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries recorded at or after this RFC 3339 timestamp",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries recorded before this RFC 3339 timestamp",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries, at most 1000",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Serie"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid filter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -196,7 +313,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -205,9 +322,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Inserts a new series into the database, make sure the series object includes all the necessary fields.",
+            }
+        },
+        "/api/cache/stats": {
+            "get": {
+                "description": "Get the hit/miss counters and size of the in-process series cache",
                 "consumes": [
                     "application/json"
                 ],
@@ -215,24 +334,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "series"
+                    "cache"
+                ],
+                "summary": "Retrieve series cache counters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CacheStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/calendar.ics": {
+            "get": {
+                "description": "RFC 5545 feed of the episodes airing and the planned watch sessions of the series being or planned to be watched, from 30 days ago to 180 days ahead. Opened by the secret token of POST /api/calendar/token.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
                 ],
-                "summary": "Remove an existing series from the database",
+                "summary": "iCalendar feed of the watch plan",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Series ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Secret calendar token",
+                        "name": "token",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No content"
+                    "200": {
+                        "description": "series-tracker.ics",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
-                    "400": {
-                        "description": "Bad request, e.g, invalid input",
+                    "404": {
+                        "description": "Unknown or revoked token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -241,7 +383,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error, e.g, database error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -252,46 +394,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/series/{id}/downvote": {
-            "patch": {
-                "description": "Decreases the rating (score) of the series with the specified ID by one vote.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/calendar/token": {
+            "post": {
+                "description": "Mints the secret token of the calendar feed of the actor given by X-Actor, the returned URL can be subscribed to from calendar apps. Any previous token of the actor stops working. The token isn't shown again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "series"
-                ],
-                "summary": "Decrease series score",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Series ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "calendar"
                 ],
+                "summary": "Get a secret calendar URL",
                 "responses": {
-                    "200": {
-                        "description": "Series successfully downvoted",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Serie"
+                            "$ref": "#/definitions/models.FeedToken"
                         }
                     },
-                    "400": {
-                        "description": "Invalid series ID",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The calendar URL of the actor given by X-Actor stops working",
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Revoke the secret calendar URL",
+                "responses": {
+                    "204": {
+                        "description": "No content"
                     },
                     "404": {
-                        "description": "Series not found",
+                        "description": "The actor has no token",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -311,46 +453,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/series/{id}/episode": {
-            "patch": {
-                "description": "Increments the current episode number of a series by one",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/catalog": {
+            "get": {
+                "description": "Returns the catalog titles starting with q, ignoring case, the exact match first then the ones with the most episodes. The catalog is ingested from the IMDb datasets by the catalog command.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "series"
+                    "catalog"
                 ],
-                "summary": "Advance series episode count",
+                "summary": "Autocomplete titles from the catalog",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Series ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Start of the title",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max titles returned, 10 by default \u0026 50 at most",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated series status",
-                        "schema": {
-                            "$ref": "#/definitions/models.Serie"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid input or status value",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CatalogTitle"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Series not found",
+                    "400": {
+                        "description": "Missing q or invalid limit",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -370,23 +509,137 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/series/{id}/status": {
-            "patch": {
-                "description": "Updates the status of the series with the specified ID.",
-                "consumes": [
-                    "application/json"
+        "/api/export.csv": {
+            "get": {
+                "description": "Downloads every series, trashed ones left out, as a CSV file with a header row of the series' JSON field names. The file can be imported back as is.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Export every series as CSV",
+                "responses": {
+                    "200": {
+                        "description": "series.csv",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import": {
+            "post": {
+                "description": "Creates or updates a series per row, matching existing series by title ignoring case. The header row names the field of each column (title, ranking, status, lastEpisodeWatched, totalEpisodes, year, genres separated by semicolons), other headers are ignored unless mapped. Empty cells keep the current value, or the default on creation. Rows that can't be imported are reported one by one while the rest are applied together; with dryRun nothing is written.",
+                "consumes": [
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "series"
+                    "import"
                 ],
-                "summary": "Update series status",
+                "summary": "Import series from a CSV file",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Series ID",
+                        "type": "file",
+                        "description": "CSV file with a header row",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what would be created, updated or skipped",
+                        "name": "dryRun",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "update",
+                            "skip",
+                            "rename"
+                        ],
+                        "type": "string",
+                        "description": "What to do with rows whose title is taken: update (default), skip or rename",
+                        "name": "onConflict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping headers to fields, e.g. {\\",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field delimiter, a comma by default",
+                        "name": "delimiter",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Outcome of every row",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or unreadable file, invalid options or too many rows",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "File larger than 10MB",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/jobs/{id}": {
+            "get": {
+                "description": "Get the progress of a background import, along with its report once it succeeded or its error once it failed. Finished jobs are kept for an hour.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Get an import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -394,13 +647,90 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated series status",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Serie"
+                            "$ref": "#/definitions/models.ImportJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/{format}": {
+            "post": {
+                "description": "Queues the import of a file of the given format \u0026 answers right away with the job to poll, its Location header pointing at it. Rows create or update series matched by title ignoring case; rows that can't be imported are reported one by one while the rest are applied together, and with dryRun nothing is written. Imports run one at a time.\nFormats: csv (header row of field names, settings: mapping, a JSON object of headers to fields, and delimiter), mal (MyAnimeList XML export, gzipped or not), trakt (Trakt JSON backup of the watched, watchlist or ratings lists, alone or keyed by list name) and json (array of series as returned by GET /api/series, alone or under \"series\"). Other form values are passed to the format as settings.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import a file in the background",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "mal",
+                            "trakt",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "Format of the file",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to import",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what would be created, updated or skipped",
+                        "name": "dryRun",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "update",
+                            "skip",
+                            "rename"
+                        ],
+                        "type": "string",
+                        "description": "What to do with rows whose title is taken: update (default), skip or rename",
+                        "name": "onConflict",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Queued job",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportJob"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid input or status value",
+                        "description": "Missing file or invalid options",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -409,7 +739,16 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Series not found",
+                        "description": "Unknown format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "File larger than 10MB",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -429,37 +768,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/series/{id}/upvote": {
-            "patch": {
-                "description": "Increases the rating (score) of the series with the specified ID by one vote.",
+        "/api/series": {
+            "get": {
+                "description": "Get a list of all series in the database, in the format picked by the Accept header. NDJSON streams one series per line.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/x-ndjson"
                 ],
                 "tags": [
                     "series"
                 ],
-                "summary": "Increase series score",
+                "summary": "Retrieve all series",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Serie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "None of the accepted formats is supported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Inserts a new series into the database, make sure the series object includes all the necessary fields. Total episodes, year \u0026 genres left out are filled in from the catalog when the title matches one exactly. The body may be JSON, CSV (a header \u0026 a single row), YAML or MessagePack, the response follows the Accept header.",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Create a new series",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Series ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Series info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Series successfully upvoted",
+                    "201": {
+                        "description": "Newly created series",
                         "schema": {
                             "$ref": "#/definitions/models.Serie"
                         }
                     },
                     "400": {
-                        "description": "Invalid series ID",
+                        "description": "Bad request, e.g, invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -467,8 +870,26 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Series not found",
+                    "406": {
+                        "description": "None of the accepted formats is supported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Title already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported body format",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -477,7 +898,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error, e.g, database error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -487,35 +908,3403 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Serie": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "description": "Unique identifier for the series",
-                    "type": "integer"
-                },
-                "lastEpisodeWatched": {
-                    "description": "Last episode watched of the series",
-                    "type": "integer"
-                },
-                "ranking": {
-                    "description": "Score of the series used for ranking",
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "Current status of the series; \"Watching\", \"Plan to Watch\", \"Dropped\", \"Completed\"",
-                    "type": "string"
-                },
-                "title": {
-                    "description": "Title of the series",
-                    "type": "string"
-                },
-                "totalEpisodes": {
-                    "description": "Quantity of episodes in the series",
-                    "type": "integer"
+        },
+        "/api/series/bulk": {
+            "post": {
+                "description": "Runs a list of create, update, delete, status and episode operations in order. With atomic set they run in one transaction and a single failure rolls all of them back, otherwise each one is applied on its own. Every operation gets its own result.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Run several series operations at once",
+                "parameters": [
+                    {
+                        "description": "Operations to run",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Every operation was run, check each result in best-effort mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, with error set \u0026 no results, or an atomic run failed on an invalid operation",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "An atomic run failed on a missing series",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An atomic run failed on a taken title",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/events": {
+            "get": {
+                "description": "Server-Sent Events stream of every committed series change, named after the action (\"create\", \"update\", \"delete\", \"status\", \"upvote\", ...) and carrying a models.SerieChange. Event IDs are the server's epoch and the change's seq, as in \"3f9a0c1d2e4b5a69-42\". Reconnecting with Last-Event-ID, or the lastEventId query parameter, resumes after that event; a \"reset\" event is sent instead when changes were missed, including across server restarts, and the client should reload.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Stream series changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, for clients that can't set headers",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SerieChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}": {
+            "get": {
+                "description": "Get details of a series using the provided ID, in the format picked by the Accept header",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Retrieve a series by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "None of the accepted formats is supported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces every field of an existing series, use PATCH to change only some of them. The body may be JSON, CSV (a header \u0026 a single row), YAML or MessagePack, the response follows the Accept header.",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/yaml",
+                    "application/msgpack",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Update an existing series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Series info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "None of the accepted formats is supported",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Title already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported body format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft deletes a series, it stops showing up in the series list but can be restored from the trash until it's purged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Move an existing series to the trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad request, e.g, invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error, e.g, database error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes only the fields present in the patch. Send an RFC 7396 merge patch as application/merge-patch+json (or application/json), or an RFC 6902 JSON Patch as application/json-patch+json.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Partially update an existing series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch or JSON Patch document",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully patched series",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid patch or resulting series",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Title already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported patch format",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/downvote": {
+            "patch": {
+                "description": "Decreases the rating (score) of the series with the specified ID by one vote.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Decrease series score",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series successfully downvoted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid series ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/episode": {
+            "patch": {
+                "description": "Increments the current episode number of a series by one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Advance series episode count",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated series status",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or status value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/restore": {
+            "post": {
+                "description": "Takes the series with the specified ID out of the trash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a trashed series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series successfully restored",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid series ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found in the trash",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/schedule": {
+            "get": {
+                "description": "Get when the episodes of a series air",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get the airing schedule of a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AiringSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series or schedule not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Creates or replaces when the episodes of a series air: firstEpisode (1 by default) airs at firstAirAt and the next ones every everyDays days (7 by default), each lasting runtimeMinutes (30 by default). Episodes past the total of the series aren't shown in the calendar.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Set the airing schedule of a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Airing schedule, serieId is taken from the path",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AiringSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AiringSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or schedule",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Its episodes no longer show up in the calendar",
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Remove the airing schedule of a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series or schedule not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/sessions": {
+            "get": {
+                "description": "Get every planned session of watching a series, ordered by start",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "List the watch sessions of a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WatchSession"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Plans a session of watching episodes episodes (1 by default) of a series at startsAt, lasting minutes (the runtime of the episodes by default). Upcoming sessions show in the calendar with the episodes they cover, following on from the last one watched.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Plan a watch session of a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Watch session, only startsAt, episodes and minutes are read",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchSession"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or session",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/sessions/{sessionId}": {
+            "delete": {
+                "description": "Removes a planned session of watching a series",
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Cancel a watch session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series or session not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/status": {
+            "patch": {
+                "description": "Updates the status of the series with the specified ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Update series status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated series status",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or status value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/series/{id}/upvote": {
+            "patch": {
+                "description": "Increases the rating (score) of the series with the specified ID by one vote.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Increase series score",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series successfully upvoted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid series ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shares": {
+            "get": {
+                "description": "Get the share links of the actor given by X-Actor, expired ones included, newest first. Tokens aren't included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "List share links",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ShareLink"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Mints a public, read-only link to the series list for the actor given by X-Actor. The view can be narrowed to some statuses, leave rankings out and expire. The token is returned here and isn't shown again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Create a share link",
+                "parameters": [
+                    {
+                        "description": "Share link filter",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ShareLinkInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created link, token included",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShareLink"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status or expiry",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shares/{id}": {
+            "delete": {
+                "description": "The share link of the actor given by X-Actor stops working",
+                "tags": [
+                    "share"
+                ],
+                "summary": "Revoke a share link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Share link ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Share link not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sync": {
+            "get": {
+                "description": "Delta sync for offline-capable clients: returns the series created, updated and deleted (tombstones) since the given token along with the token to pass next time. Without a token, or when the token can't be synced from anymore (e.g. after a restore), every series is returned as created with reset set and the client must replace its copy.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Pull the series changed since a sync token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token returned by the previous sync",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncChanges"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Applies create, update, delete, status and episode edits made on top of a sync token, each on its own. Edits of a series changed on the server since the token are left out as conflicts along with the server's version of the series, nil once deleted, so are edits giving a series a title taken on the server, with the reason. Pull again afterwards to get the resulting state and a new token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Push edits queued while offline",
+                "parameters": [
+                    {
+                        "description": "Queued edits",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncPush"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Every edit was tried, check each result",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncPushResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid, missing or stale token, or no edits",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash": {
+            "get": {
+                "description": "Get a list of all series in the trash, most recently deleted first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Retrieve trashed series",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Serie"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/{id}": {
+            "delete": {
+                "description": "Removes the series with the specified ID from the trash and the database, this can't be undone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Permanently delete a trashed series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid series ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found in the trash",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/series": {
+            "get": {
+                "description": "Get every series that isn't in the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List series",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v2.Series"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a new series",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create a series",
+                "parameters": [
+                    {
+                        "description": "Series info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.SeriesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Series"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/series/{id}": {
+            "get": {
+                "description": "Get a single series by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Series"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces every field of an existing series",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Replace a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Series info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.SeriesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Series"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Moves a series to the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Delete a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/series/{id}/downvote": {
+            "post": {
+                "description": "Decreases the score of a series by one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Downvote a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Series"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/series/{id}/next-episode": {
+            "post": {
+                "description": "Increases the watched episode count of a series by one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Advance to the next episode",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Series"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/series/{id}/status": {
+            "put": {
+                "description": "Changes the status of a series",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Set a series' status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v2.StatusInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Series"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/series/{id}/upvote": {
+            "post": {
+                "description": "Increases the score of a series by one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Upvote a series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v2.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v2.Series"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v2.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "get": {
+                "description": "Get every registered webhook, secrets aren't included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a URL notified of series changes. Deliveries are POSTed as a models.WebhookEvent and signed in the X-Webhook-Signature header as \"t=\u003cunix seconds\u003e,v1=\u003chex HMAC-SHA256 of '\u003ct\u003e.\u003cbody\u003e'\u003e\" with the secret returned here, which isn't shown again. Events: series.created, series.updated, series.deleted, series.restored, series.purged, series.status_changed, series.completed, series.upvoted, series.downvoted, episode.incremented or * for all.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Registered webhook, secret included",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL or events",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/deliveries/{id}/replay": {
+            "post": {
+                "description": "Queues a new delivery of the same event to the same webhook, sent with a new delivery ID but the original payload",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Replay a delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Queued delivery",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}": {
+            "get": {
+                "description": "Get a single webhook by its ID, its secret isn't included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unregisters a webhook, its pending deliveries and delivery log go with it",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Get the latest deliveries to a webhook with their status, attempts and the response code of their latest attempt, most recent first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retrieve a webhook's delivery log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries, at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or limit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ws": {
+            "get": {
+                "description": "Upgrades to a WebSocket exchanging handlers.WSMessage from the client and handlers.WSReply from the server. Clients subscribe to series or status lists and receive every matching committed change, and can run the episode, upvote, downvote and status commands, each acknowledged by ID. Browsers can pass the actor as a query parameter.",
+                "tags": [
+                    "series"
+                ],
+                "summary": "Live sync over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Who performs the commands, overrides X-Actor",
+                        "name": "actor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "get": {
+                "description": "Runs a query or mutation against the series schema, GET only allows queries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Run a GraphQL operation",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gql.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Runs a query or mutation against the series schema, GET only allows queries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Run a GraphQL operation",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/gql.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/share/{token}": {
+            "get": {
+                "description": "Public, read-only view of the series list opened by the token of a share link, filtered as the link says. Series IDs and trashed series aren't shown.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "View a shared series list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedList"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown, revoked or expired link",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "gql.Request": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "models.AiringSchedule": {
+            "type": "object",
+            "properties": {
+                "everyDays": {
+                    "description": "Days between two episodes, 7 by default",
+                    "type": "integer"
+                },
+                "firstAirAt": {
+                    "description": "Moment FirstEpisode airs",
+                    "type": "string"
+                },
+                "firstEpisode": {
+                    "description": "Episode airing at FirstAirAt, 1 by default",
+                    "type": "integer"
+                },
+                "runtimeMinutes": {
+                    "description": "Length of an episode, 30 by default",
+                    "type": "integer"
+                },
+                "serieId": {
+                    "description": "Series the schedule belongs to",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Mutation performed; \"create\", \"update\", \"delete\", \"status\", \"upvote\", ...",
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "Who performed the mutation",
+                    "type": "string"
+                },
+                "after": {
+                    "description": "Series after the mutation, nil on deletion",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                },
+                "before": {
+                    "description": "Series before the mutation, nil on creation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                },
+                "changes": {
+                    "description": "Fields that changed keyed by their JSON name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "createdAt": {
+                    "description": "Moment the mutation was recorded",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique identifier for the entry",
+                    "type": "integer"
+                },
+                "requestId": {
+                    "description": "ID of the request that triggered the mutation",
+                    "type": "string"
+                },
+                "serieId": {
+                    "description": "ID of the mutated series",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BulkOperation": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID of the series to operate on, unused by create",
+                    "type": "integer"
+                },
+                "op": {
+                    "description": "Operation to run; \"create\", \"update\", \"delete\", \"status\", \"episode\"",
+                    "type": "string"
+                },
+                "serie": {
+                    "description": "Series values, used by create and update",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "New status, used by status",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BulkRequest": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "description": "Run every operation in one transaction, all-or-nothing, instead of best-effort",
+                    "type": "boolean"
+                },
+                "operations": {
+                    "description": "Operations to run, in order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkOperation"
+                    }
+                }
+            }
+        },
+        "models.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "description": "Whether operations ran in one transaction",
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Why the request as a whole was rejected, no operation ran then",
+                    "type": "string"
+                },
+                "failed": {
+                    "description": "Number of operations that weren't applied",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Outcome of every operation, in request order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkResult"
+                    }
+                },
+                "succeeded": {
+                    "description": "Number of applied operations",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BulkResult": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "Whether the operation's changes were kept",
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Why the operation failed or wasn't applied",
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Position of the operation in the request",
+                    "type": "integer"
+                },
+                "op": {
+                    "description": "Operation that was run",
+                    "type": "string"
+                },
+                "serie": {
+                    "description": "Resulting series, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "HTTP status code the operation would have gotten on its own",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CacheStats": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "Maximum number of entries cached",
+                    "type": "integer"
+                },
+                "entries": {
+                    "description": "Entries currently cached",
+                    "type": "integer"
+                },
+                "hits": {
+                    "description": "Lookups answered from the cache",
+                    "type": "integer"
+                },
+                "misses": {
+                    "description": "Lookups that had to go to the wrapped repository",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CatalogTitle": {
+            "type": "object",
+            "properties": {
+                "endYear": {
+                    "description": "Year it ended, 0 when unknown or still airing",
+                    "type": "integer"
+                },
+                "genres": {
+                    "description": "Genres of the title",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Identifier of the title in the dataset, e.g. tt0903747",
+                    "type": "string"
+                },
+                "originalTitle": {
+                    "description": "Title in the original language",
+                    "type": "string"
+                },
+                "startYear": {
+                    "description": "Year it started airing, 0 when unknown",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "Main title",
+                    "type": "string"
+                },
+                "totalEpisodes": {
+                    "description": "Quantity of episodes listed in the dataset, 0 when unknown",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Kind of title, e.g. tvSeries",
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedToken": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Moment the token was created",
+                    "type": "string"
+                },
+                "feed": {
+                    "description": "Feed the token opens; \"calendar\", \"activity\"",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Secret token",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Path of the feed, token included",
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "Value before the mutation"
+                },
+                "to": {
+                    "description": "Value after the mutation"
+                }
+            }
+        },
+        "models.ImportJob": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Moment the job was queued",
+                    "type": "string"
+                },
+                "dryRun": {
+                    "description": "Whether the import only reports what it would do",
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Why the import failed",
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "description": "Moment the job succeeded or failed",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Format of the imported file",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique identifier for the job",
+                    "type": "string"
+                },
+                "processed": {
+                    "description": "Number of rows imported so far",
+                    "type": "integer"
+                },
+                "report": {
+                    "description": "Outcome of the import once it succeeded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ImportReport"
+                        }
+                    ]
+                },
+                "startedAt": {
+                    "description": "Moment the job started running",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Progress of the job; \"queued\", \"running\", \"succeeded\", \"failed\"",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Number of rows read from the file, 0 until it's read",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportReport": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Number of series created, renamed ones included",
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "Whether nothing was actually written",
+                    "type": "boolean"
+                },
+                "failed": {
+                    "description": "Number of rejected rows",
+                    "type": "integer"
+                },
+                "ignoredColumns": {
+                    "description": "Columns of the file not mapped to any field",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "renamed": {
+                    "description": "Number of series created under a new title since theirs was taken",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Outcome of every row, in file order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportResult"
+                    }
+                },
+                "skipped": {
+                    "description": "Number of rows left out as they wouldn't change anything or their title is taken",
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Number of series updated",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "What happened, or would happen on a dry run; \"create\", \"update\", \"rename\", \"skip\", \"error\"",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Why the row was rejected",
+                    "type": "string"
+                },
+                "line": {
+                    "description": "Line of the file the row starts on",
+                    "type": "integer"
+                },
+                "serie": {
+                    "description": "Resulting series, without ID when it would be created by a dry run. Renamed ones hold their new title.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "Title read from the row",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RestoreReport": {
+            "type": "object",
+            "properties": {
+                "backedUpAt": {
+                    "description": "Moment the backup was taken, unknown for version 0",
+                    "type": "string"
+                },
+                "migrated": {
+                    "description": "Whether the backup was migrated forward to the current version",
+                    "type": "boolean"
+                },
+                "tables": {
+                    "description": "Rows restored per table",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "version": {
+                    "description": "Schema version the backup was taken with",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Serie": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "description": "Moment the series was moved to the trash, nil if it isn't trashed",
+                    "type": "string"
+                },
+                "genres": {
+                    "description": "Genres of the series; \"Drama\", \"Comedy\", ...",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Unique identifier for the series",
+                    "type": "integer"
+                },
+                "lastEpisodeWatched": {
+                    "description": "Last episode watched of the series",
+                    "type": "integer"
+                },
+                "ranking": {
+                    "description": "Score of the series used for ranking",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Current status of the series; \"Watching\", \"Plan to Watch\", \"Dropped\", \"Completed\"",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title of the series",
+                    "type": "string"
+                },
+                "totalEpisodes": {
+                    "description": "Quantity of episodes in the series",
+                    "type": "integer"
+                },
+                "year": {
+                    "description": "Year the series started airing, 0 when unknown",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SerieChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Mutation performed; \"create\", \"update\", \"delete\", \"status\", \"upvote\", ...",
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "Who performed the mutation",
+                    "type": "string"
+                },
+                "at": {
+                    "description": "Moment the mutation was recorded",
+                    "type": "string"
+                },
+                "before": {
+                    "description": "Series before the mutation, nil on creation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                },
+                "requestId": {
+                    "description": "ID of the request that triggered the mutation",
+                    "type": "string"
+                },
+                "seq": {
+                    "description": "Position in the change stream, increasing by one per change",
+                    "type": "integer"
+                },
+                "serie": {
+                    "description": "Series after the mutation, nil once deleted or purged",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                },
+                "serieId": {
+                    "description": "ID of the mutated series",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SerieTombstone": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "description": "Moment the series was moved to the trash",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the deleted series",
+                    "type": "integer"
+                },
+                "purged": {
+                    "description": "Whether the series is gone for good instead of in the trash",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ShareLink": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Moment the link was created",
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "Moment the link stops working, nil if it never expires",
+                    "type": "string"
+                },
+                "hideRankings": {
+                    "description": "Whether rankings are left out of the view",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "Unique identifier for the link",
+                    "type": "integer"
+                },
+                "statuses": {
+                    "description": "Only series with one of these statuses are shown, all when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "description": "Secret token",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Path of the shared view, token included",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ShareLinkInput": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "Moment the link stops working, nil if it never expires",
+                    "type": "string"
+                },
+                "hideRankings": {
+                    "description": "Whether rankings are left out of the view",
+                    "type": "boolean"
+                },
+                "statuses": {
+                    "description": "Only series with one of these statuses are shown, all when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.SharedList": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "Moment the link stops working, nil if it never expires",
+                    "type": "string"
+                },
+                "series": {
+                    "description": "Series shown, best ranked first or by title when rankings are hidden",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SharedSerie"
+                    }
+                }
+            }
+        },
+        "models.SharedSerie": {
+            "type": "object",
+            "properties": {
+                "lastEpisodeWatched": {
+                    "description": "Last episode watched of the series",
+                    "type": "integer"
+                },
+                "ranking": {
+                    "description": "Score of the series, nil when the link hides rankings",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Current status of the series",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title of the series",
+                    "type": "string"
+                },
+                "totalEpisodes": {
+                    "description": "Quantity of episodes in the series",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SyncChanges": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Series created since the token, every series on a reset",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Serie"
+                    }
+                },
+                "deleted": {
+                    "description": "Series trashed or purged since the token",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SerieTombstone"
+                    }
+                },
+                "reset": {
+                    "description": "Whether the client must replace its copy by created, on a first sync or when its token can't be synced from anymore",
+                    "type": "boolean"
+                },
+                "token": {
+                    "description": "Token to sync from next time",
+                    "type": "string"
+                },
+                "updated": {
+                    "description": "Series changed since the token, restored ones included",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Serie"
+                    }
+                }
+            }
+        },
+        "models.SyncPush": {
+            "type": "object",
+            "properties": {
+                "edits": {
+                    "description": "Edits to apply, in order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkOperation"
+                    }
+                },
+                "token": {
+                    "description": "Token the client synced from before making the edits",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SyncPushResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "Number of edits applied",
+                    "type": "integer"
+                },
+                "conflicts": {
+                    "description": "Number of edits left out because the series changed on the server",
+                    "type": "integer"
+                },
+                "failed": {
+                    "description": "Number of edits that failed",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Outcome of every edit, in push order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SyncResult"
+                    }
+                }
+            }
+        },
+        "models.SyncResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Why the edit wasn't applied",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID of the series edited, unused by create",
+                    "type": "integer"
+                },
+                "index": {
+                    "description": "Position of the edit in the push",
+                    "type": "integer"
+                },
+                "op": {
+                    "description": "Operation of the edit",
+                    "type": "string"
+                },
+                "outcome": {
+                    "description": "\"applied\", \"conflict\" or \"failed\"",
+                    "type": "string"
+                },
+                "serie": {
+                    "description": "Series after the edit once applied, the server's version on a conflict, nil once deleted or on a taken title",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Serie"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.WatchSession": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Moment the session was planned",
+                    "type": "string"
+                },
+                "episodes": {
+                    "description": "Episodes planned to be watched, 1 by default",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Unique identifier for the session",
+                    "type": "integer"
+                },
+                "minutes": {
+                    "description": "Length of the session, the runtime of the episodes by default",
+                    "type": "integer"
+                },
+                "serieId": {
+                    "description": "Series planned to be watched",
+                    "type": "integer"
+                },
+                "startsAt": {
+                    "description": "Moment the session starts",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Moment the webhook was registered",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "Events delivered; \"series.created\", \"episode.incremented\", ... or \"*\" for all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Unique identifier for the webhook",
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Key of the HMAC-SHA256 signature sent with every delivery",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Where deliveries are POSTed",
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "Attempts made so far",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "Moment the delivery was queued",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Why the latest attempt failed",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "Event delivered",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Unique identifier for the delivery",
+                    "type": "integer"
+                },
+                "lastAttemptAt": {
+                    "description": "Moment of the latest attempt",
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "description": "Moment of the next attempt while pending",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Body POSTed to the webhook",
+                    "type": "object"
+                },
+                "replayOf": {
+                    "description": "Delivery this one replays",
+                    "type": "integer"
+                },
+                "responseCode": {
+                    "description": "HTTP status of the latest attempt, nil if it got no response",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "\"pending\", \"succeeded\" or \"failed\"",
+                    "type": "string"
+                },
+                "webhookId": {
+                    "description": "Webhook the event is delivered to",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WebhookInput": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "description": "Events delivered, \"*\" for all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "description": "Where deliveries are POSTed, http or https",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.Envelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "The requested resource or list of resources"
+                },
+                "meta": {
+                    "description": "Extra information about lists",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v2.Meta"
+                        }
+                    ]
+                }
+            }
+        },
+        "v2.ErrorBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Machine readable error code; \"invalid_input\", \"not_found\", \"internal\", ...",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Human readable description",
+                    "type": "string"
+                }
+            }
+        },
+        "v2.ErrorEnvelope": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/v2.ErrorBody"
+                }
+            }
+        },
+        "v2.Meta": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Number of items in data",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.Progress": {
+            "type": "object",
+            "properties": {
+                "total": {
+                    "description": "Quantity of episodes in the series",
+                    "type": "integer"
+                },
+                "watched": {
+                    "description": "Last episode watched",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.Series": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "description": "Moment the series was trashed, absent if it isn't",
+                    "type": "string"
+                },
+                "genres": {
+                    "description": "Genres of the series",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "Unique identifier for the series",
+                    "type": "integer"
+                },
+                "progress": {
+                    "description": "Episode progress",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v2.Progress"
+                        }
+                    ]
+                },
+                "score": {
+                    "description": "Score of the series used for ranking",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status code; \"watching\", \"plan_to_watch\", \"dropped\", \"completed\"",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title of the series",
+                    "type": "string"
+                },
+                "year": {
+                    "description": "Year the series started airing, absent if unknown",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.SeriesInput": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "description": "Genres of the series, kept when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "progress": {
+                    "description": "Episode progress",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v2.Progress"
+                        }
+                    ]
+                },
+                "score": {
+                    "description": "Score of the series used for ranking",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status code; \"watching\", \"plan_to_watch\", \"dropped\", \"completed\"",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title of the series",
+                    "type": "string"
+                },
+                "year": {
+                    "description": "Year the series started airing, kept when 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "v2.StatusInput": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "Status code; \"watching\", \"plan_to_watch\", \"dropped\", \"completed\"",
+                    "type": "string"
                 }
             }
         }
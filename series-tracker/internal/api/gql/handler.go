@@ -0,0 +1,100 @@
+package gql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"series-tracker/internal/services"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler holds all the dependencies for the GraphQL handler
+type Handler struct {
+	schema graphql.Schema
+	audit  services.AuditService
+}
+
+// NewHandler returns a new Handler serving a schema over the given services
+func NewHandler(series services.SeriesService, audit services.AuditService) (*Handler, error) {
+	schema, err := NewSchema(series, audit)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{
+		schema: schema,
+		audit:  audit,
+	}, nil
+}
+
+// Request represents a GraphQL request, sent as a JSON body or as query parameters
+type Request struct {
+	Query         string         `json:"query" query:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName" query:"operationName"`
+}
+
+// Serve godoc
+// @Summary      Run a GraphQL operation
+// @Description  Runs a query or mutation against the series schema, GET only allows queries
+// @Tags         graphql
+// @Accept       json
+// @Produce      json
+// @Param        body  body      gql.Request  true  "GraphQL request"
+// @Success      200   {object}  map[string]interface{}
+// @Failure      400   {object}  map[string]string
+// @Router       /graphql [post]
+// @Router       /graphql [get]
+func (h *Handler) Serve(c echo.Context) error {
+	var req Request
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid body"})
+	}
+	if req.Query == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing query"})
+	}
+	if c.Request().Method == http.MethodGet {
+		if variables := c.QueryParam("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid variables"})
+			}
+		}
+	}
+
+	params := graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        withLoaders(c.Request().Context(), h.audit),
+	}
+
+	// Mutations over GET could be triggered by a plain link
+	if c.Request().Method == http.MethodGet && isMutation(params) {
+		return c.JSON(http.StatusMethodNotAllowed, map[string]string{"error": "mutations require POST"})
+	}
+
+	return c.JSON(http.StatusOK, graphql.Do(params))
+}
+
+// isMutation reports whether the operation to run is a mutation, unparseable
+// requests are left for graphql.Do to report
+func isMutation(params graphql.Params) bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: params.RequestString})
+	if err != nil {
+		return false
+	}
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if params.OperationName == "" || (op.Name != nil && op.Name.Value == params.OperationName) {
+			return op.Operation == ast.OperationTypeMutation
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// listSeriesService lists fixed series, any other call panics
+type listSeriesService struct {
+	services.SeriesService
+	series []models.Serie
+}
+
+func (s listSeriesService) GetAllSeries(ctx context.Context) ([]models.Serie, error) {
+	return s.series, nil
+}
+
+// countingAuditService records the IDs of every GetHistories call, giving each
+// series a single entry
+type countingAuditService struct {
+	services.AuditService
+	calls [][]int
+}
+
+func (s *countingAuditService) GetHistories(ids []int) (map[int][]models.AuditEntry, error) {
+	s.calls = append(s.calls, ids)
+	histories := map[int][]models.AuditEntry{}
+	for _, id := range ids {
+		histories[id] = []models.AuditEntry{{ID: id, Action: "create", SerieID: id}}
+	}
+	return histories, nil
+}
+
+func TestSeriesHistoriesAreBatched(t *testing.T) {
+	const n = 5
+	var series []models.Serie
+	for id := 1; id <= n; id++ {
+		series = append(series, models.Serie{ID: id, Title: strings.Repeat("a", id), Status: "Watching", Ranking: id})
+	}
+	audit := &countingAuditService{}
+	handler, err := NewHandler(listSeriesService{series: series}, audit)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	body := `{"query": "{ series { items { id history { id } } } }"}`
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	if err := handler.Serve(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	var res struct {
+		Data struct {
+			Series struct {
+				Items []struct {
+					ID      int
+					History []struct{ ID int }
+				}
+			}
+		}
+		Errors []any
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || len(res.Errors) > 0 {
+		t.Fatalf("response %s: %v", rec.Body, err)
+	}
+	if items := res.Data.Series.Items; len(items) != n {
+		t.Fatalf("got %d series, want %d", len(items), n)
+	}
+	for _, item := range res.Data.Series.Items {
+		if len(item.History) != 1 || item.History[0].ID != item.ID {
+			t.Errorf("series %d got history %v", item.ID, item.History)
+		}
+	}
+
+	if len(audit.calls) != 1 {
+		t.Fatalf("GetHistories called %d times, want once", len(audit.calls))
+	}
+	ids := append([]int(nil), audit.calls[0]...)
+	sort.Ints(ids)
+	if !reflect.DeepEqual(ids, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("GetHistories got %v, want every series", audit.calls[0])
+	}
+}
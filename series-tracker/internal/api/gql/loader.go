@@ -0,0 +1,64 @@
+package gql
+
+import "sync"
+
+// Loader batches lookups made while resolving a single GraphQL request. Resolvers
+// queue their key and return the thunk given by Load, graphql-go runs thunks after
+// resolving the rest of the level so the first one loads every queued key at once.
+type Loader[K comparable, V any] struct {
+	batch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	loaded  map[K]V
+	errs    map[K]error
+}
+
+// NewLoader returns a Loader fetching keys with the given batch function
+func NewLoader[K comparable, V any](batch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:  batch,
+		queued: map[K]bool{},
+		loaded: map[K]V{},
+		errs:   map[K]error{},
+	}
+}
+
+// Load queues key and returns a thunk resolving to its value
+func (l *Loader[K, V]) Load(key K) func() (any, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			l.flush()
+		}
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		return l.loaded[key], nil
+	}
+}
+
+// flush loads every pending key in a single batch, must be called with mu held
+func (l *Loader[K, V]) flush() {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.batch(keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		l.loaded[key] = values[key]
+	}
+}
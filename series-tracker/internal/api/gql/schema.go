@@ -0,0 +1,376 @@
+// Package gql serves a GraphQL API over the series domain, queries and mutations
+// map onto the same services as the REST API.
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Default & maximum page sizes of the series query
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// loadersKey is the context key the per-request loaders are stored under
+type loadersKey struct{}
+
+// loaders holds the batching loaders of a single request
+type loaders struct {
+	history *Loader[int, []models.AuditEntry]
+}
+
+// withLoaders returns a copy of ctx carrying fresh loaders for one request
+func withLoaders(ctx context.Context, audit services.AuditService) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		history: NewLoader(audit.GetHistories),
+	})
+}
+
+// loadersFrom returns the loaders carried by ctx
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// resolverError hides internal failures from clients, only client mistakes keep
+// their message
+func resolverError(err error) error {
+	if errors.Is(err, services.ErrInvalidInput) || errors.Is(err, services.ErrNotFound) || errors.Is(err, services.ErrConflict) {
+		return err
+	}
+	return errors.New("internal server error")
+}
+
+// NewSchema builds the GraphQL schema over the given services
+func NewSchema(series services.SeriesService, audit services.AuditService) (graphql.Schema, error) {
+	statusEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "SerieStatus",
+		Description: "Status of a series",
+		Values: graphql.EnumValueConfigMap{
+			"WATCHING":      &graphql.EnumValueConfig{Value: "Watching"},
+			"PLAN_TO_WATCH": &graphql.EnumValueConfig{Value: "Plan to Watch"},
+			"DROPPED":       &graphql.EnumValueConfig{Value: "Dropped"},
+			"COMPLETED":     &graphql.EnumValueConfig{Value: "Completed"},
+		},
+	})
+
+	fieldChangeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "FieldChange",
+		Description: "Old and new value of a single field, JSON encoded",
+		Fields: graphql.Fields{
+			"field": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"from":  &graphql.Field{Type: graphql.String},
+			"to":    &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	auditEntryType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "AuditEntry",
+		Description: "A recorded mutation of a series",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"action":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"actor":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"requestId": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"changes": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(fieldChangeType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return fieldChanges(p.Source.(models.AuditEntry).Changes), nil
+				},
+			},
+		},
+	})
+
+	serieType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Serie",
+		Description: "A tracked series",
+		Fields: graphql.Fields{
+			"id":                 &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"title":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"ranking":            &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"status":             &graphql.Field{Type: graphql.NewNonNull(statusEnum)},
+			"lastEpisodeWatched": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalEpisodes":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"year":               &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Year the series started airing, 0 when unknown"},
+			"genres": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if genres := p.Source.(models.Serie).Genres; genres != nil {
+						return genres, nil
+					}
+					return []string{}, nil
+				},
+			},
+			"history": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(auditEntryType))),
+				Description: "Recorded mutations of the series, most recent first",
+				Args: graphql.FieldConfigArgument{
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					// Batched with the history of every other series in the response
+					thunk := loadersFrom(p.Context).history.Load(p.Source.(models.Serie).ID)
+					limit, _ := p.Args["limit"].(int)
+					return func() (any, error) {
+						value, err := thunk()
+						if err != nil {
+							return nil, resolverError(err)
+						}
+						entries := value.([]models.AuditEntry)
+						if limit > 0 && len(entries) > limit {
+							entries = entries[:limit]
+						}
+						return entries, nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	seriesPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "SeriesPage",
+		Description: "A page of series",
+		Fields: graphql.Fields{
+			"items":       &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(serieType)))},
+			"totalCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	serieInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "SerieInput",
+		Description: "Values of a series to create or replace",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":              &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"ranking":            &graphql.InputObjectFieldConfig{Type: graphql.Int, DefaultValue: 0},
+			"status":             &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(statusEnum)},
+			"lastEpisodeWatched": &graphql.InputObjectFieldConfig{Type: graphql.Int, DefaultValue: 0},
+			"totalEpisodes":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"year":               &graphql.InputObjectFieldConfig{Type: graphql.Int, DefaultValue: 0, Description: "Kept on replace when 0"},
+			"genres":             &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Kept on replace when empty"},
+		},
+	})
+
+	idArgs := graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+	}
+
+	// serieMutation builds a mutation calling a single series service method by ID
+	serieMutation := func(description string, call func(ctx context.Context, id int) (*models.Serie, error)) *graphql.Field {
+		return &graphql.Field{
+			Type:        graphql.NewNonNull(serieType),
+			Description: description,
+			Args:        idArgs,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				serie, err := call(p.Context, p.Args["id"].(int))
+				if err != nil {
+					return nil, resolverError(err)
+				}
+				return *serie, nil
+			},
+		}
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"series": &graphql.Field{
+				Type:        graphql.NewNonNull(seriesPageType),
+				Description: "Series that aren't trashed, sorted by ranking then title",
+				Args: graphql.FieldConfigArgument{
+					"status":     &graphql.ArgumentConfig{Type: statusEnum},
+					"search":     &graphql.ArgumentConfig{Type: graphql.String, Description: "Case insensitive title substring"},
+					"minRanking": &graphql.ArgumentConfig{Type: graphql.Int},
+					"first":      &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+					"offset":     &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					all, err := series.GetAllSeries(p.Context)
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return seriesPage(all, p.Args), nil
+				},
+			},
+			"serie": &graphql.Field{
+				Type:        serieType,
+				Description: "A single series by ID, null if it doesn't exist",
+				Args:        idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					serie, err := series.GetSerieByID(p.Context, p.Args["id"].(int))
+					if errors.Is(err, services.ErrNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return *serie, nil
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createSerie": &graphql.Field{
+				Type:        graphql.NewNonNull(serieType),
+				Description: "Creates a new series",
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(serieInputType)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					serie, err := series.CreateSerie(p.Context, serieFromInput(0, p.Args["input"]))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return *serie, nil
+				},
+			},
+			"updateSerie": &graphql.Field{
+				Type:        graphql.NewNonNull(serieType),
+				Description: "Replaces every value of a series",
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(serieInputType)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					serie, err := series.UpdateSerie(p.Context, serieFromInput(p.Args["id"].(int), p.Args["input"]))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return *serie, nil
+				},
+			},
+			"updateSerieStatus": &graphql.Field{
+				Type:        graphql.NewNonNull(serieType),
+				Description: "Changes the status of a series",
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"status": &graphql.ArgumentConfig{Type: graphql.NewNonNull(statusEnum)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					serie, err := series.UpdateSerieStatus(p.Context, p.Args["id"].(int), p.Args["status"].(string))
+					if err != nil {
+						return nil, resolverError(err)
+					}
+					return *serie, nil
+				},
+			},
+			"upvoteSerie":      serieMutation("Increases the ranking of a series by one", series.UpvoteSerie),
+			"downvoteSerie":    serieMutation("Decreases the ranking of a series by one", series.DownvoteSerie),
+			"incrementEpisode": serieMutation("Increases the last episode watched of a series by one", series.IncrementSerieEpisode),
+			"deleteSerie": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Moves a series to the trash",
+				Args:        idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if err := series.DeleteSerie(p.Context, p.Args["id"].(int)); err != nil {
+						return nil, resolverError(err)
+					}
+					return true, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+// serieFromInput converts a SerieInput argument into a series with the given ID
+func serieFromInput(id int, input any) models.Serie {
+	values := input.(map[string]any)
+	serie := models.Serie{ID: id}
+	serie.Title, _ = values["title"].(string)
+	serie.Ranking, _ = values["ranking"].(int)
+	serie.Status, _ = values["status"].(string)
+	serie.CurrentEpisode, _ = values["lastEpisodeWatched"].(int)
+	serie.TotalEpisodes, _ = values["totalEpisodes"].(int)
+	serie.Year, _ = values["year"].(int)
+	genres, _ := values["genres"].([]any)
+	for _, genre := range genres {
+		if genre, ok := genre.(string); ok {
+			serie.Genres = append(serie.Genres, genre)
+		}
+	}
+	return serie
+}
+
+// seriesPage filters, sorts and paginates the series list per the query arguments
+func seriesPage(all []models.Serie, args map[string]any) map[string]any {
+	status, _ := args["status"].(string)
+	search := strings.ToLower(strings.TrimSpace(stringArg(args["search"])))
+	minRanking, hasMinRanking := args["minRanking"].(int)
+
+	items := []models.Serie{}
+	for _, serie := range all {
+		if status != "" && serie.Status != status {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(serie.Title), search) {
+			continue
+		}
+		if hasMinRanking && serie.Ranking < minRanking {
+			continue
+		}
+		items = append(items, serie)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Ranking != items[j].Ranking {
+			return items[i].Ranking > items[j].Ranking
+		}
+		return items[i].Title < items[j].Title
+	})
+
+	// Clamp the page to sane bounds
+	first, _ := args["first"].(int)
+	offset, _ := args["offset"].(int)
+	if first <= 0 || first > maxPageSize {
+		first = defaultPageSize
+	}
+	offset = max(0, min(offset, len(items)))
+	end := min(offset+first, len(items))
+
+	return map[string]any{
+		"items":       items[offset:end],
+		"totalCount":  len(items),
+		"hasNextPage": end < len(items),
+	}
+}
+
+// stringArg returns an optional string argument, empty when absent
+func stringArg(arg any) string {
+	s, _ := arg.(string)
+	return s
+}
+
+// fieldChanges flattens an audit entry's changes into a list sorted by field name,
+// values are JSON encoded since they can be of any type
+func fieldChanges(changes map[string]models.FieldChange) []map[string]any {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	list := make([]map[string]any, 0, len(fields))
+	for _, field := range fields {
+		from, _ := json.Marshal(changes[field].From)
+		to, _ := json.Marshal(changes[field].To)
+		list = append(list, map[string]any{"field": field, "from": string(from), "to": string(to)})
+	}
+	return list
+}
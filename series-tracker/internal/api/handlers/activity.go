@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"series-tracker/internal/atom"
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ActivityHandler holds all the dependencies for the activity feed handler
+type ActivityHandler struct {
+	service services.ActivityService
+	tokens  services.FeedTokenService
+}
+
+// NewActivityHandler returns a new ActivityHandler with the given dependencies
+func NewActivityHandler(service services.ActivityService, tokens services.FeedTokenService) *ActivityHandler {
+	return &ActivityHandler{
+		service: service,
+		tokens:  tokens,
+	}
+}
+
+// CreateActivityToken godoc
+// @Summary      Get a secret activity feed URL
+// @Description  Mints the secret token of the activity feed of the actor given by X-Actor, the returned URL can be shared with friends to follow their progress in a feed reader. Any previous token of the actor stops working. The token isn't shown again.
+// @Tags         activity
+// @Produce      json
+// @Success      201  {object}  models.FeedToken
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/activity/token [post]
+func (h *ActivityHandler) CreateActivityToken(c echo.Context) error {
+	token, err := h.tokens.CreateFeedToken(c.Request().Context(), services.FeedActivity)
+	if err != nil {
+		return serviceError(c, err, "could not create token")
+	}
+
+	return c.JSON(http.StatusCreated, token)
+}
+
+// RevokeActivityToken godoc
+// @Summary      Revoke the secret activity feed URL
+// @Description  The activity feed URL of the actor given by X-Actor stops working
+// @Tags         activity
+// @Success      204  "No content"
+// @Failure      404  {object}  map[string]string "The actor has no token"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/activity/token [delete]
+func (h *ActivityHandler) RevokeActivityToken(c echo.Context) error {
+	if err := h.tokens.RevokeFeedToken(c.Request().Context(), services.FeedActivity); err != nil {
+		return serviceError(c, err, "could not revoke token")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetActivityFeed godoc
+// @Summary      Atom feed of an actor's activity
+// @Description  Atom feed of the latest series the owner of the token added, completed or changed the ranking of, most recent first. Opened by the secret token of POST /api/activity/token.
+// @Tags         activity
+// @Produce      application/atom+xml
+// @Param        token   query     string  true   "Secret activity feed token"
+// @Param        status  query     string  false  "Comma separated statuses, only activities leaving their series with one of them"
+// @Param        kind    query     string  false  "Comma separated kinds of activities: added, completed, ranked"
+// @Param        limit   query     int     false  "Maximum number of entries, 50 by default and at most 200"
+// @Success      200     {file}    file "Atom feed"
+// @Failure      400     {object}  map[string]string "Invalid status, kind or limit"
+// @Failure      404     {object}  map[string]string "Unknown or revoked token"
+// @Failure      500     {object}  map[string]string "Internal server error"
+// @Router       /api/activity.atom [get]
+func (h *ActivityHandler) GetActivityFeed(c echo.Context) error {
+	ctx := c.Request().Context()
+	actor, err := h.tokens.ResolveFeedToken(ctx, services.FeedActivity, c.QueryParam("token"))
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	filter := models.ActivityFilter{
+		Statuses: splitList(c.QueryParam("status")),
+		Kinds:    splitList(c.QueryParam("kind")),
+	}
+	if param := c.QueryParam("limit"); param != "" {
+		if filter.Limit, err = strconv.Atoi(param); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+	}
+
+	activities, err := h.service.GetActivity(ctx, actor, filter)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	self := c.Scheme() + "://" + c.Request().Host + c.Request().URL.RequestURI()
+	var feed bytes.Buffer
+	if err := atom.Encode(&feed, activityFeed(actor, self, activities)); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+
+	return c.Blob(http.StatusOK, atom.MIMEType+"; charset=utf-8", feed.Bytes())
+}
+
+// splitList splits a comma separated query parameter, empty items are dropped
+func splitList(param string) []string {
+	var items []string
+	for _, item := range strings.Split(param, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// activityFeed builds the Atom feed of an actor's activities, self is the URL the
+// feed was requested at
+func activityFeed(actor, self string, activities []models.Activity) atom.Feed {
+	feed := atom.Feed{
+		ID:       "urn:series-tracker:activity:" + url.PathEscape(actor),
+		Title:    "Series tracker activity of " + actor,
+		Subtitle: "Series added, completed and ranked",
+		Updated:  atom.Time(time.Now()),
+		Author:   &atom.Person{Name: actor},
+		Links:    []atom.Link{{Rel: "self", Href: self, Type: atom.MIMEType}},
+	}
+	if len(activities) > 0 {
+		feed.Updated = atom.Time(activities[0].At)
+	}
+
+	for _, activity := range activities {
+		serie := activity.Serie
+		var title string
+		switch activity.Kind {
+		case services.ActivityAdded:
+			title = fmt.Sprintf("%s added %s", activity.Actor, serie.Title)
+		case services.ActivityCompleted:
+			title = fmt.Sprintf("%s completed %s", activity.Actor, serie.Title)
+		case services.ActivityRanked:
+			title = fmt.Sprintf("%s ranked %s %d (was %d)", activity.Actor, serie.Title, serie.Ranking, activity.PreviousRanking)
+		}
+
+		feed.Entries = append(feed.Entries, atom.Entry{
+			ID:      "urn:series-tracker:activity:" + activity.ID,
+			Title:   title,
+			Updated: atom.Time(activity.At),
+			Summary: fmt.Sprintf("%s is %s, ranked %d, %d of %d episodes watched.",
+				serie.Title, serie.Status, serie.Ranking, serie.CurrentEpisode, serie.TotalEpisodes),
+			Categories: []atom.Category{{Term: activity.Kind}, {Term: serie.Status}},
+		})
+	}
+	return feed
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuditHandler holds all the dependencies for the audit handler
+type AuditHandler struct {
+	service services.AuditService
+}
+
+// NewAuditHandler returns a new AuditHandler with the given dependencies
+func NewAuditHandler(service services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+	}
+}
+
+// GetAuditEntries godoc
+// @Summary      Retrieve the audit log
+// @Description  Get the recorded series mutations with before/after snapshots and a diff of the changed fields, most recent first. Every filter is optional.
+// @Tags         audit
+// @Accept       json
+// @Produce      json
+// @Param        serieId    query     int     false  "Only entries for this series ID"
+// @Param        actor      query     string  false  "Only entries by this actor"
+// @Param        action     query     string  false  "Only entries for this action, e.g. create, update, delete, status, upvote"
+// @Param        requestId  query     string  false  "Only entries triggered by this request ID"
+// @Param        since      query     string  false  "Only entries recorded at or after this RFC 3339 timestamp"
+// @Param        until      query     string  false  "Only entries recorded before this RFC 3339 timestamp"
+// @Param        limit      query     int     false  "Maximum number of entries, at most 1000"
+// @Success      200        {array}   models.AuditEntry
+// @Failure      400        {object}  map[string]string "Invalid filter"
+// @Failure      500        {object}  map[string]string "Internal server error"
+// @Router       /api/audit [get]
+func (h *AuditHandler) GetAuditEntries(c echo.Context) error {
+	// Build the filter from the query parameters
+	filter := models.AuditFilter{
+		Actor:     c.QueryParam("actor"),
+		Action:    c.QueryParam("action"),
+		RequestID: c.QueryParam("requestId"),
+	}
+
+	var err error
+	if param := c.QueryParam("serieId"); param != "" {
+		if filter.SerieID, err = strconv.Atoi(param); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid serieId"})
+		}
+	}
+	if param := c.QueryParam("limit"); param != "" {
+		if filter.Limit, err = strconv.Atoi(param); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+	}
+	if param := c.QueryParam("since"); param != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, param); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid since"})
+		}
+	}
+	if param := c.QueryParam("until"); param != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, param); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid until"})
+		}
+	}
+
+	// Get entries via service
+	entries, err := h.service.GetAuditEntries(filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxBackupSize caps the size of an uploaded backup archive
+const maxBackupSize = 100 << 20
+
+// BackupHandler holds all the dependencies for the backup handler
+type BackupHandler struct {
+	service services.BackupService
+}
+
+// NewBackupHandler returns a new BackupHandler with the given dependencies
+func NewBackupHandler(service services.BackupService) *BackupHandler {
+	return &BackupHandler{
+		service: service,
+	}
+}
+
+// GetBackup godoc
+// @Summary      Back up the whole tracker
+// @Description  Downloads a zip archive of every table: series, trashed ones included, audit log, event store, webhooks and their deliveries. Each table is a JSON array of rows under tables/, described by manifest.json along with the format, schema version, creation time, row counts and SHA-256 checksums. Every table is read from the same snapshot of the database.
+// @Tags         admin
+// @Produce      application/zip
+// @Success      200  {file}    file "series-tracker-backup-<date>.zip"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/admin/backup [get]
+func (h *BackupHandler) GetBackup(c echo.Context) error {
+	var archive bytes.Buffer
+	if err := h.service.WriteBackup(c.Request().Context(), &archive); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not back up"})
+	}
+
+	filename := "series-tracker-backup-" + time.Now().UTC().Format("20060102-150405") + ".zip"
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	return c.Blob(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+// Restore godoc
+// @Summary      Restore the whole tracker from a backup
+// @Description  Replaces every table by the content of a backup archive, sent as the body or as the file of a multipart form. The manifest, checksums and row counts are checked first and backups of older schema versions are migrated forward; a JSON array of series as returned by GET /api/series is read as version 0. Everything is restored in a single transaction, nothing changes when the backup is rejected.
+// @Tags         admin
+// @Accept       application/zip
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  false  "Backup archive, when sent as a multipart form"
+// @Success      200   {object}  models.RestoreReport "Rows restored per table"
+// @Failure      400   {object}  map[string]string "Missing, corrupted or unsupported backup"
+// @Failure      413   {object}  map[string]string "Backup larger than 100MB"
+// @Failure      500   {object}  map[string]string "Internal server error"
+// @Router       /api/admin/restore [post]
+func (h *BackupHandler) Restore(c echo.Context) error {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxBackupSize)
+
+	archive, err := readBackupUpload(c)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "backup is too large"})
+	}
+	if err != nil || len(archive) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "a backup is required"})
+	}
+
+	report, err := h.service.Restore(c.Request().Context(), archive)
+	if err != nil {
+		return serviceError(c, err, "could not restore backup")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// readBackupUpload returns the uploaded backup, either the request body or the file
+// of a multipart form
+func readBackupUpload(c echo.Context) ([]byte, error) {
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		return io.ReadAll(c.Request().Body)
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
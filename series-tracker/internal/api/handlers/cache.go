@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"series-tracker/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CacheStatsSource is implemented by every cache exposing its hit/miss counters
+type CacheStatsSource interface {
+	Stats() models.CacheStats
+}
+
+// CacheHandler holds all the dependencies for the cache handler
+type CacheHandler struct {
+	cache CacheStatsSource
+}
+
+// NewCacheHandler returns a new CacheHandler with the given dependencies
+func NewCacheHandler(cache CacheStatsSource) *CacheHandler {
+	return &CacheHandler{
+		cache: cache,
+	}
+}
+
+// GetCacheStats godoc
+// @Summary      Retrieve series cache counters
+// @Description  Get the hit/miss counters and size of the in-process series cache
+// @Tags         cache
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  models.CacheStats
+// @Router       /api/cache/stats [get]
+func (h *CacheHandler) GetCacheStats(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.cache.Stats())
+}
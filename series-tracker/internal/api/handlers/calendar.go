@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"series-tracker/internal/ical"
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CalendarHandler holds all the dependencies for the calendar handler
+type CalendarHandler struct {
+	service services.CalendarService
+	tokens  services.FeedTokenService
+}
+
+// NewCalendarHandler returns a new CalendarHandler with the given dependencies
+func NewCalendarHandler(service services.CalendarService, tokens services.FeedTokenService) *CalendarHandler {
+	return &CalendarHandler{
+		service: service,
+		tokens:  tokens,
+	}
+}
+
+// GetSchedule godoc
+// @Summary      Get the airing schedule of a series
+// @Description  Get when the episodes of a series air
+// @Tags         calendar
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {object}  models.AiringSchedule
+// @Failure      400  {object}  map[string]string "Invalid ID"
+// @Failure      404  {object}  map[string]string "Series or schedule not found"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id}/schedule [get]
+func (h *CalendarHandler) GetSchedule(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	schedule, err := h.service.GetSchedule(c.Request().Context(), id)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
+
+// SetSchedule godoc
+// @Summary      Set the airing schedule of a series
+// @Description  Creates or replaces when the episodes of a series air: firstEpisode (1 by default) airs at firstAirAt and the next ones every everyDays days (7 by default), each lasting runtimeMinutes (30 by default). Episodes past the total of the series aren't shown in the calendar.
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "Series ID"
+// @Param        body  body      models.AiringSchedule  true  "Airing schedule, serieId is taken from the path"
+// @Success      200   {object}  models.AiringSchedule
+// @Failure      400   {object}  map[string]string "Invalid ID or schedule"
+// @Failure      404   {object}  map[string]string "Series not found"
+// @Failure      500   {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id}/schedule [put]
+func (h *CalendarHandler) SetSchedule(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	var schedule models.AiringSchedule
+	if err := c.Bind(&schedule); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	}
+	schedule.SerieID = id
+
+	saved, err := h.service.SetSchedule(c.Request().Context(), schedule)
+	if err != nil {
+		return serviceError(c, err, "could not set schedule")
+	}
+
+	return c.JSON(http.StatusOK, saved)
+}
+
+// DeleteSchedule godoc
+// @Summary      Remove the airing schedule of a series
+// @Description  Its episodes no longer show up in the calendar
+// @Tags         calendar
+// @Param        id   path      int  true  "Series ID"
+// @Success      204  "No content"
+// @Failure      400  {object}  map[string]string "Invalid ID"
+// @Failure      404  {object}  map[string]string "Series or schedule not found"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id}/schedule [delete]
+func (h *CalendarHandler) DeleteSchedule(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	if err := h.service.DeleteSchedule(c.Request().Context(), id); err != nil {
+		return serviceError(c, err, "could not delete schedule")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetSessions godoc
+// @Summary      List the watch sessions of a series
+// @Description  Get every planned session of watching a series, ordered by start
+// @Tags         calendar
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {array}   models.WatchSession
+// @Failure      400  {object}  map[string]string "Invalid ID"
+// @Failure      404  {object}  map[string]string "Series not found"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id}/sessions [get]
+func (h *CalendarHandler) GetSessions(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	sessions, err := h.service.GetSessions(c.Request().Context(), id)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+// CreateSession godoc
+// @Summary      Plan a watch session of a series
+// @Description  Plans a session of watching episodes episodes (1 by default) of a series at startsAt, lasting minutes (the runtime of the episodes by default). Upcoming sessions show in the calendar with the episodes they cover, following on from the last one watched.
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                  true  "Series ID"
+// @Param        body  body      models.WatchSession  true  "Watch session, only startsAt, episodes and minutes are read"
+// @Success      201   {object}  models.WatchSession
+// @Failure      400   {object}  map[string]string "Invalid ID or session"
+// @Failure      404   {object}  map[string]string "Series not found"
+// @Failure      500   {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id}/sessions [post]
+func (h *CalendarHandler) CreateSession(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	var input models.WatchSession
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	}
+
+	session, err := h.service.CreateSession(c.Request().Context(), models.WatchSession{
+		SerieID:  id,
+		StartsAt: input.StartsAt,
+		Episodes: input.Episodes,
+		Minutes:  input.Minutes,
+	})
+	if err != nil {
+		return serviceError(c, err, "could not create session")
+	}
+
+	return c.JSON(http.StatusCreated, session)
+}
+
+// DeleteSession godoc
+// @Summary      Cancel a watch session
+// @Description  Removes a planned session of watching a series
+// @Tags         calendar
+// @Param        id         path      int  true  "Series ID"
+// @Param        sessionId  path      int  true  "Session ID"
+// @Success      204        "No content"
+// @Failure      400        {object}  map[string]string "Invalid ID"
+// @Failure      404        {object}  map[string]string "Series or session not found"
+// @Failure      500        {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id}/sessions/{sessionId} [delete]
+func (h *CalendarHandler) DeleteSession(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+	sessionID, err := strconv.Atoi(c.Param("sessionId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid session id"})
+	}
+
+	if err := h.service.DeleteSession(c.Request().Context(), id, sessionID); err != nil {
+		return serviceError(c, err, "could not delete session")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateCalendarToken godoc
+// @Summary      Get a secret calendar URL
+// @Description  Mints the secret token of the calendar feed of the actor given by X-Actor, the returned URL can be subscribed to from calendar apps. Any previous token of the actor stops working. The token isn't shown again.
+// @Tags         calendar
+// @Produce      json
+// @Success      201  {object}  models.FeedToken
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/calendar/token [post]
+func (h *CalendarHandler) CreateCalendarToken(c echo.Context) error {
+	token, err := h.tokens.CreateFeedToken(c.Request().Context(), services.FeedCalendar)
+	if err != nil {
+		return serviceError(c, err, "could not create token")
+	}
+
+	return c.JSON(http.StatusCreated, token)
+}
+
+// RevokeCalendarToken godoc
+// @Summary      Revoke the secret calendar URL
+// @Description  The calendar URL of the actor given by X-Actor stops working
+// @Tags         calendar
+// @Success      204  "No content"
+// @Failure      404  {object}  map[string]string "The actor has no token"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/calendar/token [delete]
+func (h *CalendarHandler) RevokeCalendarToken(c echo.Context) error {
+	if err := h.tokens.RevokeFeedToken(c.Request().Context(), services.FeedCalendar); err != nil {
+		return serviceError(c, err, "could not revoke token")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetCalendar godoc
+// @Summary      iCalendar feed of the watch plan
+// @Description  RFC 5545 feed of the episodes airing and the planned watch sessions of the series being or planned to be watched, from 30 days ago to 180 days ahead. Opened by the secret token of POST /api/calendar/token.
+// @Tags         calendar
+// @Produce      text/calendar
+// @Param        token  query     string  true  "Secret calendar token"
+// @Success      200    {file}    file "series-tracker.ics"
+// @Failure      404    {object}  map[string]string "Unknown or revoked token"
+// @Failure      500    {object}  map[string]string "Internal server error"
+// @Router       /api/calendar.ics [get]
+func (h *CalendarHandler) GetCalendar(c echo.Context) error {
+	ctx := c.Request().Context()
+	actor, err := h.tokens.ResolveFeedToken(ctx, services.FeedCalendar, c.QueryParam("token"))
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	now := time.Now()
+	cal, err := h.service.Calendar(ctx, actor, now)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	var feed bytes.Buffer
+	if err := ical.Encode(&feed, *cal, now); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="series-tracker.ics"`)
+	return c.Blob(http.StatusOK, ical.MIMEType+"; charset=utf-8", feed.Bytes())
+}
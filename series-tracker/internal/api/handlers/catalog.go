@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CatalogHandler holds all the dependencies for the catalog handler
+type CatalogHandler struct {
+	service services.CatalogService
+}
+
+// NewCatalogHandler returns a new CatalogHandler with the given dependencies
+func NewCatalogHandler(service services.CatalogService) *CatalogHandler {
+	return &CatalogHandler{
+		service: service,
+	}
+}
+
+// SearchCatalog godoc
+// @Summary      Autocomplete titles from the catalog
+// @Description  Returns the catalog titles starting with q, ignoring case, the exact match first then the ones with the most episodes. The catalog is ingested from the IMDb datasets by the catalog command.
+// @Tags         catalog
+// @Produce      json
+// @Param        q      query     string  true   "Start of the title"
+// @Param        limit  query     int     false  "Max titles returned, 10 by default & 50 at most"
+// @Success      200    {array}   models.CatalogTitle
+// @Failure      400    {object}  map[string]string "Missing q or invalid limit"
+// @Failure      500    {object}  map[string]string "Internal server error"
+// @Router       /api/catalog [get]
+func (h *CatalogHandler) SearchCatalog(c echo.Context) error {
+	var limit int
+	var err error
+	if param := c.QueryParam("limit"); param != "" {
+		if limit, err = strconv.Atoi(param); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+	}
+
+	titles, err := h.service.SearchCatalog(c.Request().Context(), c.QueryParam("q"), limit)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, titles)
+}
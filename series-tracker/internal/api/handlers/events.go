@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"series-tracker/internal/broker"
+	"series-tracker/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// eventsBuffer is how many changes an SSE client may lag behind before its stream
+// is closed, it then reconnects & resumes through Last-Event-ID
+const eventsBuffer = 256
+
+// EventsHandler holds all the dependencies for the series events handler
+type EventsHandler struct {
+	changes   *broker.Broker
+	heartbeat time.Duration
+}
+
+// NewEventsHandler returns a new EventsHandler with the given dependencies, a
+// heartbeat comment is sent every heartbeat so proxies keep idle streams open
+func NewEventsHandler(changes *broker.Broker, heartbeat time.Duration) *EventsHandler {
+	return &EventsHandler{
+		changes:   changes,
+		heartbeat: heartbeat,
+	}
+}
+
+// StreamEvents godoc
+// @Summary 			Stream series changes
+// @Description 	Server-Sent Events stream of every committed series change, named after the action ("create", "update", "delete", "status", "upvote", ...) and carrying a models.SerieChange. Event IDs are the server's epoch and the change's seq, as in "3f9a0c1d2e4b5a69-42". Reconnecting with Last-Event-ID, or the lastEventId query parameter, resumes after that event; a "reset" event is sent instead when changes were missed, including across server restarts, and the client should reload.
+// @Tags 					series
+// @Produce 			text/event-stream
+// @Param 				Last-Event-ID 	header 	string 	false 	"ID of the last event received"
+// @Param 				lastEventId 		query 	string 	false 	"ID of the last event received, for clients that can't set headers"
+// @Success 			200 	{object} 	models.SerieChange
+// @Failure 			400 	{object} 	map[string]string
+// @Router 				/api/series/events 	[get]
+func (h *EventsHandler) StreamEvents(c echo.Context) error {
+	// Resume after the last event the client saw, if any
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("lastEventId")
+	}
+
+	var sub *broker.Subscription
+	complete := true
+	if lastEventID != "" {
+		epoch, seq, err := parseEventID(lastEventID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid Last-Event-ID"})
+		}
+		sub, complete = h.changes.SubscribeAfter(epoch, seq, eventsBuffer)
+	} else {
+		sub = h.changes.Subscribe(eventsBuffer)
+	}
+	defer sub.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+	res.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(res, "retry: 3000\n\n")
+	if !complete {
+		fmt.Fprint(res, "event: reset\ndata: {}\n\n")
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case change, ok := <-sub.C:
+			if !ok {
+				// Fell behind, the client reconnects & resumes
+				return nil
+			}
+			if err := writeEvent(res, h.changes.Epoch(), change); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// parseEventID splits an event ID into the broker epoch & the sequence number of the
+// change. IDs from before epochs, bare numbers, have no epoch and can't be resumed.
+func parseEventID(id string) (epoch string, seq uint64, err error) {
+	number := id
+	if i := strings.LastIndexByte(id, '-'); i >= 0 {
+		epoch, number = id[:i], id[i+1:]
+	}
+	seq, err = strconv.ParseUint(number, 10, 64)
+	return epoch, seq, err
+}
+
+// writeEvent writes a single change as an SSE event, its ID being the broker epoch
+// & the sequence number of the change
+func writeEvent(res *echo.Response, epoch string, change models.SerieChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(res, "id: %s-%d\nevent: %s\ndata: %s\n\n", epoch, change.Seq, change.Action, data)
+	return err
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import "testing"
+
+func TestParseEventID(t *testing.T) {
+	tests := []struct {
+		id    string
+		epoch string
+		seq   uint64
+		ok    bool
+	}{
+		{"3f9a0c1d2e4b5a69-42", "3f9a0c1d2e4b5a69", 42, true},
+		{"42", "", 42, true}, // From before epochs, resumed as another epoch
+		{"3f9a0c1d2e4b5a69-", "", 0, false},
+		{"3f9a0c1d2e4b5a69-x", "", 0, false},
+	}
+	for _, test := range tests {
+		epoch, seq, err := parseEventID(test.id)
+		if (err == nil) != test.ok {
+			t.Errorf("%q: err = %v", test.id, err)
+			continue
+		}
+		if test.ok && (epoch != test.epoch || seq != test.seq) {
+			t.Errorf("%q = %q, %d, want %q, %d", test.id, epoch, seq, test.epoch, test.seq)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"series-tracker/internal/api/render"
+	"series-tracker/internal/imports"
+	"series-tracker/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportSize caps the size of an uploaded import file
+const maxImportSize = 10 << 20
+
+// ExportCSV godoc
+// @Summary      Export every series as CSV
+// @Description  Downloads every series, trashed ones left out, as a CSV file with a header row of the series' JSON field names. The file can be imported back as is.
+// @Tags         import
+// @Produce      text/csv
+// @Success      200  {file}    file "series.csv"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/export.csv [get]
+func (h *SeriesHandler) ExportCSV(c echo.Context) error {
+	seriesList, err := h.service.GetAllSeries(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="series.csv"`)
+	c.Response().Header().Set(echo.HeaderContentType, render.MIMECSV+"; charset=utf-8")
+	c.Response().WriteHeader(http.StatusOK)
+	return render.EncodeCSV(c.Response(), seriesList)
+}
+
+// importFile opens the uploaded import file & reads the import options shared
+// by every format. When they're invalid it answers the request itself & returns
+// a nil file along with the error of answering.
+func importFile(c echo.Context) (multipart.File, models.ImportOptions, error) {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxImportSize)
+
+	var opts models.ImportOptions
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, opts, c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "file is too large"})
+	}
+	if err != nil {
+		return nil, opts, c.JSON(http.StatusBadRequest, map[string]string{"error": "a file is required"})
+	}
+
+	if value := c.FormValue("dryRun"); value != "" {
+		if opts.DryRun, err = strconv.ParseBool(value); err != nil {
+			return nil, opts, c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid dryRun"})
+		}
+	}
+	opts.OnConflict = c.FormValue("onConflict")
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, opts, c.JSON(http.StatusBadRequest, map[string]string{"error": "could not read file"})
+	}
+	return file, opts, nil
+}
+
+// runImport imports the rows read from a file & answers with the report
+func (h *SeriesHandler) runImport(c echo.Context, result *imports.Result, opts models.ImportOptions, err error) error {
+	if errors.Is(err, imports.ErrInvalidFile) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not read file"})
+	}
+
+	report, err := h.service.ImportSeries(c.Request().Context(), result.Rows, opts)
+	if err != nil {
+		return serviceError(c, err, "could not import series")
+	}
+	report.IgnoredColumns = result.IgnoredColumns
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// ImportCSV godoc
+// @Summary      Import series from a CSV file
+// @Description  Creates or updates a series per row, matching existing series by title ignoring case. The header row names the field of each column (title, ranking, status, lastEpisodeWatched, totalEpisodes, year, genres separated by semicolons), other headers are ignored unless mapped. Empty cells keep the current value, or the default on creation. Rows that can't be imported are reported one by one while the rest are applied together; with dryRun nothing is written.
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file        formData  file    true   "CSV file with a header row"
+// @Param        dryRun      formData  bool    false  "Only report what would be created, updated or skipped"
+// @Param        onConflict  formData  string  false  "What to do with rows whose title is taken: update (default), skip or rename" Enums(update, skip, rename)
+// @Param        mapping     formData  string  false  "JSON object mapping headers to fields, e.g. {\"Name\":\"title\",\"Notes\":\"\"}"
+// @Param        delimiter   formData  string  false  "Field delimiter, a comma by default"
+// @Success      200         {object}  models.ImportReport "Outcome of every row"
+// @Failure      400         {object}  map[string]string "Missing or unreadable file, invalid options or too many rows"
+// @Failure      413         {object}  map[string]string "File larger than 10MB"
+// @Failure      500         {object}  map[string]string "Internal server error"
+// @Router       /api/import [post]
+func (h *SeriesHandler) ImportCSV(c echo.Context) error {
+	file, opts, err := importFile(c)
+	if file == nil {
+		return err
+	}
+	defer file.Close()
+
+	result, err := imports.CSVImporter{}.Read(file, importSettings(c))
+	return h.runImport(c, result, opts, err)
+}
+
+// importSettings returns the form values of an import request, those that aren't
+// shared by every format are settings of the file's format
+func importSettings(c echo.Context) map[string]string {
+	settings := map[string]string{}
+	if form, err := c.FormParams(); err == nil {
+		for name, values := range form {
+			if len(values) > 0 && name != "dryRun" && name != "onConflict" {
+				settings[name] = values[0]
+			}
+		}
+	}
+	return settings
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ImportJobHandler holds all the dependencies for the background import handler
+type ImportJobHandler struct {
+	service services.ImportJobService
+}
+
+// NewImportJobHandler returns a new ImportJobHandler with the given dependencies
+func NewImportJobHandler(service services.ImportJobService) *ImportJobHandler {
+	return &ImportJobHandler{
+		service: service,
+	}
+}
+
+// StartImport godoc
+// @Summary      Import a file in the background
+// @Description  Queues the import of a file of the given format & answers right away with the job to poll, its Location header pointing at it. Rows create or update series matched by title ignoring case; rows that can't be imported are reported one by one while the rest are applied together, and with dryRun nothing is written. Imports run one at a time.
+// @Description  Formats: csv (header row of field names, settings: mapping, a JSON object of headers to fields, and delimiter), mal (MyAnimeList XML export, gzipped or not), trakt (Trakt JSON backup of the watched, watchlist or ratings lists, alone or keyed by list name) and json (array of series as returned by GET /api/series, alone or under "series"). Other form values are passed to the format as settings.
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        format      path      string  true   "Format of the file" Enums(csv, mal, trakt, json)
+// @Param        file        formData  file    true   "File to import"
+// @Param        dryRun      formData  bool    false  "Only report what would be created, updated or skipped"
+// @Param        onConflict  formData  string  false  "What to do with rows whose title is taken: update (default), skip or rename" Enums(update, skip, rename)
+// @Success      202         {object}  models.ImportJob "Queued job"
+// @Header       202         {string}  Location "URL of the job"
+// @Failure      400         {object}  map[string]string "Missing file or invalid options"
+// @Failure      404         {object}  map[string]string "Unknown format"
+// @Failure      413         {object}  map[string]string "File larger than 10MB"
+// @Failure      500         {object}  map[string]string "Internal server error"
+// @Router       /api/import/{format} [post]
+func (h *ImportJobHandler) StartImport(c echo.Context) error {
+	file, opts, err := importFile(c)
+	if file == nil {
+		return err
+	}
+	defer file.Close()
+
+	// The job outlives the request & its upload
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "could not read file"})
+	}
+
+	job, err := h.service.StartImport(c.Request().Context(), c.Param("format"), data, importSettings(c), opts)
+	if err != nil {
+		return serviceError(c, err, "could not start import")
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/api/import/jobs/"+job.ID)
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// GetImportJob godoc
+// @Summary      Get an import job
+// @Description  Get the progress of a background import, along with its report once it succeeded or its error once it failed. Finished jobs are kept for an hour.
+// @Tags         import
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  models.ImportJob
+// @Failure      404  {object}  map[string]string "Import job not found"
+// @Router       /api/import/jobs/{id} [get]
+func (h *ImportJobHandler) GetImportJob(c echo.Context) error {
+	job, err := h.service.GetImportJob(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
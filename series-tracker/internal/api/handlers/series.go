@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 
+	"series-tracker/internal/api/render"
 	"series-tracker/internal/models"
 	"series-tracker/internal/services"
 
@@ -22,35 +26,71 @@ func NewSeriesHandler(service services.SeriesService) *SeriesHandler {
 	}
 }
 
+// serviceError turns an error returned by a service into a JSON error response,
+// client mistakes are reported as such and anything else as the fallback message
+func serviceError(c echo.Context, err error, fallback string) error {
+	status, message := errorStatus(err, fallback)
+	return c.JSON(status, map[string]string{"error": message})
+}
+
+// errorStatus returns the HTTP status code and message reported for an error
+// returned by a service, internal failures only get the fallback message
+func errorStatus(err error, fallback string) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, services.ErrConflict):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, services.ErrBulkAborted):
+		return http.StatusFailedDependency, err.Error()
+	default:
+		return http.StatusInternalServerError, fallback
+	}
+}
+
+// bindError turns an error binding a request body into a JSON error response,
+// bodies in a format render can't read are reported as such
+func bindError(c echo.Context, err error) error {
+	if errors.Is(err, render.ErrUnsupportedMediaType) {
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+}
+
 // GetAllSeries godoc
 // @Summary 			Retrieve all series
-// @Description 	Get a list of all series in the database
+// @Description 	Get a list of all series in the database, in the format picked by the Accept header. NDJSON streams one series per line.
 // @Tags 					series
 // @Accept 				json
-// @Produce 			json
+// @Produce 			json,text/csv,application/yaml,application/msgpack,application/x-ndjson
 // @Success 			200 	{array} 		models.Serie
 // @Failure 			400 	{object} 		map[string]string
+// @Failure 			406 	{object} 		map[string]string "None of the accepted formats is supported"
 // @Failure 			500 	{object} 		map[string]string
 // @Router 				/api/series 		 	[get]
 func (h *SeriesHandler) GetAllSeries(c echo.Context) error {
 	// Get serie via service
-	seriesList, err := h.service.GetAllSeries()
+	seriesList, err := h.service.GetAllSeries(c.Request().Context())
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
 	}
 
-	return c.JSON(http.StatusOK, seriesList)
+	return render.Render(c, http.StatusOK, seriesList)
 }
 
 // GetSerie godoc
 // @Summary 			Retrieve a series by ID
-// @Description 	Get details of a series using the provided ID
+// @Description 	Get details of a series using the provided ID, in the format picked by the Accept header
 // @Tags 					series
 // @Accept 				json
-// @Produce 			json
+// @Produce 			json,text/csv,application/yaml,application/msgpack,application/x-ndjson
 // @Param 				id 		path 			int 		true 		"Series ID"
 // @Success 			200 	{object} 		models.Serie
 // @Failure 			400 	{object} 		map[string]string
+// @Failure 			404 	{object} 		map[string]string "Series not found"
+// @Failure 			406 	{object} 		map[string]string "None of the accepted formats is supported"
 // @Failure 			500 	{object} 		map[string]string
 // @Router 				/api/series/{id} 	[get]
 func (h *SeriesHandler) GetSerie(c echo.Context) error {
@@ -62,24 +102,29 @@ func (h *SeriesHandler) GetSerie(c echo.Context) error {
 	}
 
 	// Get serie via service
-	serie, err := h.service.GetSerieByID(id)
+	serie, err := h.service.GetSerieByID(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+		return serviceError(c, err, "internal server error")
 	}
 
 	// Return fetched serie
-	return c.JSON(http.StatusOK, serie)
+	return render.Render(c, http.StatusOK, serie)
 }
 
 // UpdateSerie godoc
 // @Summary 			Update an existing series
-// @Description 	Updates details of an existing series
+// @Description 	Replaces every field of an existing series, use PATCH to change only some of them. The body may be JSON, CSV (a header & a single row), YAML or MessagePack, the response follows the Accept header.
 // @Tags 					series
-// @Accept 				json
-// @Produce 			json
-// @Param 				id 		path 			int 		true 		"Series ID"
+// @Accept 				json,text/csv,application/yaml,application/msgpack
+// @Produce 			json,text/csv,application/yaml,application/msgpack,application/x-ndjson
+// @Param 				id 		path 			int 					true 		"Series ID"
+// @Param 				body 	body 			models.Serie 	true 		"Series info"
 // @Success 			200 	{object} 		models.Serie
 // @Failure 			400 	{object} 		map[string]string
+// @Failure 			404 	{object} 		map[string]string
+// @Failure 			406 	{object} 		map[string]string "None of the accepted formats is supported"
+// @Failure 			409 	{object} 		map[string]string "Title already taken"
+// @Failure 			415 	{object} 		map[string]string "Unsupported body format"
 // @Failure 			500 	{object} 		map[string]string
 // @Router 				/api/series/{id} 	[put]
 func (h *SeriesHandler) UpdateSerie(c echo.Context) error {
@@ -92,60 +137,113 @@ func (h *SeriesHandler) UpdateSerie(c echo.Context) error {
 
 	// Bind and validate request body
 	var serie models.Serie
-	if err := c.Bind(&serie); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	if err := render.Bind(c, &serie); err != nil {
+		return bindError(c, err)
 	}
 
 	// Insert ID into struct
 	serie.ID = id
 
 	// Update series via service
-	updatedSeries, err := h.service.CreateSerie(serie)
+	updatedSeries, err := h.service.UpdateSerie(c.Request().Context(), serie)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+		return serviceError(c, err, "internal server error")
 	}
 
 	// Return OK & updated data
-	return c.JSON(http.StatusOK, updatedSeries)
+	return render.Render(c, http.StatusOK, updatedSeries)
+}
+
+// PatchSerie godoc
+// @Summary      Partially update an existing series
+// @Description  Changes only the fields present in the patch. Send an RFC 7396 merge patch as application/merge-patch+json (or application/json), or an RFC 6902 JSON Patch as application/json-patch+json.
+// @Tags         series
+// @Accept       application/merge-patch+json,application/json-patch+json,json
+// @Produce      json
+// @Param        id    path      int     true  "Series ID"
+// @Param        body  body      object  true  "Merge patch or JSON Patch document"
+// @Success      200   {object}  models.Serie "Successfully patched series"
+// @Failure      400   {object}  map[string]string "Invalid patch or resulting series"
+// @Failure      404   {object}  map[string]string "Series not found"
+// @Failure      409   {object}  map[string]string "Title already taken"
+// @Failure      415   {object}  map[string]string "Unsupported patch format"
+// @Failure      500   {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id} [patch]
+func (h *SeriesHandler) PatchSerie(c echo.Context) error {
+	// Get URL parameter
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	// Pick the patch format from the content type
+	var format services.PatchFormat
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch mediaType {
+	case "application/merge-patch+json", echo.MIMEApplicationJSON:
+		format = services.PatchFormatMerge
+	case "application/json-patch+json":
+		format = services.PatchFormatJSON
+	default:
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{"error": "unsupported patch format"})
+	}
+
+	document, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	}
+
+	// Patch series via service
+	patchedSerie, err := h.service.PatchSerie(c.Request().Context(), id, format, document)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, patchedSerie)
 }
 
 // CreateSerie godoc
 // @Summary      Create a new series
-// @Description  Inserts a new series into the database, make sure the series object includes all the necessary fields.
+// @Description  Inserts a new series into the database, make sure the series object includes all the necessary fields. Total episodes, year & genres left out are filled in from the catalog when the title matches one exactly. The body may be JSON, CSV (a header & a single row), YAML or MessagePack, the response follows the Accept header.
 // @Tags         series
-// @Accept       json
-// @Produce      json
+// @Accept       json,text/csv,application/yaml,application/msgpack
+// @Produce      json,text/csv,application/yaml,application/msgpack,application/x-ndjson
 // @Param        body  body      models.Serie  true  "Series info"
 // @Success      201   {object}  models.Serie "Newly created series"
 // @Failure      400   {object}  map[string]string "Bad request, e.g, invalid input"
+// @Failure      406   {object}  map[string]string "None of the accepted formats is supported"
+// @Failure      409   {object}  map[string]string "Title already taken"
+// @Failure      415   {object}  map[string]string "Unsupported body format"
 // @Failure      500   {object}  map[string]string "Internal Server Error, e.g, database error"
 // @Router       /api/series [post]
 func (h *SeriesHandler) CreateSerie(c echo.Context) error {
 	// Bind and validate request body
 	var serie models.Serie
-	if err := c.Bind(&serie); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	if err := render.Bind(c, &serie); err != nil {
+		return bindError(c, err)
 	}
 
 	// Create series via service
-	createdSerie, err := h.service.CreateSerie(serie)
+	createdSerie, err := h.service.CreateSerie(c.Request().Context(), serie)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not create series"})
+		return serviceError(c, err, "could not create series")
 	}
 
 	// Returned created serie
-	return c.JSON(http.StatusCreated, createdSerie)
+	return render.Render(c, http.StatusCreated, createdSerie)
 }
 
 // DeleteSerie 	 godoc
-// @Summary      Remove an existing series from the database
-// @Description  Inserts a new series into the database, make sure the series object includes all the necessary fields.
+// @Summary      Move an existing series to the trash
+// @Description  Soft deletes a series, it stops showing up in the series list but can be restored from the trash until it's purged.
 // @Tags         series
 // @Accept       json
 // @Produce      json
 // @Param 				id 		path 			int 		true 		"Series ID"
 // @Success      204   "No content"
 // @Failure      400   {object}  map[string]string "Bad request, e.g, invalid input"
+// @Failure      404   {object}  map[string]string "Series not found"
 // @Failure      500   {object}  map[string]string "Internal Server Error, e.g, database error"
 // @Router       /api/series/{id} [delete]
 func (h *SeriesHandler) DeleteSerie(c echo.Context) error {
@@ -156,8 +254,8 @@ func (h *SeriesHandler) DeleteSerie(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
 	}
 
-	if err := h.service.DeleteSerie(id); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "error"})
+	if err := h.service.DeleteSerie(c.Request().Context(), id); err != nil {
+		return serviceError(c, err, "could not delete series")
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -195,9 +293,9 @@ func (h *SeriesHandler) UpdateSerieStatus(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing status"})
 	}
 
-	updatedSeries, err := h.service.UpdateSerieStatus(id, newStatus)
+	updatedSeries, err := h.service.UpdateSerieStatus(c.Request().Context(), id, newStatus)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "error"})
+		return serviceError(c, err, "could not update series status")
 	}
 	return c.JSON(http.StatusOK, updatedSeries)
 }
@@ -222,9 +320,9 @@ func (h *SeriesHandler) IncrementEpisode(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
 	}
 
-	updatedSeries, err := h.service.IncrementSerieEpisode(id)
+	updatedSeries, err := h.service.IncrementSerieEpisode(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "error"})
+		return serviceError(c, err, "could not increment episode")
 	}
 	return c.JSON(http.StatusOK, updatedSeries)
 }
@@ -250,10 +348,9 @@ func (h *SeriesHandler) UpvoteSerie(c echo.Context) error {
 	}
 
 	// Call the service layer to upvote the series.
-	updatedSerie, err := h.service.UpvoteSerie(id)
+	updatedSerie, err := h.service.UpvoteSerie(c.Request().Context(), id)
 	if err != nil {
-		// Optionally handle not found errors separately.
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to upvote series"})
+		return serviceError(c, err, "failed to upvote series")
 	}
 
 	// Return the updated series.
@@ -281,12 +378,141 @@ func (h *SeriesHandler) DownvoteSerie(c echo.Context) error {
 	}
 
 	// Call the service layer to downvote the series.
-	updatedSerie, err := h.service.DownvoteSerie(id)
+	updatedSerie, err := h.service.DownvoteSerie(c.Request().Context(), id)
 	if err != nil {
-		// Optionally handle not found errors separately.
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to downvote series"})
+		return serviceError(c, err, "failed to downvote series")
 	}
 
 	// Return the updated series.
 	return c.JSON(http.StatusOK, updatedSerie)
 }
+
+// GetTrash godoc
+// @Summary      Retrieve trashed series
+// @Description  Get a list of all series in the trash, most recently deleted first
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   models.Serie
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/trash [get]
+func (h *SeriesHandler) GetTrash(c echo.Context) error {
+	// Get trashed series via service
+	seriesList, err := h.service.GetTrashedSeries(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+	}
+
+	return c.JSON(http.StatusOK, seriesList)
+}
+
+// RestoreSerie godoc
+// @Summary      Restore a trashed series
+// @Description  Takes the series with the specified ID out of the trash.
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int   true  "Series ID"
+// @Success      200  {object}  models.Serie "Series successfully restored"
+// @Failure      400  {object}  map[string]string "Invalid series ID"
+// @Failure      404  {object}  map[string]string "Series not found in the trash"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/series/{id}/restore [post]
+func (h *SeriesHandler) RestoreSerie(c echo.Context) error {
+	// Get URL parameter
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	// Restore series via service
+	restoredSerie, err := h.service.RestoreSerie(c.Request().Context(), id)
+	if err != nil {
+		return serviceError(c, err, "failed to restore series")
+	}
+
+	return c.JSON(http.StatusOK, restoredSerie)
+}
+
+// PurgeSerie godoc
+// @Summary      Permanently delete a trashed series
+// @Description  Removes the series with the specified ID from the trash and the database, this can't be undone.
+// @Tags         trash
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int   true  "Series ID"
+// @Success      204  "No content"
+// @Failure      400  {object}  map[string]string "Invalid series ID"
+// @Failure      404  {object}  map[string]string "Series not found in the trash"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/trash/{id} [delete]
+func (h *SeriesHandler) PurgeSerie(c echo.Context) error {
+	// Get URL parameter
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	if err := h.service.PurgeSerie(c.Request().Context(), id); err != nil {
+		return serviceError(c, err, "failed to purge series")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RunBulk godoc
+// @Summary      Run several series operations at once
+// @Description  Runs a list of create, update, delete, status and episode operations in order. With atomic set they run in one transaction and a single failure rolls all of them back, otherwise each one is applied on its own. Every operation gets its own result.
+// @Tags         series
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.BulkRequest  true  "Operations to run"
+// @Success      200   {object}  models.BulkResponse "Every operation was run, check each result in best-effort mode"
+// @Failure      400   {object}  models.BulkResponse "Invalid request, with error set & no results, or an atomic run failed on an invalid operation"
+// @Failure      404   {object}  models.BulkResponse "An atomic run failed on a missing series"
+// @Failure      409   {object}  models.BulkResponse "An atomic run failed on a taken title"
+// @Failure      500   {object}  models.BulkResponse "Internal server error"
+// @Router       /api/series/bulk [post]
+func (h *SeriesHandler) RunBulk(c echo.Context) error {
+	// Bind and validate request body
+	var req models.BulkRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.BulkResponse{Atomic: req.Atomic, Results: []models.BulkResult{}, Error: "invalid input"})
+	}
+
+	// Run operations via service, requests rejected as a whole get the same shape
+	results, err := h.service.RunBulk(c.Request().Context(), req.Operations, req.Atomic)
+	if err != nil && !errors.Is(err, services.ErrBulkAborted) {
+		status, message := errorStatus(err, "internal server error")
+		return c.JSON(status, models.BulkResponse{Atomic: req.Atomic, Results: []models.BulkResult{}, Error: message})
+	}
+
+	// Fill in the per operation status codes & tally the outcome
+	res := models.BulkResponse{Atomic: req.Atomic, Results: results}
+	status := http.StatusOK
+	for i, result := range results {
+		if result.Err == nil {
+			res.Succeeded++
+			switch result.Op {
+			case services.BulkOpCreate:
+				results[i].Status = http.StatusCreated
+			case services.BulkOpDelete:
+				results[i].Status = http.StatusNoContent
+			default:
+				results[i].Status = http.StatusOK
+			}
+			continue
+		}
+
+		res.Failed++
+		results[i].Status, results[i].Error = errorStatus(result.Err, "internal server error")
+		// An aborted atomic run answers with the status of the operation that failed
+		if !errors.Is(result.Err, services.ErrBulkAborted) && req.Atomic {
+			status = results[i].Status
+		}
+	}
+
+	return c.JSON(status, res)
+}
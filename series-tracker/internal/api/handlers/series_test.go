@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// bulkSeriesService answers RunBulk with canned results, other methods panic
+// through the nil embedded interface
+type bulkSeriesService struct {
+	services.SeriesService
+	results []models.BulkResult
+	err     error
+}
+
+func (s *bulkSeriesService) RunBulk(ctx context.Context, ops []models.BulkOperation, atomic bool) ([]models.BulkResult, error) {
+	return s.results, s.err
+}
+
+// runBulk posts body to the bulk handler, returning the status & decoded response
+func runBulk(t *testing.T, service services.SeriesService, body string) (int, models.BulkResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/series/bulk", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	if err := NewSeriesHandler(service).RunBulk(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatalf("RunBulk: %v", err)
+	}
+
+	var res models.BulkResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("response isn't a BulkResponse: %v: %s", err, rec.Body)
+	}
+	return rec.Code, res
+}
+
+func TestRunBulkConflict(t *testing.T) {
+	service := &bulkSeriesService{results: []models.BulkResult{
+		{Index: 0, Op: services.BulkOpCreate, Applied: true, Serie: &models.Serie{ID: 1, Title: "Dark"}},
+		{Index: 1, Op: services.BulkOpCreate, Err: fmt.Errorf("%w: Key (title)=(Dark) already exists.", services.ErrConflict)},
+	}}
+
+	code, res := runBulk(t, service, `{"operations":[{"op":"create"},{"op":"create"}]}`)
+	if code != http.StatusOK || res.Succeeded != 1 || res.Failed != 1 {
+		t.Fatalf("got %d with %d succeeded & %d failed", code, res.Succeeded, res.Failed)
+	}
+	if res.Results[0].Status != http.StatusCreated || res.Results[1].Status != http.StatusConflict {
+		t.Errorf("statuses = %d & %d, want 201 & 409", res.Results[0].Status, res.Results[1].Status)
+	}
+	if !strings.HasPrefix(res.Results[1].Error, "conflict") {
+		t.Errorf("error = %q", res.Results[1].Error)
+	}
+}
+
+func TestRunBulkRejectedRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		err     error
+		code    int
+		message string
+	}{
+		{"malformed body", `{"operations":`, nil, http.StatusBadRequest, "invalid input"},
+		{"no operations", `{"operations":[]}`, fmt.Errorf("%w: no operations given", services.ErrInvalidInput), http.StatusBadRequest, "invalid input: no operations given"},
+		{"internal failure", `{"operations":[{"op":"delete","id":1}]}`, fmt.Errorf("connection refused"), http.StatusInternalServerError, "internal server error"},
+	}
+	for _, test := range tests {
+		code, res := runBulk(t, &bulkSeriesService{err: test.err}, test.body)
+		if code != test.code || res.Error != test.message || res.Results == nil {
+			t.Errorf("%s: got %d %+v, want %d %q", test.name, code, res, test.code, test.message)
+		}
+	}
+}
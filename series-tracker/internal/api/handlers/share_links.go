@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ShareHandler holds all the dependencies for the share link handler
+type ShareHandler struct {
+	service services.ShareService
+}
+
+// NewShareHandler returns a new ShareHandler with the given dependencies
+func NewShareHandler(service services.ShareService) *ShareHandler {
+	return &ShareHandler{
+		service: service,
+	}
+}
+
+// CreateShareLink godoc
+// @Summary      Create a share link
+// @Description  Mints a public, read-only link to the series list for the actor given by X-Actor. The view can be narrowed to some statuses, leave rankings out and expire. The token is returned here and isn't shown again.
+// @Tags         share
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.ShareLinkInput  true  "Share link filter"
+// @Success      201   {object}  models.ShareLink "Created link, token included"
+// @Failure      400   {object}  map[string]string "Invalid status or expiry"
+// @Failure      500   {object}  map[string]string "Internal server error"
+// @Router       /api/shares [post]
+func (h *ShareHandler) CreateShareLink(c echo.Context) error {
+	var input models.ShareLinkInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	}
+
+	link, err := h.service.CreateShareLink(c.Request().Context(), input)
+	if err != nil {
+		return serviceError(c, err, "could not create share link")
+	}
+
+	return c.JSON(http.StatusCreated, link)
+}
+
+// GetShareLinks godoc
+// @Summary      List share links
+// @Description  Get the share links of the actor given by X-Actor, expired ones included, newest first. Tokens aren't included.
+// @Tags         share
+// @Produce      json
+// @Success      200  {array}   models.ShareLink
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/shares [get]
+func (h *ShareHandler) GetShareLinks(c echo.Context) error {
+	links, err := h.service.GetShareLinks(c.Request().Context())
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, links)
+}
+
+// RevokeShareLink godoc
+// @Summary      Revoke a share link
+// @Description  The share link of the actor given by X-Actor stops working
+// @Tags         share
+// @Param        id   path      int  true  "Share link ID"
+// @Success      204  "No content"
+// @Failure      400  {object}  map[string]string "Invalid ID"
+// @Failure      404  {object}  map[string]string "Share link not found"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/shares/{id} [delete]
+func (h *ShareHandler) RevokeShareLink(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	if err := h.service.RevokeShareLink(c.Request().Context(), id); err != nil {
+		return serviceError(c, err, "could not revoke share link")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetSharedList godoc
+// @Summary      View a shared series list
+// @Description  Public, read-only view of the series list opened by the token of a share link, filtered as the link says. Series IDs and trashed series aren't shown.
+// @Tags         share
+// @Produce      json
+// @Param        token  path      string  true  "Share link token"
+// @Success      200    {object}  models.SharedList
+// @Failure      404    {object}  map[string]string "Unknown, revoked or expired link"
+// @Failure      500    {object}  map[string]string "Internal server error"
+// @Router       /share/{token} [get]
+func (h *ShareHandler) GetSharedList(c echo.Context) error {
+	shared, err := h.service.GetSharedList(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	// Revoked & expired links must stop working right away
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, shared)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetChanges godoc
+// @Summary      Pull the series changed since a sync token
+// @Description  Delta sync for offline-capable clients: returns the series created, updated and deleted (tombstones) since the given token along with the token to pass next time. Without a token, or when the token can't be synced from anymore (e.g. after a restore), every series is returned as created with reset set and the client must replace its copy.
+// @Tags         sync
+// @Produce      json
+// @Param        since  query     string  false  "Token returned by the previous sync"
+// @Success      200    {object}  models.SyncChanges
+// @Failure      400    {object}  map[string]string "Invalid token"
+// @Failure      500    {object}  map[string]string "Internal server error"
+// @Router       /api/sync [get]
+func (h *SeriesHandler) GetChanges(c echo.Context) error {
+	changes, err := h.service.GetChanges(c.Request().Context(), c.QueryParam("since"))
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, changes)
+}
+
+// PushChanges godoc
+// @Summary      Push edits queued while offline
+// @Description  Applies create, update, delete, status and episode edits made on top of a sync token, each on its own. Edits of a series changed on the server since the token are left out as conflicts along with the server's version of the series, nil once deleted, so are edits giving a series a title taken on the server, with the reason. Pull again afterwards to get the resulting state and a new token.
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.SyncPush  true  "Queued edits"
+// @Success      200   {object}  models.SyncPushResponse "Every edit was tried, check each result"
+// @Failure      400   {object}  map[string]string "Invalid, missing or stale token, or no edits"
+// @Failure      500   {object}  map[string]string "Internal server error"
+// @Router       /api/sync [post]
+func (h *SeriesHandler) PushChanges(c echo.Context) error {
+	var push models.SyncPush
+	if err := c.Bind(&push); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	}
+
+	results, err := h.service.PushChanges(c.Request().Context(), push)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	// Tally the outcome, failures & taken titles get the message they would have gotten
+	// on their own
+	res := models.SyncPushResponse{Results: results}
+	for i, result := range results {
+		switch result.Outcome {
+		case services.SyncApplied:
+			res.Applied++
+		case services.SyncConflict:
+			res.Conflicts++
+			if result.Err != nil {
+				_, results[i].Error = errorStatus(result.Err, "internal server error")
+			}
+		default:
+			res.Failed++
+			_, results[i].Error = errorStatus(result.Err, "internal server error")
+		}
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
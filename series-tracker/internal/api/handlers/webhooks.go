@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WebhookHandler holds all the dependencies for the webhook handler
+type WebhookHandler struct {
+	service services.WebhookService
+}
+
+// NewWebhookHandler returns a new WebhookHandler with the given dependencies
+func NewWebhookHandler(service services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary      Register a webhook
+// @Description  Registers a URL notified of series changes. Deliveries are POSTed as a models.WebhookEvent and signed in the X-Webhook-Signature header as "t=<unix seconds>,v1=<hex HMAC-SHA256 of '<t>.<body>'>" with the secret returned here, which isn't shown again. Events: series.created, series.updated, series.deleted, series.restored, series.purged, series.status_changed, series.completed, series.upvoted, series.downvoted, episode.incremented or * for all.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.WebhookInput  true  "Webhook info"
+// @Success      201   {object}  models.Webhook "Registered webhook, secret included"
+// @Failure      400   {object}  map[string]string "Invalid URL or events"
+// @Failure      500   {object}  map[string]string "Internal server error"
+// @Router       /api/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var input models.WebhookInput
+	if err := c.Bind(&input); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid input"})
+	}
+
+	webhook, err := h.service.CreateWebhook(c.Request().Context(), input)
+	if err != nil {
+		return serviceError(c, err, "could not create webhook")
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// GetWebhooks godoc
+// @Summary      List webhooks
+// @Description  Get every registered webhook, secrets aren't included
+// @Tags         webhooks
+// @Produce      json
+// @Success      200  {array}   models.Webhook
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(c echo.Context) error {
+	webhooks, err := h.service.GetWebhooks(c.Request().Context())
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Summary      Get a webhook
+// @Description  Get a single webhook by its ID, its secret isn't included
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  models.Webhook
+// @Failure      400  {object}  map[string]string "Invalid ID"
+// @Failure      404  {object}  map[string]string "Webhook not found"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	webhook, err := h.service.GetWebhook(c.Request().Context(), id)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook
+// @Description  Unregisters a webhook, its pending deliveries and delivery log go with it
+// @Tags         webhooks
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      204  "No content"
+// @Failure      400  {object}  map[string]string "Invalid ID"
+// @Failure      404  {object}  map[string]string "Webhook not found"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	if err := h.service.DeleteWebhook(c.Request().Context(), id); err != nil {
+		return serviceError(c, err, "could not delete webhook")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetDeliveries godoc
+// @Summary      Retrieve a webhook's delivery log
+// @Description  Get the latest deliveries to a webhook with their status, attempts and the response code of their latest attempt, most recent first
+// @Tags         webhooks
+// @Produce      json
+// @Param        id     path      int  true   "Webhook ID"
+// @Param        limit  query     int  false  "Maximum number of deliveries, at most 500"
+// @Success      200    {array}   models.WebhookDelivery
+// @Failure      400    {object}  map[string]string "Invalid ID or limit"
+// @Failure      404    {object}  map[string]string "Webhook not found"
+// @Failure      500    {object}  map[string]string "Internal server error"
+// @Router       /api/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	limit := 0
+	if param := c.QueryParam("limit"); param != "" {
+		if limit, err = strconv.Atoi(param); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+	}
+
+	deliveries, err := h.service.GetDeliveries(c.Request().Context(), id, limit)
+	if err != nil {
+		return serviceError(c, err, "internal server error")
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+// ReplayDelivery godoc
+// @Summary      Replay a delivery
+// @Description  Queues a new delivery of the same event to the same webhook, sent with a new delivery ID but the original payload
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      int  true  "Delivery ID"
+// @Success      202  {object}  models.WebhookDelivery "Queued delivery"
+// @Failure      400  {object}  map[string]string "Invalid ID"
+// @Failure      404  {object}  map[string]string "Delivery not found"
+// @Failure      500  {object}  map[string]string "Internal server error"
+// @Router       /api/webhooks/deliveries/{id}/replay [post]
+func (h *WebhookHandler) ReplayDelivery(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid id"})
+	}
+
+	delivery, err := h.service.ReplayDelivery(c.Request().Context(), id)
+	if err != nil {
+		return serviceError(c, err, "could not replay delivery")
+	}
+
+	return c.JSON(http.StatusAccepted, delivery)
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"series-tracker/internal/broker"
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+// WebSocket connection settings
+const (
+	wsBuffer       = 256              // Outgoing messages a client may lag behind before it's disconnected
+	wsMaxMessage   = 4096             // Largest message accepted from a client, in bytes
+	wsPongWait     = 60 * time.Second // How long a client may stay silent, pongs included
+	wsPingInterval = 30 * time.Second // How often clients are pinged, must be below wsPongWait
+	wsWriteWait    = 10 * time.Second // How long a single write may take
+)
+
+// WebSocket message types
+const (
+	WSSubscribe   = "subscribe"   // Client: start receiving changes of the given series/lists
+	WSUnsubscribe = "unsubscribe" // Client: stop receiving changes of the given series/lists
+	WSEpisode     = "episode"     // Client: increment the last episode watched of a series
+	WSUpvote      = "upvote"      // Client: upvote a series
+	WSDownvote    = "downvote"    // Client: downvote a series
+	WSStatus      = "status"      // Client: change the status of a series
+	WSAck         = "ack"         // Server: outcome of a client message
+	WSChange      = "change"      // Server: a committed change matching a subscription
+)
+
+// WSMessage represents a message sent by a WebSocket client. Subscriptions pick
+// series by ID, lists by status, or every series with all.
+type WSMessage struct {
+	ID       string   `json:"id"`                 // Client chosen ID, echoed back in the ack
+	Type     string   `json:"type"`               // One of subscribe, unsubscribe, episode, upvote, downvote, status
+	SerieIDs []int    `json:"serieIds,omitempty"` // Series to (un)subscribe to
+	Statuses []string `json:"statuses,omitempty"` // Status lists to (un)subscribe to, e.g. "Watching"
+	All      bool     `json:"all,omitempty"`      // (Un)subscribe to every series
+	SerieID  int      `json:"serieId,omitempty"`  // Target of a command
+	Status   string   `json:"status,omitempty"`   // New status for the status command
+}
+
+// WSReply represents a message sent to a WebSocket client
+type WSReply struct {
+	Type   string              `json:"type"`             // ack or change
+	ID     string              `json:"id,omitempty"`     // ID of the acknowledged message
+	OK     bool                `json:"ok,omitempty"`     // Whether the acknowledged message succeeded
+	Error  string              `json:"error,omitempty"`  // Why the acknowledged message failed
+	Serie  *models.Serie       `json:"serie,omitempty"`  // Series resulting from a command
+	Change *models.SerieChange `json:"change,omitempty"` // Change matching a subscription
+}
+
+// WSHandler holds all the dependencies for the WebSocket handler
+type WSHandler struct {
+	service  services.SeriesService
+	changes  *broker.Broker
+	upgrader websocket.Upgrader
+}
+
+// NewWSHandler returns a new WSHandler with the given dependencies, connections
+// are only accepted from the given origins
+func NewWSHandler(service services.SeriesService, changes *broker.Broker, allowedOrigins []string) *WSHandler {
+	origins := map[string]bool{}
+	for _, origin := range allowedOrigins {
+		origins[origin] = true
+	}
+
+	return &WSHandler{
+		service: service,
+		changes: changes,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || origins[origin]
+			},
+		},
+	}
+}
+
+// Connect godoc
+// @Summary 			Live sync over WebSocket
+// @Description 	Upgrades to a WebSocket exchanging handlers.WSMessage from the client and handlers.WSReply from the server. Clients subscribe to series or status lists and receive every matching committed change, and can run the episode, upvote, downvote and status commands, each acknowledged by ID. Browsers can pass the actor as a query parameter.
+// @Tags 					series
+// @Param 				actor 	query 	string 	false 	"Who performs the commands, overrides X-Actor"
+// @Success 			101 	"Switching protocols"
+// @Failure 			400 	{object} 	map[string]string
+// @Router 				/api/ws 	[get]
+func (h *WSHandler) Connect(c echo.Context) error {
+	// Browsers can't set headers on WebSocket requests
+	info := services.RequestInfoFrom(c.Request().Context())
+	if actor := c.QueryParam("actor"); actor != "" {
+		info.Actor = actor
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// The upgrader already replied
+		return nil
+	}
+
+	session := &wsSession{
+		handler: h,
+		conn:    conn,
+		info:    info,
+		out:     make(chan WSReply, wsBuffer),
+		sub:     h.changes.Subscribe(wsBuffer),
+		series:  map[int]bool{},
+		lists:   map[string]bool{},
+	}
+	session.run()
+	return nil
+}
+
+// wsSession is the state of a single WebSocket connection
+type wsSession struct {
+	handler *WSHandler
+	conn    *websocket.Conn
+	info    services.RequestInfo
+	out     chan WSReply
+	sub     *broker.Subscription
+
+	mu     sync.Mutex
+	all    bool
+	series map[int]bool
+	lists  map[string]bool
+}
+
+// run serves the connection until either side closes it, messages are read here
+// and every write happens in writeLoop
+func (s *wsSession) run() {
+	defer s.conn.Close()
+	defer s.sub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.writeLoop(ctx, cancel)
+
+	s.conn.SetReadLimit(wsMaxMessage)
+	s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			// Closed, timed out or too large
+			return
+		}
+
+		var msg WSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			// The connection is still usable
+			if !s.send(ctx, WSReply{Type: WSAck, Error: "invalid message"}) {
+				return
+			}
+			continue
+		}
+
+		if !s.send(ctx, s.handle(msg)) {
+			return
+		}
+	}
+}
+
+// send queues a reply for writeLoop, false once the connection is going away
+func (s *wsSession) send(ctx context.Context, reply WSReply) bool {
+	select {
+	case s.out <- reply:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// writeLoop writes replies, matching changes and pings until the connection fails
+// or the client falls behind
+func (s *wsSession) writeLoop(ctx context.Context, cancel context.CancelFunc) {
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	// Unblocks the read loop
+	defer s.conn.Close()
+	defer cancel()
+
+	write := func(reply WSReply) bool {
+		s.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		return s.conn.WriteJSON(reply) == nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case reply := <-s.out:
+			if !write(reply) {
+				return
+			}
+		case change, ok := <-s.sub.C:
+			if !ok {
+				s.conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "fell behind, reconnect"),
+					time.Now().Add(wsWriteWait))
+				return
+			}
+			if s.matches(change) && !write(WSReply{Type: WSChange, Change: &change}) {
+				return
+			}
+		case <-ping.C:
+			if s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)) != nil {
+				return
+			}
+		}
+	}
+}
+
+// matches reports whether a change concerns any of the session's subscriptions, a
+// list subscription sees series entering & leaving it
+func (s *wsSession) matches(change models.SerieChange) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.all || s.series[change.SerieID] {
+		return true
+	}
+	return (change.Before != nil && s.lists[change.Before.Status]) ||
+		(change.Serie != nil && s.lists[change.Serie.Status])
+}
+
+// handle runs a single client message, returning its ack
+func (s *wsSession) handle(msg WSMessage) WSReply {
+	ack := WSReply{Type: WSAck, ID: msg.ID}
+
+	var serie *models.Serie
+	var err error
+	switch msg.Type {
+	case WSSubscribe, WSUnsubscribe:
+		err = s.subscribe(msg, msg.Type == WSSubscribe)
+	case WSEpisode:
+		serie, err = s.handler.service.IncrementSerieEpisode(s.commandContext(msg), msg.SerieID)
+	case WSUpvote:
+		serie, err = s.handler.service.UpvoteSerie(s.commandContext(msg), msg.SerieID)
+	case WSDownvote:
+		serie, err = s.handler.service.DownvoteSerie(s.commandContext(msg), msg.SerieID)
+	case WSStatus:
+		serie, err = s.handler.service.UpdateSerieStatus(s.commandContext(msg), msg.SerieID, msg.Status)
+	default:
+		err = fmt.Errorf("%w: unknown message type %q", services.ErrInvalidInput, msg.Type)
+	}
+
+	if err != nil {
+		_, ack.Error = errorStatus(err, "internal server error")
+		return ack
+	}
+	ack.OK = true
+	ack.Serie = serie
+	return ack
+}
+
+// commandContext returns the context a command runs with, its request ID ties it to
+// both the connection & the message
+func (s *wsSession) commandContext(msg WSMessage) context.Context {
+	info := s.info
+	switch {
+	case msg.ID == "":
+	case info.RequestID == "":
+		info.RequestID = msg.ID
+	default:
+		info.RequestID = fmt.Sprintf("%s:%s", info.RequestID, msg.ID)
+	}
+	return services.WithRequestInfo(context.Background(), info)
+}
+
+// subscribe adds or removes the series & lists named by a message
+func (s *wsSession) subscribe(msg WSMessage, add bool) error {
+	if !msg.All && len(msg.SerieIDs) == 0 && len(msg.Statuses) == 0 {
+		return fmt.Errorf("%w: nothing to %s", services.ErrInvalidInput, msg.Type)
+	}
+	for _, status := range msg.Statuses {
+		if !services.IsValidStatus(status) {
+			return fmt.Errorf("%w: invalid status %q", services.ErrInvalidInput, status)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if msg.All {
+		s.all = add
+	}
+	for _, id := range msg.SerieIDs {
+		if add {
+			s.series[id] = true
+		} else {
+			delete(s.series, id)
+		}
+	}
+	for _, status := range msg.Statuses {
+		if add {
+			s.lists[status] = true
+		} else {
+			delete(s.lists, status)
+		}
+	}
+	return nil
+}
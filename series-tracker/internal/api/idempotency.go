@@ -0,0 +1,140 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Headers of idempotent requests
+const (
+	// HeaderIdempotencyKey is the request header clients use to make a POST, PATCH
+	// or DELETE safe to retry
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed is set on responses replayed from an earlier request
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// maxIdempotentBodySize caps the body of requests carrying an Idempotency-Key, it's
+// held in memory to fingerprint them. Larger ones, such as big backups, have to be
+// sent without a key.
+const maxIdempotentBodySize = 10 << 20
+
+// Idempotency makes POST, PATCH & DELETE requests carrying an Idempotency-Key
+// header safe to retry: the first response per key is stored and replayed to any
+// repeat of the same request, while reusing a key for a different request is
+// rejected. Server errors aren't stored so those requests can be retried for real.
+// It must run after CORS so replayed responses carry its headers.
+func Idempotency(service services.IdempotencyService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderIdempotencyKey)
+			if key == "" || !isMutating(req.Method) {
+				return next(c)
+			}
+
+			// The body is read to fingerprint the request & put back for the handler
+			body, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxIdempotentBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "request body is too large to be made idempotent"})
+			}
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": "could not read request body"})
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			record, err := service.Begin(ctx, key, fingerprint(req, body))
+			if err != nil {
+				status, message := idempotencyErrorStatus(err)
+				return c.JSON(status, map[string]string{"error": message})
+			}
+			if record != nil {
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				if len(record.Body) == 0 {
+					return c.NoContent(record.Status)
+				}
+				return c.Blob(record.Status, record.ContentType, record.Body)
+			}
+
+			// The key is released unless a response gets stored, panics included
+			stored := false
+			defer func() {
+				if !stored {
+					if err := service.Release(ctx, key); err != nil {
+						log.Printf("idempotency: failed to release key %q: %v", key, err)
+					}
+				}
+			}()
+
+			res := c.Response()
+			recorder := &responseRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+			err = next(c)
+			res.Writer = recorder.ResponseWriter
+			if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError {
+				return err
+			}
+
+			if err := service.Complete(ctx, key, res.Status, res.Header().Get(echo.HeaderContentType), recorder.body.Bytes()); err != nil {
+				log.Printf("idempotency: failed to store response for key %q: %v", key, err)
+				return nil
+			}
+			stored = true
+			return nil
+		}
+	}
+}
+
+// isMutating reports whether requests with the method can be made idempotent
+func isMutating(method string) bool {
+	return method == http.MethodPost || method == http.MethodPatch || method == http.MethodDelete
+}
+
+// fingerprint hashes what makes two requests the same: method, path, query & body
+func fingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, req.Method)
+	io.WriteString(h, " ")
+	io.WriteString(h, req.URL.RequestURI())
+	io.WriteString(h, "\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyErrorStatus returns the HTTP status code and message reported for an
+// error returned by IdempotencyService.Begin
+func idempotencyErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, services.ErrIdempotencyKeyInUse):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, services.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity, err.Error()
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
+
+// responseRecorder keeps a copy of everything written to the response
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write writes b to the response & the copy
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
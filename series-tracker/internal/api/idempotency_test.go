@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Bodies over the cap are rejected before the service, nil here, is reached
+func TestIdempotencyRejectsLargeBodies(t *testing.T) {
+	e := echo.New()
+	e.Use(Idempotency(services.IdempotencyService(nil)))
+	e.POST("/", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	body := strings.Repeat("x", maxIdempotentBodySize+1)
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(HeaderIdempotencyKey, "key")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	// Without a key the handler decides
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status without a key = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+}
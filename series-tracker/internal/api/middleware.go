@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderActor is the request header clients use to identify who is performing
+// a request, there's no authentication so it's taken at face value
+const HeaderActor = "X-Actor"
+
+// RequestInfo stores the actor & request ID of every request in its context so
+// the service layer can record them in the audit log. It must run after echo's
+// RequestID middleware.
+func RequestInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := services.WithRequestInfo(req.Context(), services.RequestInfo{
+				Actor:     req.Header.Get(HeaderActor),
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+			})
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// Deprecated marks every response of a route as deprecated since the given moment
+// through the Deprecation (RFC 9745) and Sunset (RFC 8594) headers, linking to the
+// route replacing it. A zero sunset leaves the Sunset header out.
+func Deprecated(since, sunset time.Time, successor string) echo.MiddlewareFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	link := fmt.Sprintf(`<%s>; rel="successor-version"`, successor)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set("Deprecation", deprecation)
+			if !sunset.IsZero() {
+				header.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			header.Add("Link", link)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package render
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCSVUnsupported is returned for values that can't be written as CSV rows,
+// only structs of scalar & string list fields & slices of them can
+var ErrCSVUnsupported = errors.New("value can't be represented as CSV")
+
+// listSeparator separates the items of string list cells
+const listSeparator = ";"
+
+// stringsType is written as a single cell of listSeparator separated items
+var stringsType = reflect.TypeOf([]string{})
+
+// csvColumn is a struct field written as a CSV column, named as in JSON
+type csvColumn struct {
+	name  string
+	index int
+}
+
+// timeType is formatted as RFC 3339 instead of its fields
+var timeType = reflect.TypeOf(time.Time{})
+
+// csvColumns lists the columns of a struct type, in field order
+func csvColumns(t reflect.Type) ([]csvColumn, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, ErrCSVUnsupported
+	}
+
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		kind := field.Type
+		if kind.Kind() == reflect.Pointer {
+			kind = kind.Elem()
+		}
+		if kind != timeType && kind != stringsType && (kind.Kind() == reflect.Struct || kind.Kind() == reflect.Slice || kind.Kind() == reflect.Map) {
+			return nil, ErrCSVUnsupported
+		}
+		columns = append(columns, csvColumn{name: name, index: i})
+	}
+	return columns, nil
+}
+
+// EncodeCSV writes a struct, or a slice of structs, as CSV with a header row of
+// their JSON field names. Nil pointers are written as empty cells, times as
+// RFC 3339 & string lists joined by semicolons.
+func EncodeCSV(w io.Writer, v interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	rows := []reflect.Value{value}
+	elemType := value.Type()
+	if value.Kind() == reflect.Slice {
+		elemType = value.Type().Elem()
+		rows = make([]reflect.Value, value.Len())
+		for i := range rows {
+			rows[i] = value.Index(i)
+		}
+	}
+
+	columns, err := csvColumns(elemType)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.name
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = formatCell(row.Field(column.index))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatCell returns the text of a field
+func formatCell(field reflect.Value) string {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+	switch field.Type() {
+	case timeType:
+		return field.Interface().(time.Time).Format(time.RFC3339)
+	case stringsType:
+		return strings.Join(field.Interface().([]string), listSeparator)
+	}
+	return fmt.Sprint(field.Interface())
+}
+
+// DecodeCSV reads CSV with a header row into a pointer to a slice of structs, or
+// to a single struct when it holds exactly one row. Columns are matched to the
+// JSON field names, unknown ones are ignored like unknown JSON fields are.
+func DecodeCSV(r io.Reader, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return ErrCSVUnsupported
+	}
+	target = target.Elem()
+	elemType := target.Type()
+	if target.Kind() == reflect.Slice {
+		elemType = elemType.Elem()
+	}
+
+	columns, err := csvColumns(elemType)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]int, len(columns))
+	for _, column := range columns {
+		byName[column.name] = column.index
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return errors.New("csv: missing header row")
+	}
+	header, records := records[0], records[1:]
+
+	if target.Kind() != reflect.Slice && len(records) != 1 {
+		return fmt.Errorf("csv: expected a single row, got %d", len(records))
+	}
+
+	rows := reflect.MakeSlice(reflect.SliceOf(elemType), len(records), len(records))
+	for i, record := range records {
+		row := rows.Index(i)
+		for j, cell := range record {
+			index, ok := byName[strings.TrimSpace(header[j])]
+			if !ok {
+				continue
+			}
+			if err := parseCell(row.Field(index), cell); err != nil {
+				// Row 1 is the header
+				return fmt.Errorf("csv: row %d, column %q: %w", i+2, header[j], err)
+			}
+		}
+	}
+
+	if target.Kind() == reflect.Slice {
+		target.Set(rows)
+	} else {
+		target.Set(rows.Index(0))
+	}
+	return nil
+}
+
+// parseCell sets a field from its text, empty cells leave pointers nil & string
+// lists empty
+func parseCell(field reflect.Value, cell string) error {
+	if field.Kind() == reflect.Pointer {
+		if cell == "" {
+			return nil
+		}
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+
+	if field.Type() == timeType {
+		at, err := time.Parse(time.RFC3339, cell)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(at))
+		return nil
+	}
+	if field.Type() == stringsType {
+		items := []string{}
+		for _, item := range strings.Split(cell, listSeparator) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(cell), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(cell), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(cell), field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(cell))
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return ErrCSVUnsupported
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package render
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"series-tracker/internal/models"
+)
+
+func TestCSVRoundTripSeries(t *testing.T) {
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	series := []models.Serie{
+		{ID: 1, Title: "Dark", Ranking: 9, Status: "Completed", CurrentEpisode: 26, TotalEpisodes: 26, Year: 2017, Genres: []string{"Crime", "Drama", "Mystery"}},
+		{ID: 2, Title: "Lost, again", Ranking: 5, Status: "Dropped", CurrentEpisode: 3, TotalEpisodes: 121, Genres: []string{}, DeletedAt: &deletedAt},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeCSV(&buf, series); err != nil {
+		t.Fatalf("EncodeCSV: %v", err)
+	}
+	header, _, _ := strings.Cut(buf.String(), "\n")
+	if header != "id,title,ranking,status,lastEpisodeWatched,totalEpisodes,year,genres,deletedAt" {
+		t.Errorf("header = %q", header)
+	}
+	if !strings.Contains(buf.String(), "Crime;Drama;Mystery") {
+		t.Errorf("genres aren't joined by semicolons:\n%s", buf.String())
+	}
+
+	var decoded []models.Serie
+	if err := DecodeCSV(&buf, &decoded); err != nil {
+		t.Fatalf("DecodeCSV: %v", err)
+	}
+	if len(decoded) != len(series) {
+		t.Fatalf("decoded %d series, want %d", len(decoded), len(series))
+	}
+	for i := range series {
+		if !decoded[i].Equal(series[i]) {
+			t.Errorf("series %d = %+v, want %+v", i, decoded[i], series[i])
+		}
+	}
+}
+
+func TestDecodeCSVSingleSerie(t *testing.T) {
+	var serie models.Serie
+	body := "title,status,totalEpisodes,genres\nDark,Watching,26, Crime ; Drama;\n"
+	if err := DecodeCSV(strings.NewReader(body), &serie); err != nil {
+		t.Fatalf("DecodeCSV: %v", err)
+	}
+	if want := []string{"Crime", "Drama"}; !reflect.DeepEqual(serie.Genres, want) {
+		t.Errorf("genres = %q, want %q", serie.Genres, want)
+	}
+	if serie.Title != "Dark" || serie.TotalEpisodes != 26 {
+		t.Errorf("serie = %+v", serie)
+	}
+}
+
+func TestCSVUnsupported(t *testing.T) {
+	type nested struct {
+		Values map[string]int `json:"values"`
+	}
+	if err := EncodeCSV(&bytes.Buffer{}, nested{}); err != ErrCSVUnsupported {
+		t.Errorf("EncodeCSV of a map field = %v, want ErrCSVUnsupported", err)
+	}
+}
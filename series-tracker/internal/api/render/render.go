@@ -0,0 +1,252 @@
+// Package render writes responses and reads request bodies in the format the
+// client asked for through the Accept and Content-Type headers: JSON, CSV, YAML,
+// MessagePack or NDJSON.
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// Media types of the supported formats
+const (
+	MIMEJSON    = echo.MIMEApplicationJSON
+	MIMECSV     = "text/csv"
+	MIMEYAML    = "application/yaml"
+	MIMEMsgPack = "application/msgpack"
+	MIMENDJSON  = "application/x-ndjson"
+)
+
+// aliases maps the other media types clients use for a format to its own
+var aliases = map[string]string{
+	"application/x-yaml":      MIMEYAML,
+	"text/yaml":               MIMEYAML,
+	"text/x-yaml":             MIMEYAML,
+	"application/x-msgpack":   MIMEMsgPack,
+	"application/vnd.msgpack": MIMEMsgPack,
+	"application/ndjson":      MIMENDJSON,
+	"application/jsonl":       MIMENDJSON,
+}
+
+// Errors returned when a request can't be served in the asked format
+var (
+	// ErrNotAcceptable is returned when none of the accepted formats is supported
+	ErrNotAcceptable = errors.New("none of the accepted media types is supported")
+	// ErrUnsupportedMediaType is returned when a request body is in an unsupported format
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// encoders writes a value in each format, JSON is handled by echo itself
+var encoders = map[string]func(w io.Writer, v interface{}) error{
+	MIMECSV:     EncodeCSV,
+	MIMEYAML:    encodeYAML,
+	MIMEMsgPack: encodeMsgPack,
+}
+
+// decoders reads a value in each format, JSON is handled by echo itself
+var decoders = map[string]func(r io.Reader, v interface{}) error{
+	MIMECSV:     DecodeCSV,
+	MIMEYAML:    decodeYAML,
+	MIMEMsgPack: decodeMsgPack,
+}
+
+// Negotiate picks the supported format the Accept header prefers, JSON when it's
+// empty or accepts anything. It fails with ErrNotAcceptable otherwise.
+func Negotiate(accept string) (string, error) {
+	if strings.TrimSpace(accept) == "" {
+		return MIMEJSON, nil
+	}
+
+	type candidate struct {
+		mediaType string
+		quality   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{mediaType, quality})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		switch mediaType := canonical(c.mediaType); mediaType {
+		case "*/*", "application/*":
+			return MIMEJSON, nil
+		case MIMEJSON, MIMECSV, MIMEYAML, MIMEMsgPack, MIMENDJSON:
+			return mediaType, nil
+		}
+	}
+	return "", ErrNotAcceptable
+}
+
+// canonical returns the media type a format is known by
+func canonical(mediaType string) string {
+	if alias, ok := aliases[mediaType]; ok {
+		return alias
+	}
+	return mediaType
+}
+
+// Render writes v with the given status in the format negotiated from the request's
+// Accept header, or answers 406 Not Acceptable. Slices are streamed one element per
+// line as NDJSON.
+func Render(c echo.Context, status int, v interface{}) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	format, err := Negotiate(c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return c.JSON(http.StatusNotAcceptable, map[string]string{"error": err.Error()})
+	}
+
+	switch format {
+	case MIMEJSON:
+		return c.JSON(status, v)
+	case MIMENDJSON:
+		return renderNDJSON(c, status, v)
+	}
+
+	// Encoded up front so a value the format can't represent is still reported as
+	// a proper error response
+	var buf bytes.Buffer
+	if err := encoders[format](&buf, v); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "could not encode response"})
+	}
+	contentType := format
+	if format == MIMECSV || format == MIMEYAML {
+		contentType += "; charset=utf-8"
+	}
+	return c.Blob(status, contentType, buf.Bytes())
+}
+
+// renderNDJSON writes every element of a slice as a JSON line, flushing as it goes
+// so large lists reach the client progressively. Anything else is a single line.
+func renderNDJSON(c echo.Context, status int, v interface{}) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, MIMENDJSON)
+	res.WriteHeader(status)
+
+	encoder := json.NewEncoder(res)
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice {
+		return encoder.Encode(v)
+	}
+	for i := 0; i < value.Len(); i++ {
+		if err := encoder.Encode(value.Index(i).Interface()); err != nil {
+			return err
+		}
+		if (i+1)%100 == 0 {
+			res.Flush()
+		}
+	}
+	return nil
+}
+
+// Bind decodes the request body into v according to its Content-Type, JSON
+// bodies go through echo's binder as before. It fails with ErrUnsupportedMediaType
+// for formats it can't read.
+func Bind(c echo.Context, v interface{}) error {
+	req := c.Request()
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return c.Bind(v)
+	}
+
+	switch mediaType = canonical(mediaType); mediaType {
+	case MIMEJSON:
+		return c.Bind(v)
+	case MIMENDJSON:
+		// A single NDJSON line is a JSON document
+		return json.NewDecoder(req.Body).Decode(v)
+	}
+	decode, ok := decoders[mediaType]
+	if !ok {
+		return ErrUnsupportedMediaType
+	}
+	return decode(req.Body, v)
+}
+
+// encodeYAML writes v as YAML with the same field names it has in JSON, by going
+// through its JSON encoding
+func encodeYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML, parsing it into a node keeps the field order
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle resets the flow style & quoting taken from JSON so the node is written
+// as plain block YAML
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// decodeYAML reads YAML into v using its JSON field names, by going through JSON
+func decodeYAML(r io.Reader, v interface{}) error {
+	var document interface{}
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// encodeMsgPack writes v as MessagePack with its JSON field names
+func encodeMsgPack(w io.Writer, v interface{}) error {
+	encoder := msgpack.NewEncoder(w)
+	encoder.SetCustomStructTag("json")
+	return encoder.Encode(v)
+}
+
+// decodeMsgPack reads MessagePack into v using its JSON field names
+func decodeMsgPack(r io.Reader, v interface{}) error {
+	decoder := msgpack.NewDecoder(r)
+	decoder.SetCustomStructTag("json")
+	return decoder.Decode(v)
+}
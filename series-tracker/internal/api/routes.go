@@ -1,25 +1,107 @@
 package api
 
 import (
+	"time"
+
+	"series-tracker/internal/api/gql"
 	"series-tracker/internal/api/handlers"
+	v2 "series-tracker/internal/api/v2"
 
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+// V1DeprecatedSince is when the v1 series routes were deprecated in favor of v2
+var V1DeprecatedSince = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+// DefaultV1Sunset is when the v1 series routes are expected to go away, unless
+// RouterConfig says otherwise
+var DefaultV1Sunset = time.Date(2027, time.April, 18, 0, 0, 0, 0, time.UTC)
+
 type RouterConfig struct {
-	SeriesHandler *handlers.SeriesHandler
+	SeriesHandler   *handlers.SeriesHandler
+	AuditHandler    *handlers.AuditHandler
+	EventsHandler   *handlers.EventsHandler
+	WSHandler       *handlers.WSHandler
+	WebhookHandler  *handlers.WebhookHandler
+	ImportHandler   *handlers.ImportJobHandler
+	BackupHandler   *handlers.BackupHandler
+	CalendarHandler *handlers.CalendarHandler
+	ActivityHandler *handlers.ActivityHandler
+	ShareHandler    *handlers.ShareHandler
+	CatalogHandler  *handlers.CatalogHandler
+	CacheHandler    *handlers.CacheHandler // nil when the series cache is disabled
+	V2Handler       *v2.SeriesHandler
+	GraphQL         *gql.Handler
+	V1Sunset        time.Time // Announced end of the v1 series routes, DefaultV1Sunset when zero
 }
 
 func SetupRoutes(e *echo.Echo, config *RouterConfig) {
-	e.GET("api/series", config.SeriesHandler.GetAllSeries)
-	e.GET("api/series/:id", config.SeriesHandler.GetSerie)
-	e.PUT("api/series/:id", config.SeriesHandler.UpdateSerie)
-	e.POST("api/series", config.SeriesHandler.CreateSerie)
-	e.DELETE("api/series/:id", config.SeriesHandler.DeleteSerie)
-	e.PATCH("api/series/:id/status", config.SeriesHandler.UpdateSerieStatus)
-	e.PATCH("api/series/:id/episode", config.SeriesHandler.IncrementEpisode)
-	e.PATCH("api/series/:id/upvote", config.SeriesHandler.UpvoteSerie)
-	e.PATCH("api/series/:id/downvote", config.SeriesHandler.DownvoteSerie)
+	// The v1 series routes v2 replaces keep working unchanged but announce their
+	// successor
+	sunset := config.V1Sunset
+	if sunset.IsZero() {
+		sunset = DefaultV1Sunset
+	}
+	v1 := Deprecated(V1DeprecatedSince, sunset, "/api/v2/series")
+
+	// Only the routes v2 replaces are deprecated, the others have no successor yet
+	series := e.Group("/api/series")
+	series.GET("", config.SeriesHandler.GetAllSeries, v1)
+	series.GET("/events", config.EventsHandler.StreamEvents)
+	series.GET("/:id", config.SeriesHandler.GetSerie, v1)
+	series.PUT("/:id", config.SeriesHandler.UpdateSerie, v1)
+	series.PATCH("/:id", config.SeriesHandler.PatchSerie)
+	series.POST("", config.SeriesHandler.CreateSerie, v1)
+	series.POST("/bulk", config.SeriesHandler.RunBulk)
+	series.DELETE("/:id", config.SeriesHandler.DeleteSerie, v1)
+	series.PATCH("/:id/status", config.SeriesHandler.UpdateSerieStatus, v1)
+	series.PATCH("/:id/episode", config.SeriesHandler.IncrementEpisode, v1)
+	series.PATCH("/:id/upvote", config.SeriesHandler.UpvoteSerie, v1)
+	series.PATCH("/:id/downvote", config.SeriesHandler.DownvoteSerie, v1)
+	series.POST("/:id/restore", config.SeriesHandler.RestoreSerie)
+	series.GET("/:id/schedule", config.CalendarHandler.GetSchedule)
+	series.PUT("/:id/schedule", config.CalendarHandler.SetSchedule)
+	series.DELETE("/:id/schedule", config.CalendarHandler.DeleteSchedule)
+	series.GET("/:id/sessions", config.CalendarHandler.GetSessions)
+	series.POST("/:id/sessions", config.CalendarHandler.CreateSession)
+	series.DELETE("/:id/sessions/:sessionId", config.CalendarHandler.DeleteSession)
+
+	e.GET("api/sync", config.SeriesHandler.GetChanges)
+	e.POST("api/sync", config.SeriesHandler.PushChanges)
+	e.GET("api/ws", config.WSHandler.Connect)
+	e.GET("api/export.csv", config.SeriesHandler.ExportCSV)
+	e.POST("api/import", config.SeriesHandler.ImportCSV)
+	e.POST("api/import/:format", config.ImportHandler.StartImport)
+	e.GET("api/import/jobs/:id", config.ImportHandler.GetImportJob)
+	e.GET("api/trash", config.SeriesHandler.GetTrash)
+	e.DELETE("api/trash/:id", config.SeriesHandler.PurgeSerie)
+	e.GET("api/audit", config.AuditHandler.GetAuditEntries)
+	e.POST("api/webhooks", config.WebhookHandler.CreateWebhook)
+	e.GET("api/webhooks", config.WebhookHandler.GetWebhooks)
+	e.GET("api/webhooks/:id", config.WebhookHandler.GetWebhook)
+	e.DELETE("api/webhooks/:id", config.WebhookHandler.DeleteWebhook)
+	e.GET("api/webhooks/:id/deliveries", config.WebhookHandler.GetDeliveries)
+	e.POST("api/webhooks/deliveries/:id/replay", config.WebhookHandler.ReplayDelivery)
+	e.POST("api/calendar/token", config.CalendarHandler.CreateCalendarToken)
+	e.DELETE("api/calendar/token", config.CalendarHandler.RevokeCalendarToken)
+	e.GET("api/calendar.ics", config.CalendarHandler.GetCalendar)
+	e.POST("api/activity/token", config.ActivityHandler.CreateActivityToken)
+	e.DELETE("api/activity/token", config.ActivityHandler.RevokeActivityToken)
+	e.GET("api/activity.atom", config.ActivityHandler.GetActivityFeed)
+	e.POST("api/shares", config.ShareHandler.CreateShareLink)
+	e.GET("api/shares", config.ShareHandler.GetShareLinks)
+	e.DELETE("api/shares/:id", config.ShareHandler.RevokeShareLink)
+	// Shared views are public & read-only, nothing but this GET is reachable by token
+	e.GET("share/:token", config.ShareHandler.GetSharedList)
+	e.GET("api/catalog", config.CatalogHandler.SearchCatalog)
+	e.GET("api/admin/backup", config.BackupHandler.GetBackup)
+	e.POST("api/admin/restore", config.BackupHandler.Restore)
+	if config.CacheHandler != nil {
+		e.GET("api/cache/stats", config.CacheHandler.GetCacheStats)
+	}
+	config.V2Handler.Register(e.Group("/api/v2"))
+	e.POST("/graphql", config.GraphQL.Serve)
+	e.GET("/graphql", config.GraphQL.Serve)
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 }
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"series-tracker/internal/api/gql"
+	"series-tracker/internal/api/handlers"
+	v2 "series-tracker/internal/api/v2"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// deprecatedRoutes tells for every route under /api/series whether it's deprecated,
+// which is only the case of the routes v2 replaces
+var deprecatedRoutes = map[string]bool{
+	"GET /api/series":                            true,
+	"POST /api/series":                           true,
+	"GET /api/series/:id":                        true,
+	"PUT /api/series/:id":                        true,
+	"DELETE /api/series/:id":                     true,
+	"PATCH /api/series/:id/status":               true,
+	"PATCH /api/series/:id/episode":              true,
+	"PATCH /api/series/:id/upvote":               true,
+	"PATCH /api/series/:id/downvote":             true,
+	"PATCH /api/series/:id":                      false,
+	"GET /api/series/events":                     false,
+	"POST /api/series/bulk":                      false,
+	"POST /api/series/:id/restore":               false,
+	"GET /api/series/:id/schedule":               false,
+	"PUT /api/series/:id/schedule":               false,
+	"DELETE /api/series/:id/schedule":            false,
+	"GET /api/series/:id/sessions":               false,
+	"POST /api/series/:id/sessions":              false,
+	"DELETE /api/series/:id/sessions/:sessionId": false,
+}
+
+// Handlers are nil & panic once reached, which happens after the deprecation
+// headers are set
+func TestSeriesRoutesDeprecation(t *testing.T) {
+	e := echo.New()
+	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error { return err },
+	}))
+	SetupRoutes(e, &RouterConfig{
+		SeriesHandler:   &handlers.SeriesHandler{},
+		EventsHandler:   &handlers.EventsHandler{},
+		CalendarHandler: &handlers.CalendarHandler{},
+		V2Handler:       &v2.SeriesHandler{},
+		GraphQL:         &gql.Handler{},
+	})
+
+	seen := map[string]bool{}
+	for _, route := range e.Routes() {
+		if route.Path != "/api/series" && !strings.HasPrefix(route.Path, "/api/series/") {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		want, ok := deprecatedRoutes[key]
+		if !ok {
+			t.Errorf("%s isn't listed, decide whether v2 replaces it", key)
+			continue
+		}
+		seen[key] = true
+
+		path := strings.NewReplacer(":id", "1", ":sessionId", "1").Replace(route.Path)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(route.Method, path, nil))
+
+		deprecated := rec.Header().Get("Deprecation") != ""
+		if deprecated != want || (rec.Header().Get("Sunset") != "") != want {
+			t.Errorf("%s deprecated = %t, want %t", key, deprecated, want)
+		}
+	}
+	for key := range deprecatedRoutes {
+		if !seen[key] {
+			t.Errorf("%s isn't registered", key)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/series", nil))
+	if rec.Header().Get("Deprecation") != "" {
+		t.Error("a v2 route is marked as deprecated")
+	}
+}
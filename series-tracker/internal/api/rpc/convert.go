@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"series-tracker/internal/api/rpc/seriesv1"
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Stored series statuses keyed by their protobuf value
+var storedStatuses = map[seriesv1.SerieStatus]string{
+	seriesv1.SerieStatus_SERIE_STATUS_WATCHING:      "Watching",
+	seriesv1.SerieStatus_SERIE_STATUS_PLAN_TO_WATCH: "Plan to Watch",
+	seriesv1.SerieStatus_SERIE_STATUS_DROPPED:       "Dropped",
+	seriesv1.SerieStatus_SERIE_STATUS_COMPLETED:     "Completed",
+}
+
+// Protobuf series statuses keyed by their stored value
+var protoStatuses = map[string]seriesv1.SerieStatus{}
+
+func init() {
+	for proto, stored := range storedStatuses {
+		protoStatuses[stored] = proto
+	}
+}
+
+// errorCode returns the gRPC code reported for an error returned by a service
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		return codes.InvalidArgument
+	case errors.Is(err, services.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, services.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, services.ErrBulkAborted):
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
+
+// statusError converts an error returned by a service into a gRPC status error,
+// internal failures don't leak details
+func statusError(err error) error {
+	code := errorCode(err)
+	if code == codes.Internal {
+		return status.Error(code, "internal server error")
+	}
+	return status.Error(code, err.Error())
+}
+
+// storedStatus converts a protobuf status into its stored value
+func storedStatus(s seriesv1.SerieStatus) (string, error) {
+	stored, ok := storedStatuses[s]
+	if !ok {
+		return "", fmt.Errorf("%w: invalid status %q", services.ErrInvalidInput, s)
+	}
+	return stored, nil
+}
+
+// toProto converts a series into its protobuf representation
+func toProto(serie models.Serie) *seriesv1.Serie {
+	p := &seriesv1.Serie{
+		Id:                 int64(serie.ID),
+		Title:              serie.Title,
+		Ranking:            int32(serie.Ranking),
+		Status:             protoStatuses[serie.Status],
+		LastEpisodeWatched: int32(serie.CurrentEpisode),
+		TotalEpisodes:      int32(serie.TotalEpisodes),
+		Year:               int32(serie.Year),
+		Genres:             serie.Genres,
+	}
+	if serie.DeletedAt != nil {
+		p.DeletedAt = timestamppb.New(*serie.DeletedAt)
+	}
+	return p
+}
+
+// toProtoList converts a list of series into their protobuf representation
+func toProtoList(series []models.Serie) *seriesv1.ListSeriesResponse {
+	list := make([]*seriesv1.Serie, len(series))
+	for i, serie := range series {
+		list[i] = toProto(serie)
+	}
+	return &seriesv1.ListSeriesResponse{Series: list}
+}
+
+// fromInput converts a protobuf input into a series with the given ID
+func fromInput(id int, in *seriesv1.SerieInput) (models.Serie, error) {
+	if in == nil {
+		return models.Serie{}, fmt.Errorf("%w: serie is required", services.ErrInvalidInput)
+	}
+	stored, err := storedStatus(in.Status)
+	if err != nil {
+		return models.Serie{}, err
+	}
+	return models.Serie{
+		ID:             id,
+		Title:          in.Title,
+		Ranking:        int(in.Ranking),
+		Status:         stored,
+		CurrentEpisode: int(in.LastEpisodeWatched),
+		TotalEpisodes:  int(in.TotalEpisodes),
+		Year:           int(in.Year),
+		Genres:         in.Genres,
+	}, nil
+}
+
+// changeToProto converts a series change into its protobuf representation
+func changeToProto(change models.SerieChange) *seriesv1.SerieChange {
+	p := &seriesv1.SerieChange{
+		Seq:       change.Seq,
+		Action:    change.Action,
+		SerieId:   int64(change.SerieID),
+		Actor:     change.Actor,
+		RequestId: change.RequestID,
+		At:        timestamppb.New(change.At),
+	}
+	if change.Serie != nil {
+		p.Serie = toProto(*change.Serie)
+	}
+	return p
+}
@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"series-tracker/internal/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{fmt.Errorf("%w: title is required", services.ErrInvalidInput), codes.InvalidArgument, "invalid input: title is required"},
+		{services.ErrNotInTrash, codes.NotFound, "series not found in trash"},
+		{fmt.Errorf("%w: Key (title)=(Dark) already exists.", services.ErrConflict), codes.AlreadyExists, "conflict: Key (title)=(Dark) already exists."},
+		{services.ErrBulkAborted, codes.Aborted, services.ErrBulkAborted.Error()},
+		{errors.New("connection refused"), codes.Internal, "internal server error"},
+	}
+	for _, test := range tests {
+		s := status.Convert(statusError(test.err))
+		if s.Code() != test.code || s.Message() != test.message {
+			t.Errorf("statusError(%v) = %s %q, want %s %q", test.err, s.Code(), s.Message(), test.code, test.message)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/gommon/random"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys identifying who performs a call & which request it belongs to,
+// matching the X-Actor & X-Request-ID headers of the REST API
+const (
+	MetadataActor     = "x-actor"
+	MetadataRequestID = "x-request-id"
+)
+
+// withRequestInfo stores the actor & request ID of a call in its context so the
+// service layer can record them in the audit log. Calls without a request ID get a
+// generated one, sent back in the response header.
+func withRequestInfo(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	requestID := first(MetadataRequestID)
+	if requestID == "" {
+		requestID = random.String(32)
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, requestID))
+
+	return services.WithRequestInfo(ctx, services.RequestInfo{
+		Actor:     first(MetadataActor),
+		RequestID: requestID,
+	})
+}
+
+// unaryRequestInfo stores RequestInfo in the context of unary calls
+func unaryRequestInfo(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withRequestInfo(ctx), req)
+}
+
+// streamRequestInfo stores RequestInfo in the context of streaming calls
+func streamRequestInfo(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &requestInfoStream{ServerStream: ss, ctx: withRequestInfo(ss.Context())})
+}
+
+// requestInfoStream overrides the context of a server stream
+type requestInfoStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying RequestInfo
+func (s *requestInfoStream) Context() context.Context {
+	return s.ctx
+}
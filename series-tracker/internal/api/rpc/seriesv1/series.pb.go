@@ -0,0 +1,1486 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: series/v1/series.proto
+
+package seriesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SerieStatus is the watching status of a series
+type SerieStatus int32
+
+const (
+	SerieStatus_SERIE_STATUS_UNSPECIFIED   SerieStatus = 0
+	SerieStatus_SERIE_STATUS_WATCHING      SerieStatus = 1
+	SerieStatus_SERIE_STATUS_PLAN_TO_WATCH SerieStatus = 2
+	SerieStatus_SERIE_STATUS_DROPPED       SerieStatus = 3
+	SerieStatus_SERIE_STATUS_COMPLETED     SerieStatus = 4
+)
+
+// Enum value maps for SerieStatus.
+var (
+	SerieStatus_name = map[int32]string{
+		0: "SERIE_STATUS_UNSPECIFIED",
+		1: "SERIE_STATUS_WATCHING",
+		2: "SERIE_STATUS_PLAN_TO_WATCH",
+		3: "SERIE_STATUS_DROPPED",
+		4: "SERIE_STATUS_COMPLETED",
+	}
+	SerieStatus_value = map[string]int32{
+		"SERIE_STATUS_UNSPECIFIED":   0,
+		"SERIE_STATUS_WATCHING":      1,
+		"SERIE_STATUS_PLAN_TO_WATCH": 2,
+		"SERIE_STATUS_DROPPED":       3,
+		"SERIE_STATUS_COMPLETED":     4,
+	}
+)
+
+func (x SerieStatus) Enum() *SerieStatus {
+	p := new(SerieStatus)
+	*p = x
+	return p
+}
+
+func (x SerieStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SerieStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_series_v1_series_proto_enumTypes[0].Descriptor()
+}
+
+func (SerieStatus) Type() protoreflect.EnumType {
+	return &file_series_v1_series_proto_enumTypes[0]
+}
+
+func (x SerieStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SerieStatus.Descriptor instead.
+func (SerieStatus) EnumDescriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{0}
+}
+
+// Serie is a tracked series
+type Serie struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title              string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Ranking            int32                  `protobuf:"varint,3,opt,name=ranking,proto3" json:"ranking,omitempty"`
+	Status             SerieStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=series.v1.SerieStatus" json:"status,omitempty"`
+	LastEpisodeWatched int32                  `protobuf:"varint,5,opt,name=last_episode_watched,json=lastEpisodeWatched,proto3" json:"last_episode_watched,omitempty"`
+	TotalEpisodes      int32                  `protobuf:"varint,6,opt,name=total_episodes,json=totalEpisodes,proto3" json:"total_episodes,omitempty"`
+	// Set while the series is in the trash
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Year the series started airing, 0 when unknown
+	Year          int32    `protobuf:"varint,8,opt,name=year,proto3" json:"year,omitempty"`
+	Genres        []string `protobuf:"bytes,9,rep,name=genres,proto3" json:"genres,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Serie) Reset() {
+	*x = Serie{}
+	mi := &file_series_v1_series_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Serie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Serie) ProtoMessage() {}
+
+func (x *Serie) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Serie.ProtoReflect.Descriptor instead.
+func (*Serie) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Serie) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Serie) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Serie) GetRanking() int32 {
+	if x != nil {
+		return x.Ranking
+	}
+	return 0
+}
+
+func (x *Serie) GetStatus() SerieStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SerieStatus_SERIE_STATUS_UNSPECIFIED
+}
+
+func (x *Serie) GetLastEpisodeWatched() int32 {
+	if x != nil {
+		return x.LastEpisodeWatched
+	}
+	return 0
+}
+
+func (x *Serie) GetTotalEpisodes() int32 {
+	if x != nil {
+		return x.TotalEpisodes
+	}
+	return 0
+}
+
+func (x *Serie) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Serie) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Serie) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+// SerieInput holds the values of a series to create or replace
+type SerieInput struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Title              string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Ranking            int32                  `protobuf:"varint,2,opt,name=ranking,proto3" json:"ranking,omitempty"`
+	Status             SerieStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=series.v1.SerieStatus" json:"status,omitempty"`
+	LastEpisodeWatched int32                  `protobuf:"varint,4,opt,name=last_episode_watched,json=lastEpisodeWatched,proto3" json:"last_episode_watched,omitempty"`
+	TotalEpisodes      int32                  `protobuf:"varint,5,opt,name=total_episodes,json=totalEpisodes,proto3" json:"total_episodes,omitempty"`
+	// Kept on replace when 0
+	Year int32 `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
+	// Kept on replace when empty
+	Genres        []string `protobuf:"bytes,7,rep,name=genres,proto3" json:"genres,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SerieInput) Reset() {
+	*x = SerieInput{}
+	mi := &file_series_v1_series_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerieInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerieInput) ProtoMessage() {}
+
+func (x *SerieInput) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerieInput.ProtoReflect.Descriptor instead.
+func (*SerieInput) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SerieInput) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SerieInput) GetRanking() int32 {
+	if x != nil {
+		return x.Ranking
+	}
+	return 0
+}
+
+func (x *SerieInput) GetStatus() SerieStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SerieStatus_SERIE_STATUS_UNSPECIFIED
+}
+
+func (x *SerieInput) GetLastEpisodeWatched() int32 {
+	if x != nil {
+		return x.LastEpisodeWatched
+	}
+	return 0
+}
+
+func (x *SerieInput) GetTotalEpisodes() int32 {
+	if x != nil {
+		return x.TotalEpisodes
+	}
+	return 0
+}
+
+func (x *SerieInput) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *SerieInput) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+type GetSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSerieRequest) Reset() {
+	*x = GetSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSerieRequest) ProtoMessage() {}
+
+func (x *GetSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSerieRequest.ProtoReflect.Descriptor instead.
+func (*GetSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSerieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeriesRequest) Reset() {
+	*x = ListSeriesRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeriesRequest) ProtoMessage() {}
+
+func (x *ListSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeriesRequest.ProtoReflect.Descriptor instead.
+func (*ListSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{3}
+}
+
+type ListSeriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*Serie               `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSeriesResponse) Reset() {
+	*x = ListSeriesResponse{}
+	mi := &file_series_v1_series_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSeriesResponse) ProtoMessage() {}
+
+func (x *ListSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSeriesResponse.ProtoReflect.Descriptor instead.
+func (*ListSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListSeriesResponse) GetSeries() []*Serie {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+type CreateSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Serie         *SerieInput            `protobuf:"bytes,1,opt,name=serie,proto3" json:"serie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSerieRequest) Reset() {
+	*x = CreateSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSerieRequest) ProtoMessage() {}
+
+func (x *CreateSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSerieRequest.ProtoReflect.Descriptor instead.
+func (*CreateSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateSerieRequest) GetSerie() *SerieInput {
+	if x != nil {
+		return x.Serie
+	}
+	return nil
+}
+
+type UpdateSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Serie         *SerieInput            `protobuf:"bytes,2,opt,name=serie,proto3" json:"serie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSerieRequest) Reset() {
+	*x = UpdateSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSerieRequest) ProtoMessage() {}
+
+func (x *UpdateSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSerieRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateSerieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSerieRequest) GetSerie() *SerieInput {
+	if x != nil {
+		return x.Serie
+	}
+	return nil
+}
+
+type DeleteSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSerieRequest) Reset() {
+	*x = DeleteSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSerieRequest) ProtoMessage() {}
+
+func (x *DeleteSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSerieRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteSerieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListTrashedSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashedSeriesRequest) Reset() {
+	*x = ListTrashedSeriesRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashedSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashedSeriesRequest) ProtoMessage() {}
+
+func (x *ListTrashedSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashedSeriesRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashedSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{8}
+}
+
+type RestoreSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreSerieRequest) Reset() {
+	*x = RestoreSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSerieRequest) ProtoMessage() {}
+
+func (x *RestoreSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSerieRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RestoreSerieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PurgeSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeSerieRequest) Reset() {
+	*x = PurgeSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeSerieRequest) ProtoMessage() {}
+
+func (x *PurgeSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeSerieRequest.ProtoReflect.Descriptor instead.
+func (*PurgeSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PurgeSerieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdateSerieStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        SerieStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=series.v1.SerieStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSerieStatusRequest) Reset() {
+	*x = UpdateSerieStatusRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSerieStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSerieStatusRequest) ProtoMessage() {}
+
+func (x *UpdateSerieStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSerieStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSerieStatusRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateSerieStatusRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSerieStatusRequest) GetStatus() SerieStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SerieStatus_SERIE_STATUS_UNSPECIFIED
+}
+
+type UpvoteSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpvoteSerieRequest) Reset() {
+	*x = UpvoteSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpvoteSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpvoteSerieRequest) ProtoMessage() {}
+
+func (x *UpvoteSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpvoteSerieRequest.ProtoReflect.Descriptor instead.
+func (*UpvoteSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpvoteSerieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DownvoteSerieRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownvoteSerieRequest) Reset() {
+	*x = DownvoteSerieRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownvoteSerieRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownvoteSerieRequest) ProtoMessage() {}
+
+func (x *DownvoteSerieRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownvoteSerieRequest.ProtoReflect.Descriptor instead.
+func (*DownvoteSerieRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DownvoteSerieRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type IncrementSerieEpisodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementSerieEpisodeRequest) Reset() {
+	*x = IncrementSerieEpisodeRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementSerieEpisodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementSerieEpisodeRequest) ProtoMessage() {}
+
+func (x *IncrementSerieEpisodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementSerieEpisodeRequest.ProtoReflect.Descriptor instead.
+func (*IncrementSerieEpisodeRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IncrementSerieEpisodeRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// BulkOperation is a single operation of a bulk request, op is one of "create",
+// "update", "delete", "status" or "episode"
+type BulkOperation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Op    string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	// Target of every operation but create
+	Id int64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Values for create and update
+	Serie *SerieInput `protobuf:"bytes,3,opt,name=serie,proto3" json:"serie,omitempty"`
+	// New status for status
+	Status        SerieStatus `protobuf:"varint,4,opt,name=status,proto3,enum=series.v1.SerieStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkOperation) Reset() {
+	*x = BulkOperation{}
+	mi := &file_series_v1_series_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkOperation) ProtoMessage() {}
+
+func (x *BulkOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkOperation.ProtoReflect.Descriptor instead.
+func (*BulkOperation) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BulkOperation) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *BulkOperation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BulkOperation) GetSerie() *SerieInput {
+	if x != nil {
+		return x.Serie
+	}
+	return nil
+}
+
+func (x *BulkOperation) GetStatus() SerieStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SerieStatus_SERIE_STATUS_UNSPECIFIED
+}
+
+type RunBulkRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Roll every operation back as soon as one fails
+	Atomic        bool             `protobuf:"varint,1,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	Operations    []*BulkOperation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunBulkRequest) Reset() {
+	*x = RunBulkRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunBulkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunBulkRequest) ProtoMessage() {}
+
+func (x *RunBulkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunBulkRequest.ProtoReflect.Descriptor instead.
+func (*RunBulkRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RunBulkRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *RunBulkRequest) GetOperations() []*BulkOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+// BulkResult is the outcome of a single bulk operation
+type BulkResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Index   int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Op      string                 `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`
+	Applied bool                   `protobuf:"varint,3,opt,name=applied,proto3" json:"applied,omitempty"`
+	Serie   *Serie                 `protobuf:"bytes,4,opt,name=serie,proto3" json:"serie,omitempty"`
+	// gRPC status code name of the failure, e.g. "InvalidArgument"
+	Code          string `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkResult) Reset() {
+	*x = BulkResult{}
+	mi := &file_series_v1_series_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkResult) ProtoMessage() {}
+
+func (x *BulkResult) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkResult.ProtoReflect.Descriptor instead.
+func (*BulkResult) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BulkResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkResult) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *BulkResult) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *BulkResult) GetSerie() *Serie {
+	if x != nil {
+		return x.Serie
+	}
+	return nil
+}
+
+func (x *BulkResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *BulkResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RunBulkResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False when an atomic request was rolled back
+	Committed     bool          `protobuf:"varint,1,opt,name=committed,proto3" json:"committed,omitempty"`
+	Results       []*BulkResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunBulkResponse) Reset() {
+	*x = RunBulkResponse{}
+	mi := &file_series_v1_series_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunBulkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunBulkResponse) ProtoMessage() {}
+
+func (x *RunBulkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunBulkResponse.ProtoReflect.Descriptor instead.
+func (*RunBulkResponse) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RunBulkResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *RunBulkResponse) GetResults() []*BulkResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type WatchSeriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream changes of these series, every series when empty
+	SerieIds      []int64 `protobuf:"varint,1,rep,packed,name=serie_ids,json=serieIds,proto3" json:"serie_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSeriesRequest) Reset() {
+	*x = WatchSeriesRequest{}
+	mi := &file_series_v1_series_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSeriesRequest) ProtoMessage() {}
+
+func (x *WatchSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSeriesRequest.ProtoReflect.Descriptor instead.
+func (*WatchSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchSeriesRequest) GetSerieIds() []int64 {
+	if x != nil {
+		return x.SerieIds
+	}
+	return nil
+}
+
+// SerieChange is a committed mutation of a series
+type SerieChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position in the change stream, increasing by one per change
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Mutation performed, e.g. "create", "update", "delete", "status", "upvote"
+	Action    string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	SerieId   int64                  `protobuf:"varint,3,opt,name=serie_id,json=serieId,proto3" json:"serie_id,omitempty"`
+	Actor     string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	RequestId string                 `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	At        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=at,proto3" json:"at,omitempty"`
+	// Series after the mutation, unset once deleted or purged
+	Serie         *Serie `protobuf:"bytes,7,opt,name=serie,proto3" json:"serie,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SerieChange) Reset() {
+	*x = SerieChange{}
+	mi := &file_series_v1_series_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SerieChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerieChange) ProtoMessage() {}
+
+func (x *SerieChange) ProtoReflect() protoreflect.Message {
+	mi := &file_series_v1_series_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerieChange.ProtoReflect.Descriptor instead.
+func (*SerieChange) Descriptor() ([]byte, []int) {
+	return file_series_v1_series_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SerieChange) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SerieChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *SerieChange) GetSerieId() int64 {
+	if x != nil {
+		return x.SerieId
+	}
+	return 0
+}
+
+func (x *SerieChange) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *SerieChange) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SerieChange) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *SerieChange) GetSerie() *Serie {
+	if x != nil {
+		return x.Serie
+	}
+	return nil
+}
+
+var File_series_v1_series_proto protoreflect.FileDescriptor
+
+const file_series_v1_series_proto_rawDesc = "" +
+	"\n" +
+	"\x16series/v1/series.proto\x12\tseries.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb7\x02\n" +
+	"\x05Serie\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aranking\x18\x03 \x01(\x05R\aranking\x12.\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x16.series.v1.SerieStatusR\x06status\x120\n" +
+	"\x14last_episode_watched\x18\x05 \x01(\x05R\x12lastEpisodeWatched\x12%\n" +
+	"\x0etotal_episodes\x18\x06 \x01(\x05R\rtotalEpisodes\x129\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x12\n" +
+	"\x04year\x18\b \x01(\x05R\x04year\x12\x16\n" +
+	"\x06genres\x18\t \x03(\tR\x06genres\"\xf1\x01\n" +
+	"\n" +
+	"SerieInput\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\aranking\x18\x02 \x01(\x05R\aranking\x12.\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x16.series.v1.SerieStatusR\x06status\x120\n" +
+	"\x14last_episode_watched\x18\x04 \x01(\x05R\x12lastEpisodeWatched\x12%\n" +
+	"\x0etotal_episodes\x18\x05 \x01(\x05R\rtotalEpisodes\x12\x12\n" +
+	"\x04year\x18\x06 \x01(\x05R\x04year\x12\x16\n" +
+	"\x06genres\x18\a \x03(\tR\x06genres\"!\n" +
+	"\x0fGetSerieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x13\n" +
+	"\x11ListSeriesRequest\">\n" +
+	"\x12ListSeriesResponse\x12(\n" +
+	"\x06series\x18\x01 \x03(\v2\x10.series.v1.SerieR\x06series\"A\n" +
+	"\x12CreateSerieRequest\x12+\n" +
+	"\x05serie\x18\x01 \x01(\v2\x15.series.v1.SerieInputR\x05serie\"Q\n" +
+	"\x12UpdateSerieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12+\n" +
+	"\x05serie\x18\x02 \x01(\v2\x15.series.v1.SerieInputR\x05serie\"$\n" +
+	"\x12DeleteSerieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1a\n" +
+	"\x18ListTrashedSeriesRequest\"%\n" +
+	"\x13RestoreSerieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"#\n" +
+	"\x11PurgeSerieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"Z\n" +
+	"\x18UpdateSerieStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.series.v1.SerieStatusR\x06status\"$\n" +
+	"\x12UpvoteSerieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
+	"\x14DownvoteSerieRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\".\n" +
+	"\x1cIncrementSerieEpisodeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x8c\x01\n" +
+	"\rBulkOperation\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12+\n" +
+	"\x05serie\x18\x03 \x01(\v2\x15.series.v1.SerieInputR\x05serie\x12.\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x16.series.v1.SerieStatusR\x06status\"b\n" +
+	"\x0eRunBulkRequest\x12\x16\n" +
+	"\x06atomic\x18\x01 \x01(\bR\x06atomic\x128\n" +
+	"\n" +
+	"operations\x18\x02 \x03(\v2\x18.series.v1.BulkOperationR\n" +
+	"operations\"\x9e\x01\n" +
+	"\n" +
+	"BulkResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x18\n" +
+	"\aapplied\x18\x03 \x01(\bR\aapplied\x12&\n" +
+	"\x05serie\x18\x04 \x01(\v2\x10.series.v1.SerieR\x05serie\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"`\n" +
+	"\x0fRunBulkResponse\x12\x1c\n" +
+	"\tcommitted\x18\x01 \x01(\bR\tcommitted\x12/\n" +
+	"\aresults\x18\x02 \x03(\v2\x15.series.v1.BulkResultR\aresults\"1\n" +
+	"\x12WatchSeriesRequest\x12\x1b\n" +
+	"\tserie_ids\x18\x01 \x03(\x03R\bserieIds\"\xdb\x01\n" +
+	"\vSerieChange\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x19\n" +
+	"\bserie_id\x18\x03 \x01(\x03R\aserieId\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x05 \x01(\tR\trequestId\x12*\n" +
+	"\x02at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12&\n" +
+	"\x05serie\x18\a \x01(\v2\x10.series.v1.SerieR\x05serie*\x9c\x01\n" +
+	"\vSerieStatus\x12\x1c\n" +
+	"\x18SERIE_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15SERIE_STATUS_WATCHING\x10\x01\x12\x1e\n" +
+	"\x1aSERIE_STATUS_PLAN_TO_WATCH\x10\x02\x12\x18\n" +
+	"\x14SERIE_STATUS_DROPPED\x10\x03\x12\x1a\n" +
+	"\x16SERIE_STATUS_COMPLETED\x10\x042\xe7\a\n" +
+	"\rSeriesService\x128\n" +
+	"\bGetSerie\x12\x1a.series.v1.GetSerieRequest\x1a\x10.series.v1.Serie\x12I\n" +
+	"\n" +
+	"ListSeries\x12\x1c.series.v1.ListSeriesRequest\x1a\x1d.series.v1.ListSeriesResponse\x12>\n" +
+	"\vCreateSerie\x12\x1d.series.v1.CreateSerieRequest\x1a\x10.series.v1.Serie\x12>\n" +
+	"\vUpdateSerie\x12\x1d.series.v1.UpdateSerieRequest\x1a\x10.series.v1.Serie\x12D\n" +
+	"\vDeleteSerie\x12\x1d.series.v1.DeleteSerieRequest\x1a\x16.google.protobuf.Empty\x12W\n" +
+	"\x11ListTrashedSeries\x12#.series.v1.ListTrashedSeriesRequest\x1a\x1d.series.v1.ListSeriesResponse\x12@\n" +
+	"\fRestoreSerie\x12\x1e.series.v1.RestoreSerieRequest\x1a\x10.series.v1.Serie\x12B\n" +
+	"\n" +
+	"PurgeSerie\x12\x1c.series.v1.PurgeSerieRequest\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\x11UpdateSerieStatus\x12#.series.v1.UpdateSerieStatusRequest\x1a\x10.series.v1.Serie\x12>\n" +
+	"\vUpvoteSerie\x12\x1d.series.v1.UpvoteSerieRequest\x1a\x10.series.v1.Serie\x12B\n" +
+	"\rDownvoteSerie\x12\x1f.series.v1.DownvoteSerieRequest\x1a\x10.series.v1.Serie\x12R\n" +
+	"\x15IncrementSerieEpisode\x12'.series.v1.IncrementSerieEpisodeRequest\x1a\x10.series.v1.Serie\x12@\n" +
+	"\aRunBulk\x12\x19.series.v1.RunBulkRequest\x1a\x1a.series.v1.RunBulkResponse\x12F\n" +
+	"\vWatchSeries\x12\x1d.series.v1.WatchSeriesRequest\x1a\x16.series.v1.SerieChange0\x01B3Z1series-tracker/internal/api/rpc/seriesv1;seriesv1b\x06proto3"
+
+var (
+	file_series_v1_series_proto_rawDescOnce sync.Once
+	file_series_v1_series_proto_rawDescData []byte
+)
+
+func file_series_v1_series_proto_rawDescGZIP() []byte {
+	file_series_v1_series_proto_rawDescOnce.Do(func() {
+		file_series_v1_series_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_series_v1_series_proto_rawDesc), len(file_series_v1_series_proto_rawDesc)))
+	})
+	return file_series_v1_series_proto_rawDescData
+}
+
+var file_series_v1_series_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_series_v1_series_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_series_v1_series_proto_goTypes = []any{
+	(SerieStatus)(0),                     // 0: series.v1.SerieStatus
+	(*Serie)(nil),                        // 1: series.v1.Serie
+	(*SerieInput)(nil),                   // 2: series.v1.SerieInput
+	(*GetSerieRequest)(nil),              // 3: series.v1.GetSerieRequest
+	(*ListSeriesRequest)(nil),            // 4: series.v1.ListSeriesRequest
+	(*ListSeriesResponse)(nil),           // 5: series.v1.ListSeriesResponse
+	(*CreateSerieRequest)(nil),           // 6: series.v1.CreateSerieRequest
+	(*UpdateSerieRequest)(nil),           // 7: series.v1.UpdateSerieRequest
+	(*DeleteSerieRequest)(nil),           // 8: series.v1.DeleteSerieRequest
+	(*ListTrashedSeriesRequest)(nil),     // 9: series.v1.ListTrashedSeriesRequest
+	(*RestoreSerieRequest)(nil),          // 10: series.v1.RestoreSerieRequest
+	(*PurgeSerieRequest)(nil),            // 11: series.v1.PurgeSerieRequest
+	(*UpdateSerieStatusRequest)(nil),     // 12: series.v1.UpdateSerieStatusRequest
+	(*UpvoteSerieRequest)(nil),           // 13: series.v1.UpvoteSerieRequest
+	(*DownvoteSerieRequest)(nil),         // 14: series.v1.DownvoteSerieRequest
+	(*IncrementSerieEpisodeRequest)(nil), // 15: series.v1.IncrementSerieEpisodeRequest
+	(*BulkOperation)(nil),                // 16: series.v1.BulkOperation
+	(*RunBulkRequest)(nil),               // 17: series.v1.RunBulkRequest
+	(*BulkResult)(nil),                   // 18: series.v1.BulkResult
+	(*RunBulkResponse)(nil),              // 19: series.v1.RunBulkResponse
+	(*WatchSeriesRequest)(nil),           // 20: series.v1.WatchSeriesRequest
+	(*SerieChange)(nil),                  // 21: series.v1.SerieChange
+	(*timestamppb.Timestamp)(nil),        // 22: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 23: google.protobuf.Empty
+}
+var file_series_v1_series_proto_depIdxs = []int32{
+	0,  // 0: series.v1.Serie.status:type_name -> series.v1.SerieStatus
+	22, // 1: series.v1.Serie.deleted_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: series.v1.SerieInput.status:type_name -> series.v1.SerieStatus
+	1,  // 3: series.v1.ListSeriesResponse.series:type_name -> series.v1.Serie
+	2,  // 4: series.v1.CreateSerieRequest.serie:type_name -> series.v1.SerieInput
+	2,  // 5: series.v1.UpdateSerieRequest.serie:type_name -> series.v1.SerieInput
+	0,  // 6: series.v1.UpdateSerieStatusRequest.status:type_name -> series.v1.SerieStatus
+	2,  // 7: series.v1.BulkOperation.serie:type_name -> series.v1.SerieInput
+	0,  // 8: series.v1.BulkOperation.status:type_name -> series.v1.SerieStatus
+	16, // 9: series.v1.RunBulkRequest.operations:type_name -> series.v1.BulkOperation
+	1,  // 10: series.v1.BulkResult.serie:type_name -> series.v1.Serie
+	18, // 11: series.v1.RunBulkResponse.results:type_name -> series.v1.BulkResult
+	22, // 12: series.v1.SerieChange.at:type_name -> google.protobuf.Timestamp
+	1,  // 13: series.v1.SerieChange.serie:type_name -> series.v1.Serie
+	3,  // 14: series.v1.SeriesService.GetSerie:input_type -> series.v1.GetSerieRequest
+	4,  // 15: series.v1.SeriesService.ListSeries:input_type -> series.v1.ListSeriesRequest
+	6,  // 16: series.v1.SeriesService.CreateSerie:input_type -> series.v1.CreateSerieRequest
+	7,  // 17: series.v1.SeriesService.UpdateSerie:input_type -> series.v1.UpdateSerieRequest
+	8,  // 18: series.v1.SeriesService.DeleteSerie:input_type -> series.v1.DeleteSerieRequest
+	9,  // 19: series.v1.SeriesService.ListTrashedSeries:input_type -> series.v1.ListTrashedSeriesRequest
+	10, // 20: series.v1.SeriesService.RestoreSerie:input_type -> series.v1.RestoreSerieRequest
+	11, // 21: series.v1.SeriesService.PurgeSerie:input_type -> series.v1.PurgeSerieRequest
+	12, // 22: series.v1.SeriesService.UpdateSerieStatus:input_type -> series.v1.UpdateSerieStatusRequest
+	13, // 23: series.v1.SeriesService.UpvoteSerie:input_type -> series.v1.UpvoteSerieRequest
+	14, // 24: series.v1.SeriesService.DownvoteSerie:input_type -> series.v1.DownvoteSerieRequest
+	15, // 25: series.v1.SeriesService.IncrementSerieEpisode:input_type -> series.v1.IncrementSerieEpisodeRequest
+	17, // 26: series.v1.SeriesService.RunBulk:input_type -> series.v1.RunBulkRequest
+	20, // 27: series.v1.SeriesService.WatchSeries:input_type -> series.v1.WatchSeriesRequest
+	1,  // 28: series.v1.SeriesService.GetSerie:output_type -> series.v1.Serie
+	5,  // 29: series.v1.SeriesService.ListSeries:output_type -> series.v1.ListSeriesResponse
+	1,  // 30: series.v1.SeriesService.CreateSerie:output_type -> series.v1.Serie
+	1,  // 31: series.v1.SeriesService.UpdateSerie:output_type -> series.v1.Serie
+	23, // 32: series.v1.SeriesService.DeleteSerie:output_type -> google.protobuf.Empty
+	5,  // 33: series.v1.SeriesService.ListTrashedSeries:output_type -> series.v1.ListSeriesResponse
+	1,  // 34: series.v1.SeriesService.RestoreSerie:output_type -> series.v1.Serie
+	23, // 35: series.v1.SeriesService.PurgeSerie:output_type -> google.protobuf.Empty
+	1,  // 36: series.v1.SeriesService.UpdateSerieStatus:output_type -> series.v1.Serie
+	1,  // 37: series.v1.SeriesService.UpvoteSerie:output_type -> series.v1.Serie
+	1,  // 38: series.v1.SeriesService.DownvoteSerie:output_type -> series.v1.Serie
+	1,  // 39: series.v1.SeriesService.IncrementSerieEpisode:output_type -> series.v1.Serie
+	19, // 40: series.v1.SeriesService.RunBulk:output_type -> series.v1.RunBulkResponse
+	21, // 41: series.v1.SeriesService.WatchSeries:output_type -> series.v1.SerieChange
+	28, // [28:42] is the sub-list for method output_type
+	14, // [14:28] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_series_v1_series_proto_init() }
+func file_series_v1_series_proto_init() {
+	if File_series_v1_series_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_series_v1_series_proto_rawDesc), len(file_series_v1_series_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_series_v1_series_proto_goTypes,
+		DependencyIndexes: file_series_v1_series_proto_depIdxs,
+		EnumInfos:         file_series_v1_series_proto_enumTypes,
+		MessageInfos:      file_series_v1_series_proto_msgTypes,
+	}.Build()
+	File_series_v1_series_proto = out.File
+	file_series_v1_series_proto_goTypes = nil
+	file_series_v1_series_proto_depIdxs = nil
+}
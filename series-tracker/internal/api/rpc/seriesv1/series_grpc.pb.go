@@ -0,0 +1,654 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: series/v1/series.proto
+
+package seriesv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SeriesService_GetSerie_FullMethodName              = "/series.v1.SeriesService/GetSerie"
+	SeriesService_ListSeries_FullMethodName            = "/series.v1.SeriesService/ListSeries"
+	SeriesService_CreateSerie_FullMethodName           = "/series.v1.SeriesService/CreateSerie"
+	SeriesService_UpdateSerie_FullMethodName           = "/series.v1.SeriesService/UpdateSerie"
+	SeriesService_DeleteSerie_FullMethodName           = "/series.v1.SeriesService/DeleteSerie"
+	SeriesService_ListTrashedSeries_FullMethodName     = "/series.v1.SeriesService/ListTrashedSeries"
+	SeriesService_RestoreSerie_FullMethodName          = "/series.v1.SeriesService/RestoreSerie"
+	SeriesService_PurgeSerie_FullMethodName            = "/series.v1.SeriesService/PurgeSerie"
+	SeriesService_UpdateSerieStatus_FullMethodName     = "/series.v1.SeriesService/UpdateSerieStatus"
+	SeriesService_UpvoteSerie_FullMethodName           = "/series.v1.SeriesService/UpvoteSerie"
+	SeriesService_DownvoteSerie_FullMethodName         = "/series.v1.SeriesService/DownvoteSerie"
+	SeriesService_IncrementSerieEpisode_FullMethodName = "/series.v1.SeriesService/IncrementSerieEpisode"
+	SeriesService_RunBulk_FullMethodName               = "/series.v1.SeriesService/RunBulk"
+	SeriesService_WatchSeries_FullMethodName           = "/series.v1.SeriesService/WatchSeries"
+)
+
+// SeriesServiceClient is the client API for SeriesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SeriesService mirrors the series operations of the REST API. Mutations are
+// recorded in the audit log under the actor given in the x-actor metadata.
+type SeriesServiceClient interface {
+	// GetSerie returns a series by its ID
+	GetSerie(ctx context.Context, in *GetSerieRequest, opts ...grpc.CallOption) (*Serie, error)
+	// ListSeries returns every series that isn't in the trash
+	ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error)
+	// CreateSerie creates a new series
+	CreateSerie(ctx context.Context, in *CreateSerieRequest, opts ...grpc.CallOption) (*Serie, error)
+	// UpdateSerie replaces every value of a series
+	UpdateSerie(ctx context.Context, in *UpdateSerieRequest, opts ...grpc.CallOption) (*Serie, error)
+	// DeleteSerie moves a series to the trash
+	DeleteSerie(ctx context.Context, in *DeleteSerieRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListTrashedSeries returns every series in the trash
+	ListTrashedSeries(ctx context.Context, in *ListTrashedSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error)
+	// RestoreSerie takes a series out of the trash
+	RestoreSerie(ctx context.Context, in *RestoreSerieRequest, opts ...grpc.CallOption) (*Serie, error)
+	// PurgeSerie permanently deletes a trashed series
+	PurgeSerie(ctx context.Context, in *PurgeSerieRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UpdateSerieStatus changes the status of a series
+	UpdateSerieStatus(ctx context.Context, in *UpdateSerieStatusRequest, opts ...grpc.CallOption) (*Serie, error)
+	// UpvoteSerie increases the ranking of a series by one
+	UpvoteSerie(ctx context.Context, in *UpvoteSerieRequest, opts ...grpc.CallOption) (*Serie, error)
+	// DownvoteSerie decreases the ranking of a series by one
+	DownvoteSerie(ctx context.Context, in *DownvoteSerieRequest, opts ...grpc.CallOption) (*Serie, error)
+	// IncrementSerieEpisode increases the last episode watched of a series by one
+	IncrementSerieEpisode(ctx context.Context, in *IncrementSerieEpisodeRequest, opts ...grpc.CallOption) (*Serie, error)
+	// RunBulk runs several operations, all in one transaction when atomic
+	RunBulk(ctx context.Context, in *RunBulkRequest, opts ...grpc.CallOption) (*RunBulkResponse, error)
+	// WatchSeries streams every committed change made after the call started
+	WatchSeries(ctx context.Context, in *WatchSeriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SerieChange], error)
+}
+
+type seriesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSeriesServiceClient(cc grpc.ClientConnInterface) SeriesServiceClient {
+	return &seriesServiceClient{cc}
+}
+
+func (c *seriesServiceClient) GetSerie(ctx context.Context, in *GetSerieRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_GetSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) ListSeries(ctx context.Context, in *ListSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSeriesResponse)
+	err := c.cc.Invoke(ctx, SeriesService_ListSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) CreateSerie(ctx context.Context, in *CreateSerieRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_CreateSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) UpdateSerie(ctx context.Context, in *UpdateSerieRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_UpdateSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) DeleteSerie(ctx context.Context, in *DeleteSerieRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SeriesService_DeleteSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) ListTrashedSeries(ctx context.Context, in *ListTrashedSeriesRequest, opts ...grpc.CallOption) (*ListSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSeriesResponse)
+	err := c.cc.Invoke(ctx, SeriesService_ListTrashedSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) RestoreSerie(ctx context.Context, in *RestoreSerieRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_RestoreSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) PurgeSerie(ctx context.Context, in *PurgeSerieRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SeriesService_PurgeSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) UpdateSerieStatus(ctx context.Context, in *UpdateSerieStatusRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_UpdateSerieStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) UpvoteSerie(ctx context.Context, in *UpvoteSerieRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_UpvoteSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) DownvoteSerie(ctx context.Context, in *DownvoteSerieRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_DownvoteSerie_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) IncrementSerieEpisode(ctx context.Context, in *IncrementSerieEpisodeRequest, opts ...grpc.CallOption) (*Serie, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Serie)
+	err := c.cc.Invoke(ctx, SeriesService_IncrementSerieEpisode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) RunBulk(ctx context.Context, in *RunBulkRequest, opts ...grpc.CallOption) (*RunBulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunBulkResponse)
+	err := c.cc.Invoke(ctx, SeriesService_RunBulk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seriesServiceClient) WatchSeries(ctx context.Context, in *WatchSeriesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SerieChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SeriesService_ServiceDesc.Streams[0], SeriesService_WatchSeries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSeriesRequest, SerieChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SeriesService_WatchSeriesClient = grpc.ServerStreamingClient[SerieChange]
+
+// SeriesServiceServer is the server API for SeriesService service.
+// All implementations must embed UnimplementedSeriesServiceServer
+// for forward compatibility.
+//
+// SeriesService mirrors the series operations of the REST API. Mutations are
+// recorded in the audit log under the actor given in the x-actor metadata.
+type SeriesServiceServer interface {
+	// GetSerie returns a series by its ID
+	GetSerie(context.Context, *GetSerieRequest) (*Serie, error)
+	// ListSeries returns every series that isn't in the trash
+	ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error)
+	// CreateSerie creates a new series
+	CreateSerie(context.Context, *CreateSerieRequest) (*Serie, error)
+	// UpdateSerie replaces every value of a series
+	UpdateSerie(context.Context, *UpdateSerieRequest) (*Serie, error)
+	// DeleteSerie moves a series to the trash
+	DeleteSerie(context.Context, *DeleteSerieRequest) (*emptypb.Empty, error)
+	// ListTrashedSeries returns every series in the trash
+	ListTrashedSeries(context.Context, *ListTrashedSeriesRequest) (*ListSeriesResponse, error)
+	// RestoreSerie takes a series out of the trash
+	RestoreSerie(context.Context, *RestoreSerieRequest) (*Serie, error)
+	// PurgeSerie permanently deletes a trashed series
+	PurgeSerie(context.Context, *PurgeSerieRequest) (*emptypb.Empty, error)
+	// UpdateSerieStatus changes the status of a series
+	UpdateSerieStatus(context.Context, *UpdateSerieStatusRequest) (*Serie, error)
+	// UpvoteSerie increases the ranking of a series by one
+	UpvoteSerie(context.Context, *UpvoteSerieRequest) (*Serie, error)
+	// DownvoteSerie decreases the ranking of a series by one
+	DownvoteSerie(context.Context, *DownvoteSerieRequest) (*Serie, error)
+	// IncrementSerieEpisode increases the last episode watched of a series by one
+	IncrementSerieEpisode(context.Context, *IncrementSerieEpisodeRequest) (*Serie, error)
+	// RunBulk runs several operations, all in one transaction when atomic
+	RunBulk(context.Context, *RunBulkRequest) (*RunBulkResponse, error)
+	// WatchSeries streams every committed change made after the call started
+	WatchSeries(*WatchSeriesRequest, grpc.ServerStreamingServer[SerieChange]) error
+	mustEmbedUnimplementedSeriesServiceServer()
+}
+
+// UnimplementedSeriesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSeriesServiceServer struct{}
+
+func (UnimplementedSeriesServiceServer) GetSerie(context.Context, *GetSerieRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) ListSeries(context.Context, *ListSeriesRequest) (*ListSeriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSeries not implemented")
+}
+func (UnimplementedSeriesServiceServer) CreateSerie(context.Context, *CreateSerieRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) UpdateSerie(context.Context, *UpdateSerieRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) DeleteSerie(context.Context, *DeleteSerieRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) ListTrashedSeries(context.Context, *ListTrashedSeriesRequest) (*ListSeriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTrashedSeries not implemented")
+}
+func (UnimplementedSeriesServiceServer) RestoreSerie(context.Context, *RestoreSerieRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) PurgeSerie(context.Context, *PurgeSerieRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) UpdateSerieStatus(context.Context, *UpdateSerieStatusRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSerieStatus not implemented")
+}
+func (UnimplementedSeriesServiceServer) UpvoteSerie(context.Context, *UpvoteSerieRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpvoteSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) DownvoteSerie(context.Context, *DownvoteSerieRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method DownvoteSerie not implemented")
+}
+func (UnimplementedSeriesServiceServer) IncrementSerieEpisode(context.Context, *IncrementSerieEpisodeRequest) (*Serie, error) {
+	return nil, status.Error(codes.Unimplemented, "method IncrementSerieEpisode not implemented")
+}
+func (UnimplementedSeriesServiceServer) RunBulk(context.Context, *RunBulkRequest) (*RunBulkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunBulk not implemented")
+}
+func (UnimplementedSeriesServiceServer) WatchSeries(*WatchSeriesRequest, grpc.ServerStreamingServer[SerieChange]) error {
+	return status.Error(codes.Unimplemented, "method WatchSeries not implemented")
+}
+func (UnimplementedSeriesServiceServer) mustEmbedUnimplementedSeriesServiceServer() {}
+func (UnimplementedSeriesServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSeriesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SeriesServiceServer will
+// result in compilation errors.
+type UnsafeSeriesServiceServer interface {
+	mustEmbedUnimplementedSeriesServiceServer()
+}
+
+func RegisterSeriesServiceServer(s grpc.ServiceRegistrar, srv SeriesServiceServer) {
+	// If the following call panics, it indicates UnimplementedSeriesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SeriesService_ServiceDesc, srv)
+}
+
+func _SeriesService_GetSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).GetSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_GetSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).GetSerie(ctx, req.(*GetSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_ListSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).ListSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_ListSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).ListSeries(ctx, req.(*ListSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_CreateSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).CreateSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_CreateSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).CreateSerie(ctx, req.(*CreateSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_UpdateSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).UpdateSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_UpdateSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).UpdateSerie(ctx, req.(*UpdateSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_DeleteSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).DeleteSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_DeleteSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).DeleteSerie(ctx, req.(*DeleteSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_ListTrashedSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashedSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).ListTrashedSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_ListTrashedSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).ListTrashedSeries(ctx, req.(*ListTrashedSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_RestoreSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).RestoreSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_RestoreSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).RestoreSerie(ctx, req.(*RestoreSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_PurgeSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).PurgeSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_PurgeSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).PurgeSerie(ctx, req.(*PurgeSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_UpdateSerieStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSerieStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).UpdateSerieStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_UpdateSerieStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).UpdateSerieStatus(ctx, req.(*UpdateSerieStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_UpvoteSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpvoteSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).UpvoteSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_UpvoteSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).UpvoteSerie(ctx, req.(*UpvoteSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_DownvoteSerie_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DownvoteSerieRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).DownvoteSerie(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_DownvoteSerie_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).DownvoteSerie(ctx, req.(*DownvoteSerieRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_IncrementSerieEpisode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementSerieEpisodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).IncrementSerieEpisode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_IncrementSerieEpisode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).IncrementSerieEpisode(ctx, req.(*IncrementSerieEpisodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_RunBulk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunBulkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeriesServiceServer).RunBulk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeriesService_RunBulk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeriesServiceServer).RunBulk(ctx, req.(*RunBulkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeriesService_WatchSeries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSeriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeriesServiceServer).WatchSeries(m, &grpc.GenericServerStream[WatchSeriesRequest, SerieChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SeriesService_WatchSeriesServer = grpc.ServerStreamingServer[SerieChange]
+
+// SeriesService_ServiceDesc is the grpc.ServiceDesc for SeriesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SeriesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "series.v1.SeriesService",
+	HandlerType: (*SeriesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSerie",
+			Handler:    _SeriesService_GetSerie_Handler,
+		},
+		{
+			MethodName: "ListSeries",
+			Handler:    _SeriesService_ListSeries_Handler,
+		},
+		{
+			MethodName: "CreateSerie",
+			Handler:    _SeriesService_CreateSerie_Handler,
+		},
+		{
+			MethodName: "UpdateSerie",
+			Handler:    _SeriesService_UpdateSerie_Handler,
+		},
+		{
+			MethodName: "DeleteSerie",
+			Handler:    _SeriesService_DeleteSerie_Handler,
+		},
+		{
+			MethodName: "ListTrashedSeries",
+			Handler:    _SeriesService_ListTrashedSeries_Handler,
+		},
+		{
+			MethodName: "RestoreSerie",
+			Handler:    _SeriesService_RestoreSerie_Handler,
+		},
+		{
+			MethodName: "PurgeSerie",
+			Handler:    _SeriesService_PurgeSerie_Handler,
+		},
+		{
+			MethodName: "UpdateSerieStatus",
+			Handler:    _SeriesService_UpdateSerieStatus_Handler,
+		},
+		{
+			MethodName: "UpvoteSerie",
+			Handler:    _SeriesService_UpvoteSerie_Handler,
+		},
+		{
+			MethodName: "DownvoteSerie",
+			Handler:    _SeriesService_DownvoteSerie_Handler,
+		},
+		{
+			MethodName: "IncrementSerieEpisode",
+			Handler:    _SeriesService_IncrementSerieEpisode_Handler,
+		},
+		{
+			MethodName: "RunBulk",
+			Handler:    _SeriesService_RunBulk_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSeries",
+			Handler:       _SeriesService_WatchSeries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "series/v1/series.proto",
+}
@@ -0,0 +1,223 @@
+// Package rpc serves the series domain over gRPC, see proto/series/v1 for the
+// service definition. Calls map onto the same services as the REST API.
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"series-tracker/internal/api/rpc/seriesv1"
+	"series-tracker/internal/broker"
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// watchBuffer is how many changes a WatchSeries stream may lag behind before it's
+// closed
+const watchBuffer = 256
+
+// Server holds all the dependencies for the gRPC series service
+type Server struct {
+	seriesv1.UnimplementedSeriesServiceServer
+
+	service services.SeriesService
+	changes *broker.Broker
+}
+
+// NewServer returns a new Server with the given dependencies
+func NewServer(service services.SeriesService, changes *broker.Broker) *Server {
+	return &Server{
+		service: service,
+		changes: changes,
+	}
+}
+
+// NewGRPCServer returns a grpc.Server serving the series service with server
+// reflection enabled, every call carries RequestInfo built from its metadata
+func NewGRPCServer(server *Server) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryRequestInfo),
+		grpc.ChainStreamInterceptor(streamRequestInfo),
+	)
+	seriesv1.RegisterSeriesServiceServer(s, server)
+	reflection.Register(s)
+	return s
+}
+
+// serieResponse converts the result of a single series service call
+func serieResponse(serie *models.Serie, err error) (*seriesv1.Serie, error) {
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProto(*serie), nil
+}
+
+// GetSerie returns a series by its ID
+func (s *Server) GetSerie(ctx context.Context, req *seriesv1.GetSerieRequest) (*seriesv1.Serie, error) {
+	return serieResponse(s.service.GetSerieByID(ctx, int(req.Id)))
+}
+
+// ListSeries returns every series that isn't in the trash
+func (s *Server) ListSeries(ctx context.Context, req *seriesv1.ListSeriesRequest) (*seriesv1.ListSeriesResponse, error) {
+	series, err := s.service.GetAllSeries(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoList(series), nil
+}
+
+// CreateSerie creates a new series
+func (s *Server) CreateSerie(ctx context.Context, req *seriesv1.CreateSerieRequest) (*seriesv1.Serie, error) {
+	serie, err := fromInput(0, req.Serie)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return serieResponse(s.service.CreateSerie(ctx, serie))
+}
+
+// UpdateSerie replaces every value of a series
+func (s *Server) UpdateSerie(ctx context.Context, req *seriesv1.UpdateSerieRequest) (*seriesv1.Serie, error) {
+	serie, err := fromInput(int(req.Id), req.Serie)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return serieResponse(s.service.UpdateSerie(ctx, serie))
+}
+
+// DeleteSerie moves a series to the trash
+func (s *Server) DeleteSerie(ctx context.Context, req *seriesv1.DeleteSerieRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteSerie(ctx, int(req.Id)); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListTrashedSeries returns every series in the trash
+func (s *Server) ListTrashedSeries(ctx context.Context, req *seriesv1.ListTrashedSeriesRequest) (*seriesv1.ListSeriesResponse, error) {
+	series, err := s.service.GetTrashedSeries(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoList(series), nil
+}
+
+// RestoreSerie takes a series out of the trash
+func (s *Server) RestoreSerie(ctx context.Context, req *seriesv1.RestoreSerieRequest) (*seriesv1.Serie, error) {
+	return serieResponse(s.service.RestoreSerie(ctx, int(req.Id)))
+}
+
+// PurgeSerie permanently deletes a trashed series
+func (s *Server) PurgeSerie(ctx context.Context, req *seriesv1.PurgeSerieRequest) (*emptypb.Empty, error) {
+	if err := s.service.PurgeSerie(ctx, int(req.Id)); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// UpdateSerieStatus changes the status of a series
+func (s *Server) UpdateSerieStatus(ctx context.Context, req *seriesv1.UpdateSerieStatusRequest) (*seriesv1.Serie, error) {
+	stored, err := storedStatus(req.Status)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return serieResponse(s.service.UpdateSerieStatus(ctx, int(req.Id), stored))
+}
+
+// UpvoteSerie increases the ranking of a series by one
+func (s *Server) UpvoteSerie(ctx context.Context, req *seriesv1.UpvoteSerieRequest) (*seriesv1.Serie, error) {
+	return serieResponse(s.service.UpvoteSerie(ctx, int(req.Id)))
+}
+
+// DownvoteSerie decreases the ranking of a series by one
+func (s *Server) DownvoteSerie(ctx context.Context, req *seriesv1.DownvoteSerieRequest) (*seriesv1.Serie, error) {
+	return serieResponse(s.service.DownvoteSerie(ctx, int(req.Id)))
+}
+
+// IncrementSerieEpisode increases the last episode watched of a series by one
+func (s *Server) IncrementSerieEpisode(ctx context.Context, req *seriesv1.IncrementSerieEpisodeRequest) (*seriesv1.Serie, error) {
+	return serieResponse(s.service.IncrementSerieEpisode(ctx, int(req.Id)))
+}
+
+// RunBulk runs several operations, reporting the outcome of each
+func (s *Server) RunBulk(ctx context.Context, req *seriesv1.RunBulkRequest) (*seriesv1.RunBulkResponse, error) {
+	ops := make([]models.BulkOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		ops[i] = models.BulkOperation{Op: op.Op, ID: int(op.Id)}
+		if op.Serie != nil {
+			serie, err := fromInput(int(op.Id), op.Serie)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "operation %d: %v", i, err)
+			}
+			ops[i].Serie = &serie
+		}
+		if op.Status != seriesv1.SerieStatus_SERIE_STATUS_UNSPECIFIED {
+			stored, err := storedStatus(op.Status)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "operation %d: %v", i, err)
+			}
+			ops[i].Status = stored
+		}
+	}
+
+	results, err := s.service.RunBulk(ctx, ops, req.Atomic)
+	if err != nil && !errors.Is(err, services.ErrBulkAborted) {
+		return nil, statusError(err)
+	}
+
+	res := &seriesv1.RunBulkResponse{
+		Committed: err == nil,
+		Results:   make([]*seriesv1.BulkResult, len(results)),
+	}
+	for i, result := range results {
+		r := &seriesv1.BulkResult{
+			Index:   int32(result.Index),
+			Op:      result.Op,
+			Applied: result.Applied,
+		}
+		if result.Serie != nil {
+			r.Serie = toProto(*result.Serie)
+		}
+		if result.Err != nil {
+			st := status.Convert(statusError(result.Err))
+			r.Code = st.Code().String()
+			r.Error = st.Message()
+		}
+		res.Results[i] = r
+	}
+	return res, nil
+}
+
+// WatchSeries streams committed changes until the client goes away, streams that
+// can't keep up are ended with ResourceExhausted and have to be restarted
+func (s *Server) WatchSeries(req *seriesv1.WatchSeriesRequest, stream seriesv1.SeriesService_WatchSeriesServer) error {
+	ids := map[int]bool{}
+	for _, id := range req.SerieIds {
+		ids[int(id)] = true
+	}
+
+	sub := s.changes.Subscribe(watchBuffer)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case change, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher fell behind, restart the stream")
+			}
+			if len(ids) > 0 && !ids[change.SerieID] {
+				continue
+			}
+			if err := stream.Send(changeToProto(change)); err != nil {
+				return err
+			}
+		}
+	}
+}
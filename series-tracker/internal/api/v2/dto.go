@@ -0,0 +1,145 @@
+// Package v2 serves the second version of the REST API, a cleaner resource model
+// over the same services as v1 with enum-coded statuses and consistent envelopes.
+package v2
+
+import (
+	"fmt"
+	"time"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/services"
+)
+
+// Status codes used by v2 instead of the v1 display strings
+const (
+	StatusWatching    = "watching"
+	StatusPlanToWatch = "plan_to_watch"
+	StatusDropped     = "dropped"
+	StatusCompleted   = "completed"
+)
+
+// statusCodes maps the stored statuses to their v2 codes
+var statusCodes = map[string]string{
+	"Watching":      StatusWatching,
+	"Plan to Watch": StatusPlanToWatch,
+	"Dropped":       StatusDropped,
+	"Completed":     StatusCompleted,
+}
+
+// storedStatuses maps the v2 status codes back to the stored statuses
+var storedStatuses = map[string]string{
+	StatusWatching:    "Watching",
+	StatusPlanToWatch: "Plan to Watch",
+	StatusDropped:     "Dropped",
+	StatusCompleted:   "Completed",
+}
+
+// Series represents a series as exposed by v2.
+type Series struct {
+	ID        int        `json:"id"`                  // Unique identifier for the series
+	Title     string     `json:"title"`               // Title of the series
+	Score     int        `json:"score"`               // Score of the series used for ranking
+	Status    string     `json:"status"`              // Status code; "watching", "plan_to_watch", "dropped", "completed"
+	Progress  Progress   `json:"progress"`            // Episode progress
+	Year      int        `json:"year,omitempty"`      // Year the series started airing, absent if unknown
+	Genres    []string   `json:"genres"`              // Genres of the series
+	DeletedAt *time.Time `json:"deletedAt,omitempty"` // Moment the series was trashed, absent if it isn't
+}
+
+// Progress represents how far along a series is.
+type Progress struct {
+	Watched int `json:"watched"` // Last episode watched
+	Total   int `json:"total"`   // Quantity of episodes in the series
+}
+
+// SeriesInput represents the payload to create or replace a series.
+type SeriesInput struct {
+	Title    string   `json:"title"`    // Title of the series
+	Score    int      `json:"score"`    // Score of the series used for ranking
+	Status   string   `json:"status"`   // Status code; "watching", "plan_to_watch", "dropped", "completed"
+	Progress Progress `json:"progress"` // Episode progress
+	Year     int      `json:"year"`     // Year the series started airing, kept when 0
+	Genres   []string `json:"genres"`   // Genres of the series, kept when empty
+}
+
+// StatusInput represents the payload to change the status of a series.
+type StatusInput struct {
+	Status string `json:"status"` // Status code; "watching", "plan_to_watch", "dropped", "completed"
+}
+
+// Envelope wraps every successful v2 response.
+type Envelope struct {
+	Data any   `json:"data"`           // The requested resource or list of resources
+	Meta *Meta `json:"meta,omitempty"` // Extra information about lists
+}
+
+// Meta holds information about a list response.
+type Meta struct {
+	Count int `json:"count"` // Number of items in data
+}
+
+// ErrorEnvelope wraps every failed v2 response.
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes what went wrong with a v2 request.
+type ErrorBody struct {
+	Code    string `json:"code"`    // Machine readable error code; "invalid_input", "not_found", "internal", ...
+	Message string `json:"message"` // Human readable description
+}
+
+// toSeries converts a stored series into its v2 representation
+func toSeries(serie models.Serie) Series {
+	genres := serie.Genres
+	if genres == nil {
+		genres = []string{}
+	}
+	return Series{
+		ID:        serie.ID,
+		Title:     serie.Title,
+		Score:     serie.Ranking,
+		Status:    statusCodes[serie.Status],
+		Progress:  Progress{Watched: serie.CurrentEpisode, Total: serie.TotalEpisodes},
+		Year:      serie.Year,
+		Genres:    genres,
+		DeletedAt: serie.DeletedAt,
+	}
+}
+
+// toSeriesList converts a list of stored series into their v2 representation
+func toSeriesList(series []models.Serie) []Series {
+	list := make([]Series, 0, len(series))
+	for _, serie := range series {
+		list = append(list, toSeries(serie))
+	}
+	return list
+}
+
+// storedStatus converts a v2 status code into the stored status
+func storedStatus(code string) (string, error) {
+	status, ok := storedStatuses[code]
+	if !ok {
+		return "", fmt.Errorf("%w: invalid status %q", services.ErrInvalidInput, code)
+	}
+	return status, nil
+}
+
+// toModel converts a v2 payload into a stored series with the given ID
+func (in SeriesInput) toModel(id int) (models.Serie, error) {
+	status, err := storedStatus(in.Status)
+	if err != nil {
+		return models.Serie{}, err
+	}
+
+	return models.Serie{
+		ID:             id,
+		Title:          in.Title,
+		Ranking:        in.Score,
+		Status:         status,
+		CurrentEpisode: in.Progress.Watched,
+		TotalEpisodes:  in.Progress.Total,
+		Year:           in.Year,
+		Genres:         in.Genres,
+	}, nil
+}
@@ -0,0 +1,308 @@
+package v2
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"series-tracker/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SeriesHandler holds all the dependencies for the v2 series handler
+type SeriesHandler struct {
+	service services.SeriesService
+}
+
+// NewSeriesHandler returns a new SeriesHandler with the given dependencies
+func NewSeriesHandler(service services.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		service: service,
+	}
+}
+
+// Register adds every v2 route to the given group, mounted at /api/v2
+func (h *SeriesHandler) Register(g *echo.Group) {
+	g.GET("/series", h.ListSeries)
+	g.POST("/series", h.CreateSeries)
+	g.GET("/series/:id", h.GetSeries)
+	g.PUT("/series/:id", h.ReplaceSeries)
+	g.DELETE("/series/:id", h.DeleteSeries)
+	g.PUT("/series/:id/status", h.SetStatus)
+	g.POST("/series/:id/upvote", h.Upvote)
+	g.POST("/series/:id/downvote", h.Downvote)
+	g.POST("/series/:id/next-episode", h.NextEpisode)
+}
+
+// respondError writes a v2 error envelope, internal failures don't leak details
+func respondError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		return c.JSON(http.StatusBadRequest, ErrorEnvelope{ErrorBody{Code: "invalid_input", Message: err.Error()}})
+	case errors.Is(err, services.ErrNotFound):
+		return c.JSON(http.StatusNotFound, ErrorEnvelope{ErrorBody{Code: "not_found", Message: err.Error()}})
+	case errors.Is(err, services.ErrConflict):
+		return c.JSON(http.StatusConflict, ErrorEnvelope{ErrorBody{Code: "conflict", Message: err.Error()}})
+	default:
+		return c.JSON(http.StatusInternalServerError, ErrorEnvelope{ErrorBody{Code: "internal", Message: "internal server error"}})
+	}
+}
+
+// invalid writes a v2 error envelope for a malformed request
+func invalid(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, ErrorEnvelope{ErrorBody{Code: "invalid_input", Message: message}})
+}
+
+// pathID parses the id URL parameter
+func pathID(c echo.Context) (int, error) {
+	return strconv.Atoi(c.Param("id"))
+}
+
+// ListSeries godoc
+// @Summary      List series
+// @Description  Get every series that isn't in the trash
+// @Tags         v2
+// @Produce      json
+// @Success      200  {object}  v2.Envelope{data=[]v2.Series}
+// @Failure      500  {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series [get]
+func (h *SeriesHandler) ListSeries(c echo.Context) error {
+	series, err := h.service.GetAllSeries(c.Request().Context())
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	list := toSeriesList(series)
+	return c.JSON(http.StatusOK, Envelope{Data: list, Meta: &Meta{Count: len(list)}})
+}
+
+// GetSeries godoc
+// @Summary      Get a series
+// @Description  Get a single series by its ID
+// @Tags         v2
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {object}  v2.Envelope{data=v2.Series}
+// @Failure      400  {object}  v2.ErrorEnvelope
+// @Failure      404  {object}  v2.ErrorEnvelope
+// @Failure      500  {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series/{id} [get]
+func (h *SeriesHandler) GetSeries(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return invalid(c, "invalid id")
+	}
+
+	serie, err := h.service.GetSerieByID(c.Request().Context(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, Envelope{Data: toSeries(*serie)})
+}
+
+// CreateSeries godoc
+// @Summary      Create a series
+// @Description  Adds a new series
+// @Tags         v2
+// @Accept       json
+// @Produce      json
+// @Param        body  body      v2.SeriesInput  true  "Series info"
+// @Success      201   {object}  v2.Envelope{data=v2.Series}
+// @Failure      400   {object}  v2.ErrorEnvelope
+// @Failure      409   {object}  v2.ErrorEnvelope
+// @Failure      500   {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series [post]
+func (h *SeriesHandler) CreateSeries(c echo.Context) error {
+	var in SeriesInput
+	if err := c.Bind(&in); err != nil {
+		return invalid(c, "invalid body")
+	}
+
+	serie, err := in.toModel(0)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	created, err := h.service.CreateSerie(c.Request().Context(), serie)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, Envelope{Data: toSeries(*created)})
+}
+
+// ReplaceSeries godoc
+// @Summary      Replace a series
+// @Description  Replaces every field of an existing series
+// @Tags         v2
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int             true  "Series ID"
+// @Param        body  body      v2.SeriesInput  true  "Series info"
+// @Success      200   {object}  v2.Envelope{data=v2.Series}
+// @Failure      400   {object}  v2.ErrorEnvelope
+// @Failure      404   {object}  v2.ErrorEnvelope
+// @Failure      409   {object}  v2.ErrorEnvelope
+// @Failure      500   {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series/{id} [put]
+func (h *SeriesHandler) ReplaceSeries(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return invalid(c, "invalid id")
+	}
+
+	var in SeriesInput
+	if err := c.Bind(&in); err != nil {
+		return invalid(c, "invalid body")
+	}
+
+	serie, err := in.toModel(id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	updated, err := h.service.UpdateSerie(c.Request().Context(), serie)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, Envelope{Data: toSeries(*updated)})
+}
+
+// DeleteSeries godoc
+// @Summary      Delete a series
+// @Description  Moves a series to the trash
+// @Tags         v2
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      204  "No content"
+// @Failure      400  {object}  v2.ErrorEnvelope
+// @Failure      404  {object}  v2.ErrorEnvelope
+// @Failure      500  {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series/{id} [delete]
+func (h *SeriesHandler) DeleteSeries(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return invalid(c, "invalid id")
+	}
+
+	if err := h.service.DeleteSerie(c.Request().Context(), id); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SetStatus godoc
+// @Summary      Set a series' status
+// @Description  Changes the status of a series
+// @Tags         v2
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int             true  "Series ID"
+// @Param        body  body      v2.StatusInput  true  "New status"
+// @Success      200   {object}  v2.Envelope{data=v2.Series}
+// @Failure      400   {object}  v2.ErrorEnvelope
+// @Failure      404   {object}  v2.ErrorEnvelope
+// @Failure      500   {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series/{id}/status [put]
+func (h *SeriesHandler) SetStatus(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return invalid(c, "invalid id")
+	}
+
+	var in StatusInput
+	if err := c.Bind(&in); err != nil {
+		return invalid(c, "invalid body")
+	}
+
+	status, err := storedStatus(in.Status)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	updated, err := h.service.UpdateSerieStatus(c.Request().Context(), id, status)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, Envelope{Data: toSeries(*updated)})
+}
+
+// Upvote godoc
+// @Summary      Upvote a series
+// @Description  Increases the score of a series by one
+// @Tags         v2
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {object}  v2.Envelope{data=v2.Series}
+// @Failure      400  {object}  v2.ErrorEnvelope
+// @Failure      404  {object}  v2.ErrorEnvelope
+// @Failure      500  {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series/{id}/upvote [post]
+func (h *SeriesHandler) Upvote(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return invalid(c, "invalid id")
+	}
+
+	updated, err := h.service.UpvoteSerie(c.Request().Context(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, Envelope{Data: toSeries(*updated)})
+}
+
+// Downvote godoc
+// @Summary      Downvote a series
+// @Description  Decreases the score of a series by one
+// @Tags         v2
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {object}  v2.Envelope{data=v2.Series}
+// @Failure      400  {object}  v2.ErrorEnvelope
+// @Failure      404  {object}  v2.ErrorEnvelope
+// @Failure      500  {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series/{id}/downvote [post]
+func (h *SeriesHandler) Downvote(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return invalid(c, "invalid id")
+	}
+
+	updated, err := h.service.DownvoteSerie(c.Request().Context(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, Envelope{Data: toSeries(*updated)})
+}
+
+// NextEpisode godoc
+// @Summary      Advance to the next episode
+// @Description  Increases the watched episode count of a series by one
+// @Tags         v2
+// @Produce      json
+// @Param        id   path      int  true  "Series ID"
+// @Success      200  {object}  v2.Envelope{data=v2.Series}
+// @Failure      400  {object}  v2.ErrorEnvelope
+// @Failure      404  {object}  v2.ErrorEnvelope
+// @Failure      500  {object}  v2.ErrorEnvelope
+// @Router       /api/v2/series/{id}/next-episode [post]
+func (h *SeriesHandler) NextEpisode(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return invalid(c, "invalid id")
+	}
+
+	updated, err := h.service.IncrementSerieEpisode(c.Request().Context(), id)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, Envelope{Data: toSeries(*updated)})
+}
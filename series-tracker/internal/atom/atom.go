@@ -0,0 +1,88 @@
+// Package atom writes Atom (RFC 4287) feeds.
+package atom
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// MIMEType is the media type of Atom feeds
+const MIMEType = "application/atom+xml"
+
+// namespace is the XML namespace of Atom documents
+const namespace = "http://www.w3.org/2005/Atom"
+
+// Feed is an Atom feed
+type Feed struct {
+	XMLName  xml.Name  `xml:"feed"`
+	Xmlns    string    `xml:"xmlns,attr"`
+	ID       string    `xml:"id"`
+	Title    string    `xml:"title"`
+	Subtitle string    `xml:"subtitle,omitempty"`
+	Updated  Time      `xml:"updated"`
+	Author   *Person   `xml:"author,omitempty"`
+	Links    []Link    `xml:"link"`
+	Entries  []Entry   `xml:"entry"`
+	Gen      Generator `xml:"generator"`
+}
+
+// Entry is an entry of a feed
+type Entry struct {
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Updated    Time       `xml:"updated"`
+	Author     *Person    `xml:"author,omitempty"`
+	Summary    string     `xml:"summary,omitempty"`
+	Categories []Category `xml:"category"`
+}
+
+// Person is the author of a feed or entry
+type Person struct {
+	Name string `xml:"name"`
+}
+
+// Link is a link of a feed
+type Link struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// Category is a category of an entry
+type Category struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+// Generator names the software that generated a feed
+type Generator struct {
+	Name string `xml:",chardata"`
+}
+
+// Time is a moment written as an RFC 3339 timestamp
+type Time time.Time
+
+// MarshalText writes the moment in UTC
+func (t Time) MarshalText() ([]byte, error) {
+	return []byte(time.Time(t).UTC().Format(time.RFC3339)), nil
+}
+
+// Encode writes the feed to w as an XML document
+func Encode(w io.Writer, feed Feed) error {
+	feed.Xmlns = namespace
+	if feed.Gen.Name == "" {
+		feed.Gen.Name = "series-tracker"
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,145 @@
+// Package broker fans out committed series changes to in-process subscribers.
+package broker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"series-tracker/internal/models"
+)
+
+// Broker numbers published changes and hands them to every subscriber, keeping the
+// most recent ones so subscribers can resume. Publishing never blocks, subscribers
+// that fall behind are closed instead. Numbers start over with every broker, the
+// epoch tells them apart.
+type Broker struct {
+	mu          sync.Mutex
+	epoch       string
+	seq         uint64
+	history     []models.SerieChange
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+// New returns a Broker without subscribers keeping up to history changes around
+// for resuming subscribers
+func New(history int) *Broker {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return &Broker{
+		epoch:       hex.EncodeToString(b),
+		historySize: history,
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Epoch returns the random ID of the broker, sequence numbers only mean something
+// along with it
+func (b *Broker) Epoch() string {
+	return b.epoch
+}
+
+// Subscription receives every change published after it was created
+type Subscription struct {
+	C <-chan models.SerieChange // Closed once the subscription is closed or fell behind
+
+	ch     chan models.SerieChange
+	broker *Broker
+}
+
+// Subscribe returns a subscription buffering up to buffer changes
+func (b *Broker) Subscribe(buffer int) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.subscribe(nil, buffer)
+}
+
+// SubscribeAfter returns a subscription that first replays the kept changes
+// numbered after seq, buffering up to buffer changes on top of them. complete is
+// false when changes after seq are no longer kept, or seq was never handed out,
+// or it comes from another epoch such as before a restart, meaning the subscriber
+// missed changes and should reload its state.
+func (b *Broker) SubscribeAfter(epoch string, seq uint64, buffer int) (sub *Subscription, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if epoch != b.epoch {
+		return b.subscribe(nil, buffer), false
+	}
+
+	// Kept changes are numbered consecutively, the oldest one tells how far back
+	// subscribers can resume
+	oldest := b.seq + 1
+	if len(b.history) > 0 {
+		oldest = b.history[0].Seq
+	}
+	if seq > b.seq || seq+1 < oldest {
+		return b.subscribe(nil, buffer), false
+	}
+	return b.subscribe(b.history[seq+1-oldest:], buffer), true
+}
+
+// subscribe registers a subscriber starting with the given replay, must be called
+// with mu held
+func (b *Broker) subscribe(replay []models.SerieChange, buffer int) *Subscription {
+	ch := make(chan models.SerieChange, len(replay)+buffer)
+	for _, change := range replay {
+		ch <- change
+	}
+
+	sub := &Subscription{C: ch, ch: ch, broker: b}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Close stops the subscription, it's safe to call more than once
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.drop(s)
+}
+
+// drop removes a subscriber and closes its channel, must be called with mu held
+func (b *Broker) drop(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Publish numbers the change recorded by an audit entry and sends it to every
+// subscriber
+func (b *Broker) Publish(entry models.AuditEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	change := models.SerieChange{
+		Seq:       b.seq,
+		Action:    entry.Action,
+		SerieID:   entry.SerieID,
+		Actor:     entry.Actor,
+		RequestID: entry.RequestID,
+		At:        entry.CreatedAt,
+		Before:    entry.Before,
+		Serie:     entry.After,
+	}
+
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			b.history = append(b.history[:0], b.history[1:]...)
+		}
+		b.history = append(b.history, change)
+	}
+
+	for sub := range b.subscribers {
+		select {
+		case sub.ch <- change:
+		default:
+			// Too slow to keep up, the subscriber has to resubscribe
+			b.drop(sub)
+		}
+	}
+}
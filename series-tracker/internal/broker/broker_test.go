@@ -0,0 +1,170 @@
+package broker
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"series-tracker/internal/models"
+)
+
+// publish publishes one change for each of the given series
+func publish(b *Broker, ids ...int) {
+	for _, id := range ids {
+		b.Publish(models.AuditEntry{Action: "update", SerieID: id})
+	}
+}
+
+// drain returns the sequence numbers buffered in the subscription without waiting
+// for more, and whether its channel was closed
+func drain(sub *Subscription) (seqs []uint64, closed bool) {
+	for {
+		select {
+		case change, ok := <-sub.C:
+			if !ok {
+				return seqs, true
+			}
+			seqs = append(seqs, change.Seq)
+		default:
+			return seqs, false
+		}
+	}
+}
+
+func TestPublishNumbersChanges(t *testing.T) {
+	b := New(0)
+	sub := b.Subscribe(4)
+	defer sub.Close()
+
+	b.Publish(models.AuditEntry{Action: "create", SerieID: 7, Actor: "alice", RequestID: "req-1"})
+	publish(b, 8)
+
+	change := <-sub.C
+	if change.Seq != 1 || change.Action != "create" || change.SerieID != 7 || change.Actor != "alice" || change.RequestID != "req-1" {
+		t.Errorf("first change = %+v", change)
+	}
+	if seqs, _ := drain(sub); !reflect.DeepEqual(seqs, []uint64{2}) {
+		t.Errorf("then got %v, want [2]", seqs)
+	}
+}
+
+func TestSubscribeAfter(t *testing.T) {
+	b := New(3)
+	publish(b, 1, 2, 3, 4, 5) // Keeps 3, 4 & 5
+
+	tests := []struct {
+		name     string
+		seq      uint64
+		want     []uint64
+		complete bool
+	}{
+		{"resume within history", 3, []uint64{4, 5, 6}, true},
+		{"resume from oldest kept", 2, []uint64{3, 4, 5, 6}, true},
+		{"up to date", 5, []uint64{6}, true},
+		{"evicted from history", 1, []uint64{6}, false},
+		{"never handed out", 9, []uint64{6}, false},
+	}
+	var subs []*Subscription
+	for _, test := range tests {
+		sub, complete := b.SubscribeAfter(b.Epoch(), test.seq, 1)
+		if complete != test.complete {
+			t.Errorf("%s: complete = %t, want %t", test.name, complete, test.complete)
+		}
+		subs = append(subs, sub)
+	}
+
+	// Live changes follow the replay without being lost to the replay's buffer
+	publish(b, 6)
+	for i, test := range tests {
+		if seqs, closed := drain(subs[i]); closed || !reflect.DeepEqual(seqs, test.want) {
+			t.Errorf("%s: got %v (closed %t), want %v", test.name, seqs, closed, test.want)
+		}
+		subs[i].Close()
+	}
+}
+
+func TestSubscribeAfterWithoutHistory(t *testing.T) {
+	b := New(0)
+	if _, complete := b.SubscribeAfter(b.Epoch(), 0, 1); !complete {
+		t.Error("subscribing from the start of a fresh broker wasn't complete")
+	}
+
+	publish(b, 1)
+	if _, complete := b.SubscribeAfter(b.Epoch(), 0, 1); complete {
+		t.Error("resuming without history was complete")
+	}
+	if _, complete := b.SubscribeAfter(b.Epoch(), 1, 1); !complete {
+		t.Error("resuming from the latest change wasn't complete")
+	}
+}
+
+func TestSubscribeAfterAnotherEpoch(t *testing.T) {
+	previous, b := New(3), New(3)
+	if previous.Epoch() == b.Epoch() {
+		t.Fatal("two brokers share an epoch")
+	}
+	publish(b, 1, 2)
+
+	// Numbers from a previous process mean nothing here, even ones handed out again
+	sub, complete := b.SubscribeAfter(previous.Epoch(), 1, 1)
+	defer sub.Close()
+	if complete {
+		t.Error("resuming from another epoch was complete")
+	}
+	if seqs, _ := drain(sub); len(seqs) != 0 {
+		t.Errorf("replayed %v from another epoch", seqs)
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	b := New(0)
+	slow := b.Subscribe(1)
+	fast := b.Subscribe(4)
+	defer fast.Close()
+
+	publish(b, 1, 2, 3)
+
+	if seqs, closed := drain(slow); !closed || !reflect.DeepEqual(seqs, []uint64{1}) {
+		t.Errorf("slow subscriber got %v (closed %t), want [1] then closed", seqs, closed)
+	}
+	if seqs, closed := drain(fast); closed || !reflect.DeepEqual(seqs, []uint64{1, 2, 3}) {
+		t.Errorf("fast subscriber got %v (closed %t), want [1 2 3]", seqs, closed)
+	}
+
+	// Closing a dropped subscription is a no-op
+	slow.Close()
+	slow.Close()
+}
+
+func TestConcurrentPublishAndSubscribe(t *testing.T) {
+	b := New(16)
+
+	var publishers, subscribers sync.WaitGroup
+	subs := make(chan *Subscription, 4)
+	for range 4 {
+		publishers.Add(2)
+		subscribers.Add(1)
+		go func() {
+			defer publishers.Done()
+			publish(b, 1, 2, 3, 4, 5, 6, 7, 8)
+		}()
+		go func() {
+			defer publishers.Done()
+			sub, _ := b.SubscribeAfter(b.Epoch(), 0, 2)
+			subs <- sub
+			go func() {
+				defer subscribers.Done()
+				for range sub.C {
+				}
+			}()
+		}()
+	}
+
+	// Subscribers stop once dropped for falling behind or closed here
+	publishers.Wait()
+	close(subs)
+	for sub := range subs {
+		sub.Close()
+	}
+	subscribers.Wait()
+}
@@ -0,0 +1,82 @@
+package cache
+
+import "container/list"
+
+// LRU is a fixed size least recently used cache, it's not safe for concurrent use
+type LRU[K comparable, V any] struct {
+	capacity int
+	order    *list.List
+	items    map[K]*list.Element
+}
+
+// entry is what's stored in each element of the recency list
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU returns an empty LRU holding at most capacity entries
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		items:    map[K]*list.Element{},
+	}
+}
+
+// Get returns the value stored under key, marking it as recently used
+func (l *LRU[K, V]) Get(key K) (V, bool) {
+	element, ok := l.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	l.order.MoveToFront(element)
+	return element.Value.(*entry[K, V]).value, true
+}
+
+// Add stores value under key, evicting the least recently used entry when full
+func (l *LRU[K, V]) Add(key K, value V) {
+	if l.capacity <= 0 {
+		return
+	}
+
+	if element, ok := l.items[key]; ok {
+		element.Value.(*entry[K, V]).value = value
+		l.order.MoveToFront(element)
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&entry[K, V]{key: key, value: value})
+	if l.order.Len() > l.capacity {
+		l.Remove(l.order.Back().Value.(*entry[K, V]).key)
+	}
+}
+
+// Remove deletes the entry stored under key, if any
+func (l *LRU[K, V]) Remove(key K) {
+	if element, ok := l.items[key]; ok {
+		l.order.Remove(element)
+		delete(l.items, key)
+	}
+}
+
+// RemoveFunc deletes every entry whose key matches
+func (l *LRU[K, V]) RemoveFunc(match func(key K) bool) {
+	for key := range l.items {
+		if match(key) {
+			l.Remove(key)
+		}
+	}
+}
+
+// Len returns the number of entries currently stored
+func (l *LRU[K, V]) Len() int {
+	return l.order.Len()
+}
+
+// Capacity returns the maximum number of entries stored
+func (l *LRU[K, V]) Capacity() int {
+	return l.capacity
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+)
+
+// keys returns the keys of the LRU from the most to the least recently used
+func keys[K comparable, V any](l *LRU[K, V]) []K {
+	var keys []K
+	for element := l.order.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(*entry[K, V]).key)
+	}
+	return keys
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	l := NewLRU[string, int](2)
+	l.Add("a", 1)
+	l.Add("b", 2)
+	l.Get("a")
+	l.Add("c", 3)
+
+	if _, ok := l.Get("b"); ok {
+		t.Error("b was used least recently but wasn't evicted")
+	}
+	if value, ok := l.Get("a"); !ok || value != 1 {
+		t.Errorf("Get(a) = %d, %t, want 1, true", value, ok)
+	}
+	if got, want := keys(l), []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keys = %q, want %q", got, want)
+	}
+	if l.Len() != 2 || len(l.items) != 2 {
+		t.Errorf("Len = %d with %d items, want 2", l.Len(), len(l.items))
+	}
+}
+
+func TestLRUAddExistingKey(t *testing.T) {
+	l := NewLRU[string, int](2)
+	l.Add("a", 1)
+	l.Add("b", 2)
+	l.Add("a", 10)
+	l.Add("c", 3)
+
+	if value, ok := l.Get("a"); !ok || value != 10 {
+		t.Errorf("Get(a) = %d, %t, want 10, true", value, ok)
+	}
+	if _, ok := l.Get("b"); ok {
+		t.Error("updating a didn't make it the most recently used")
+	}
+}
+
+func TestLRURemove(t *testing.T) {
+	l := NewLRU[string, int](4)
+	for i, key := range []string{"serie:1", "serie:2", "list:all", "list:trash"} {
+		l.Add(key, i)
+	}
+
+	l.Remove("serie:1")
+	l.Remove("missing")
+	l.RemoveFunc(func(key string) bool { return key[:5] == "list:" })
+
+	if got, want := keys(l), []string{"serie:2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keys = %q, want %q", got, want)
+	}
+	if len(l.items) != 1 {
+		t.Errorf("%d items left in the index, want 1", len(l.items))
+	}
+}
+
+func TestLRUZeroCapacity(t *testing.T) {
+	l := NewLRU[string, int](0)
+	l.Add("a", 1)
+
+	if _, ok := l.Get("a"); ok || l.Len() != 0 {
+		t.Error("an LRU without capacity stored an entry")
+	}
+}
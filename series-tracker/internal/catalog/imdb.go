@@ -0,0 +1,177 @@
+// Package catalog reads the IMDb non-commercial datasets (title.basics.tsv &
+// title.episode.tsv, gzipped or not) into catalog titles.
+package catalog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"series-tracker/internal/models"
+)
+
+// DefaultTypes are the title types kept when none are given
+var DefaultTypes = []string{"tvSeries", "tvMiniSeries"}
+
+// ErrInvalidFile is returned when a dataset is missing columns or malformed
+var ErrInvalidFile = errors.New("invalid dataset file")
+
+// null is how the datasets write missing values
+const null = `\N`
+
+// maxLineSize is the longest line read, titles with many alternate fields run long
+const maxLineSize = 1 << 20
+
+// Open opens a dataset file, decompressing it when its name ends in .gz
+func Open(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFile, path, err)
+	}
+	return &gzipFile{Reader: gz, file: file}, nil
+}
+
+// gzipFile closes both the decompressor & the file under it
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+// Close closes the decompressor, then the file
+func (f *gzipFile) Close() error {
+	err := f.Reader.Close()
+	if closeErr := f.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// ReadTitles reads title.basics, keeping the titles of the given types, DefaultTypes
+// when empty. Adult titles are left out.
+func ReadTitles(r io.Reader, types []string) ([]models.CatalogTitle, error) {
+	if len(types) == 0 {
+		types = DefaultTypes
+	}
+	kept := map[string]bool{}
+	for _, t := range types {
+		kept[t] = true
+	}
+
+	titles := []models.CatalogTitle{}
+	err := readTSV(r, []string{"tconst", "titleType", "primaryTitle", "originalTitle", "isAdult", "startYear", "endYear", "genres"},
+		func(fields []string) error {
+			if !kept[fields[1]] || fields[4] == "1" {
+				return nil
+			}
+
+			title := models.CatalogTitle{
+				ID:            fields[0],
+				Type:          fields[1],
+				Title:         fields[2],
+				OriginalTitle: fields[3],
+				Genres:        []string{},
+			}
+			if title.OriginalTitle == null {
+				title.OriginalTitle = title.Title
+			}
+			var err error
+			if title.StartYear, err = parseYear(fields[5]); err != nil {
+				return err
+			}
+			if title.EndYear, err = parseYear(fields[6]); err != nil {
+				return err
+			}
+			if fields[7] != null && fields[7] != "" {
+				title.Genres = strings.Split(fields[7], ",")
+			}
+			titles = append(titles, title)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return titles, nil
+}
+
+// CountEpisodes reads title.episode & returns the quantity of episodes of every
+// series, by series ID
+func CountEpisodes(r io.Reader) (map[string]int, error) {
+	counts := map[string]int{}
+	err := readTSV(r, []string{"tconst", "parentTconst"}, func(fields []string) error {
+		if fields[1] != null {
+			counts[fields[1]]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// readTSV reads a dataset, calling row with the given columns of every line in
+// order. Columns are found by the header, the datasets don't quote fields.
+func readTSV(r io.Reader, columns []string, row func(fields []string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: missing header", ErrInvalidFile)
+	}
+	positions := map[string]int{}
+	for i, name := range strings.Split(scanner.Text(), "\t") {
+		positions[name] = i
+	}
+	indexes := make([]int, len(columns))
+	for i, name := range columns {
+		index, ok := positions[name]
+		if !ok {
+			return fmt.Errorf("%w: missing %s column", ErrInvalidFile, name)
+		}
+		indexes[i] = index
+	}
+
+	fields := make([]string, len(columns))
+	for line := 2; scanner.Scan(); line++ {
+		values := strings.Split(scanner.Text(), "\t")
+		if len(values) != len(positions) {
+			return fmt.Errorf("%w: line %d has %d fields instead of %d", ErrInvalidFile, line, len(values), len(positions))
+		}
+		for i, index := range indexes {
+			fields[i] = values[index]
+		}
+		if err := row(fields); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseYear parses a year, 0 when missing
+func parseYear(value string) (int, error) {
+	if value == null || value == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil || year < 0 {
+		return 0, fmt.Errorf("%w: invalid year %q", ErrInvalidFile, value)
+	}
+	return year, nil
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"url gets the default sslmode",
+			Config{URL: "postgres://user:pass@db:5432/series"},
+			"postgres://user:pass@db:5432/series?sslmode=disable"},
+		{"postgresql scheme",
+			Config{URL: "postgresql://db/series", SSLMode: "require"},
+			"postgresql://db/series?sslmode=require"},
+		{"url keeps its own tls settings",
+			Config{URL: "postgres://db/series?sslmode=verify-full", SSLMode: "require", SSLRootCert: "/ca.pem"},
+			"postgres://db/series?sslmode=verify-full&sslrootcert=%2Fca.pem"},
+		{"key/value string gets the tls settings",
+			Config{URL: "host=db user=series password='p w' dbname=series", SSLRootCert: "/ca dir/ca.pem"},
+			`host=db user=series password='p w' dbname=series sslmode=disable sslrootcert='/ca dir/ca.pem'`},
+		{"key/value string keeps its own tls settings",
+			Config{URL: `host=db password='it\'s sslkey=x' sslmode = verify-full`, SSLMode: "require", SSLKey: "/key.pem"},
+			`host=db password='it\'s sslkey=x' sslmode = verify-full sslkey=/key.pem`},
+		{"individual fields",
+			Config{Host: "db", Port: "5432", User: "series", Password: `it's`, Name: "series"},
+			`host=db port=5432 user=series password='it\'s' dbname=series sslmode=disable`},
+	}
+	for _, test := range tests {
+		if got := test.cfg.DSN(); got != test.want {
+			t.Errorf("%s: DSN() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestConfigValidateKeyValueURL(t *testing.T) {
+	cfg := Config{URL: "host=db dbname=series", ConnectAttempts: 1}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() = %v for a key/value DATABASE_URL", err)
+	}
+}
+
+func TestConfigTargetHidesPassword(t *testing.T) {
+	for _, cfg := range []Config{
+		{URL: "postgres://user:secret@db/series"},
+		{URL: "host=db user=user password=secret dbname=series"},
+		{Host: "db", User: "user", Password: "secret", Name: "series"},
+	} {
+		if target := cfg.Target(); strings.Contains(target, "secret") {
+			t.Errorf("Target() = %q leaks the password", target)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package ical writes iCalendar (RFC 5545) feeds holding timed events.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MIMEType is the media type of iCalendar feeds
+const MIMEType = "text/calendar"
+
+// maxLineLength is the longest a content line may be, in octets, before folding
+const maxLineLength = 75
+
+// Calendar is a feed of events
+type Calendar struct {
+	ProdID      string        // Identifies the product that created the feed
+	Name        string        // Name calendar apps show for the feed
+	Description string        // Description of the feed
+	Refresh     time.Duration // How often subscribers should refresh the feed, left out when zero
+	Events      []Event       // Events of the feed
+}
+
+// Event is a timed event of a calendar
+type Event struct {
+	UID         string    // Globally unique identifier, kept across refreshes of the feed
+	Start       time.Time // Start of the event
+	End         time.Time // End of the event
+	Summary     string    // Title of the event
+	Description string    // Details of the event, left out when empty
+	Categories  []string  // Categories of the event, left out when empty
+	URL         string    // Link to more details, left out when empty
+}
+
+// Encode writes the calendar to w, stamping every event with now
+func Encode(w io.Writer, cal Calendar, now time.Time) error {
+	out := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", cal.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME", escape(cal.Name))
+	}
+	if cal.Description != "" {
+		line("X-WR-CALDESC", escape(cal.Description))
+	}
+	if cal.Refresh > 0 {
+		refresh := duration(cal.Refresh)
+		line("REFRESH-INTERVAL;VALUE=DURATION", refresh)
+		line("X-PUBLISHED-TTL", refresh)
+	}
+
+	for _, event := range cal.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("DTSTAMP", timestamp(now))
+		line("DTSTART", timestamp(event.Start))
+		line("DTEND", timestamp(event.End))
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escape(category)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return out.Flush()
+}
+
+// writeLine writes a content line, folded every 75 octets without splitting
+// characters, ended by CRLF
+func writeLine(out *bufio.Writer, content string) {
+	limit := maxLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		out.WriteString(content[:cut])
+		out.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts towards their length
+		limit = maxLineLength - 1
+	}
+	out.WriteString(content)
+	out.WriteString("\r\n")
+}
+
+// escaper escapes the characters TEXT values can't hold as is
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape returns value as a TEXT value
+func escape(value string) string {
+	return escaper.Replace(value)
+}
+
+// timestamp returns t as a UTC DATE-TIME value
+func timestamp(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// duration returns d as a DURATION value, rounded down to the second
+func duration(d time.Duration) string {
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours := int(d.Hours()); hours > 0 {
+		b.WriteString(strconv.Itoa(hours) + "H")
+		d -= time.Duration(hours) * time.Hour
+	}
+	if minutes := int(d.Minutes()); minutes > 0 {
+		b.WriteString(strconv.Itoa(minutes) + "M")
+		d -= time.Duration(minutes) * time.Minute
+	}
+	if seconds := int(d.Seconds()); seconds > 0 || b.Len() == 2 {
+		b.WriteString(strconv.Itoa(seconds) + "S")
+	}
+	return b.String()
+}
@@ -0,0 +1,238 @@
+// Package imports reads series out of files exported by spreadsheets & other
+// trackers into rows for the series service to import.
+package imports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"series-tracker/internal/models"
+)
+
+// Fields lists the series fields an imported row can hold, by their JSON name
+var Fields = []string{"title", "ranking", "status", "lastEpisodeWatched", "totalEpisodes", "year", "genres"}
+
+// exportedColumns are written by the CSV export but not imported, series are
+// matched by title instead. They're left out of the ignored columns.
+var exportedColumns = map[string]bool{"id": true, "deletedat": true}
+
+// ErrInvalidFile is returned when a file can't be imported at all, as opposed to
+// the rows reported one by one
+var ErrInvalidFile = errors.New("invalid import file")
+
+// CSVOptions configures how a CSV file is read
+type CSVOptions struct {
+	// Mapping maps headers to the field their column holds, headers are matched
+	// ignoring case. Headers missing from it are matched to the field of the same
+	// name, mapping a header to "" ignores its column.
+	Mapping map[string]string
+	// Comma is the field delimiter, ',' when zero
+	Comma rune
+}
+
+// CSVImporter imports CSV files with a header row, see ReadCSV. It takes a
+// "mapping" setting holding CSVOptions.Mapping as a JSON object & a "delimiter"
+// setting holding a single character.
+type CSVImporter struct{}
+
+// Format returns "csv"
+func (CSVImporter) Format() string {
+	return "csv"
+}
+
+// Read reads a CSV file with the options given by settings
+func (CSVImporter) Read(r io.Reader, settings map[string]string) (*Result, error) {
+	var opts CSVOptions
+	if mapping := settings["mapping"]; mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &opts.Mapping); err != nil {
+			return nil, fmt.Errorf("%w: mapping must be a JSON object of headers to fields", ErrInvalidFile)
+		}
+	}
+	if delimiter := settings["delimiter"]; delimiter != "" {
+		comma, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) {
+			return nil, fmt.Errorf("%w: delimiter must be a single character", ErrInvalidFile)
+		}
+		opts.Comma = comma
+	}
+
+	rows, ignored, err := ReadCSV(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Rows: rows, IgnoredColumns: ignored}, nil
+}
+
+// ReadCSV reads a CSV file with a header row. Cells that can't be read are
+// reported through the Err of their row, empty cells leave their field out. The
+// headers that weren't mapped to any field are returned as ignored.
+func ReadCSV(r io.Reader, opts CSVOptions) (rows []models.ImportRow, ignored []string, err error) {
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	// Spreadsheets often leave ragged rows & stray quotes behind
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("%w: missing header row", ErrInvalidFile)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	columns, ignored, err := mapColumns(header, opts.Mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if isBlank(record) {
+			continue
+		}
+		rows = append(rows, readRecord(line, record, columns))
+	}
+	return rows, ignored, nil
+}
+
+// mapColumns returns the field held by every column, "" for ignored ones
+func mapColumns(header []string, mapping map[string]string) (columns []string, ignored []string, err error) {
+	known := make(map[string]string, len(Fields))
+	for _, field := range Fields {
+		known[strings.ToLower(field)] = field
+	}
+
+	// Custom mappings are matched ignoring case as well
+	custom := make(map[string]string, len(mapping))
+	for name, field := range mapping {
+		if field != "" && known[strings.ToLower(field)] == "" {
+			return nil, nil, fmt.Errorf("%w: header %q is mapped to unknown field %q", ErrInvalidFile, name, field)
+		}
+		custom[strings.ToLower(strings.TrimSpace(name))] = known[strings.ToLower(field)]
+	}
+
+	columns = make([]string, len(header))
+	mapped := make(map[string]string, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Excel saves UTF-8 files with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+
+		field, ok := custom[key]
+		if !ok {
+			field = known[key]
+		}
+		if field == "" {
+			if !ok && !exportedColumns[key] && name != "" {
+				ignored = append(ignored, name)
+			}
+			continue
+		}
+		if previous, ok := mapped[field]; ok {
+			return nil, nil, fmt.Errorf("%w: columns %q and %q are both mapped to %s", ErrInvalidFile, previous, name, field)
+		}
+		mapped[field] = name
+		columns[i] = field
+	}
+
+	if _, ok := mapped["title"]; !ok {
+		return nil, nil, fmt.Errorf("%w: no column is mapped to title", ErrInvalidFile)
+	}
+	return columns, ignored, nil
+}
+
+// readRecord reads the fields of a row out of its cells
+func readRecord(line int, record []string, columns []string) models.ImportRow {
+	row := models.ImportRow{Line: line, Fields: map[string]bool{}}
+	var problems []string
+	for i, cell := range record {
+		if i >= len(columns) || columns[i] == "" {
+			continue
+		}
+		field, cell := columns[i], strings.TrimSpace(cell)
+		if cell == "" && field != "title" {
+			continue
+		}
+
+		var err error
+		switch field {
+		case "title":
+			row.Serie.Title = cell
+		case "status":
+			row.Serie.Status = cell
+		case "ranking":
+			row.Serie.Ranking, err = parseNumber(cell)
+		case "lastEpisodeWatched":
+			row.Serie.CurrentEpisode, err = parseNumber(cell)
+		case "totalEpisodes":
+			row.Serie.TotalEpisodes, err = parseNumber(cell)
+		case "year":
+			row.Serie.Year, err = parseNumber(cell)
+		case "genres":
+			row.Serie.Genres = parseList(cell)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", field, err))
+			continue
+		}
+		row.Fields[field] = true
+	}
+
+	if len(problems) > 0 {
+		row.Err = errors.New(strings.Join(problems, "; "))
+	}
+	return row
+}
+
+// parseNumber reads a whole number, spreadsheets may write them as "12.0"
+func parseNumber(cell string) (int, error) {
+	if n, err := strconv.Atoi(cell); err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(cell, 64)
+	if err != nil || f != float64(int(f)) {
+		return 0, fmt.Errorf("%q isn't a whole number", cell)
+	}
+	return int(f), nil
+}
+
+// parseList splits a cell of genres separated by semicolons, as exported, or commas
+func parseList(cell string) []string {
+	items := []string{}
+	for _, item := range strings.FieldsFunc(cell, func(r rune) bool { return r == ';' || r == ',' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// isBlank reports whether every cell of a record is empty
+func isBlank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package imports
+
+import (
+	"io"
+	"sort"
+
+	"series-tracker/internal/models"
+)
+
+// Importer reads the series held by files of a single format. Adding a source is
+// a matter of implementing it & registering it in Default.
+type Importer interface {
+	// Format returns the name the importer is picked by, e.g. "csv"
+	Format() string
+	// Read reads every row of a file. settings holds the format specific options
+	// the client sent along, unknown ones are ignored. Files & settings that can't
+	// be read at all fail with ErrInvalidFile.
+	Read(r io.Reader, settings map[string]string) (*Result, error)
+}
+
+// Result holds what an Importer read out of a file
+type Result struct {
+	Rows           []models.ImportRow // Rows in file order
+	IgnoredColumns []string           // Parts of the file that aren't imported, for formats with columns
+}
+
+// Registry holds the importers available by format
+type Registry struct {
+	importers map[string]Importer
+}
+
+// NewRegistry returns a Registry of the given importers, later ones replace
+// earlier ones of the same format
+func NewRegistry(importers ...Importer) *Registry {
+	registry := &Registry{importers: make(map[string]Importer, len(importers))}
+	for _, importer := range importers {
+		registry.importers[importer.Format()] = importer
+	}
+	return registry
+}
+
+// Default returns a Registry of every importer of this package
+func Default() *Registry {
+	return NewRegistry(CSVImporter{}, MALImporter{}, TraktImporter{}, JSONImporter{})
+}
+
+// Get returns the importer of a format
+func (r *Registry) Get(format string) (Importer, bool) {
+	importer, ok := r.importers[format]
+	return importer, ok
+}
+
+// Formats returns the formats of every importer, sorted
+func (r *Registry) Formats() []string {
+	formats := make([]string, 0, len(r.importers))
+	for format := range r.importers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
@@ -0,0 +1,175 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"series-tracker/internal/models"
+)
+
+// JSONImporter imports series from a generic JSON document, either an array of
+// series as returned by GET /api/series or an object holding that array under
+// "series":
+//
+//	{"series": [{"title": "Dark", "status": "Completed", "ranking": 9, "lastEpisodeWatched": 26, "totalEpisodes": 26}]}
+//
+// Fields are named as in the API, missing & null ones are left out & unknown
+// ones ignored. It takes no settings.
+type JSONImporter struct{}
+
+// Format returns "json"
+func (JSONImporter) Format() string {
+	return "json"
+}
+
+// Read reads a generic JSON document
+func (JSONImporter) Read(r io.Reader, settings map[string]string) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ImportRow
+	err = walkJSONItems(data, map[string]bool{"series": true}, func(line int, item json.RawMessage) error {
+		rows = append(rows, readJSONSerie(line, item))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Rows: rows}, nil
+}
+
+// readJSONSerie reads the fields of a series object
+func readJSONSerie(line int, item json.RawMessage) models.ImportRow {
+	row := models.ImportRow{Line: line, Fields: map[string]bool{}}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(item, &values); err != nil {
+		row.Err = errors.New("not a series object")
+		return row
+	}
+
+	var problems []string
+	targets := map[string]any{
+		"title":              &row.Serie.Title,
+		"ranking":            &row.Serie.Ranking,
+		"status":             &row.Serie.Status,
+		"lastEpisodeWatched": &row.Serie.CurrentEpisode,
+		"totalEpisodes":      &row.Serie.TotalEpisodes,
+		"year":               &row.Serie.Year,
+		"genres":             &row.Serie.Genres,
+	}
+	for _, field := range Fields {
+		value, ok := values[field]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(value, targets[field]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid value %s", field, value))
+			continue
+		}
+		row.Fields[field] = true
+	}
+
+	if len(problems) > 0 {
+		row.Err = errors.New(strings.Join(problems, "; "))
+	}
+	return row
+}
+
+// walkJSONItems calls fn with every element of the top-level array of a JSON
+// document, or of the arrays its top-level object holds under the given keys
+// (any key when nil), along with the line each element starts on
+func walkJSONItems(data []byte, keys map[string]bool, fn func(line int, item json.RawMessage) error) error {
+	lines := &lineCounter{data: data, line: 1}
+	start := skipJSONSpace(data, 0)
+	if start == len(data) {
+		return fmt.Errorf("%w: empty document", ErrInvalidFile)
+	}
+
+	switch data[start] {
+	case '[':
+		return walkJSONArray(data, start, lines, fn)
+	case '{':
+	default:
+		return fmt.Errorf("%w: expected a JSON array or object", ErrInvalidFile)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		key, _ := token.(string)
+		valueStart := skipJSONSpace(data, int(decoder.InputOffset()))
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		if (keys == nil || keys[key]) && len(value) > 0 && value[0] == '[' {
+			if err := walkJSONArray(data, valueStart, lines, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkJSONArray calls fn with every element of the array starting at start
+func walkJSONArray(data []byte, start int, lines *lineCounter, fn func(line int, item json.RawMessage) error) error {
+	decoder := json.NewDecoder(bytes.NewReader(data[start:]))
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	for decoder.More() {
+		itemStart := skipJSONSpace(data, start+int(decoder.InputOffset()))
+
+		var item json.RawMessage
+		if err := decoder.Decode(&item); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		if err := fn(lines.at(itemStart), item); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	return nil
+}
+
+// skipJSONSpace returns the position of the first byte from pos that's neither
+// whitespace nor a separator
+func skipJSONSpace(data []byte, pos int) int {
+	for pos < len(data) && strings.IndexByte(" \t\r\n,:", data[pos]) >= 0 {
+		pos++
+	}
+	return pos
+}
+
+// lineCounter works out the line of increasing positions of a document without
+// counting from the start every time
+type lineCounter struct {
+	data []byte
+	pos  int
+	line int
+}
+
+// at returns the line of a position, at least the previous one asked for
+func (l *lineCounter) at(pos int) int {
+	if pos > l.pos {
+		l.line += bytes.Count(l.data[l.pos:pos], []byte("\n"))
+		l.pos = pos
+	}
+	return l.line
+}
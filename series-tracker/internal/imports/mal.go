@@ -0,0 +1,158 @@
+package imports
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"series-tracker/internal/models"
+)
+
+// malStatuses maps the statuses of a MyAnimeList export, by name or by the code
+// older exports use, to ours. On hold series are still being watched.
+var malStatuses = map[string]string{
+	"watching":      "Watching",
+	"1":             "Watching",
+	"completed":     "Completed",
+	"2":             "Completed",
+	"on-hold":       "Watching",
+	"on hold":       "Watching",
+	"3":             "Watching",
+	"dropped":       "Dropped",
+	"4":             "Dropped",
+	"plan to watch": "Plan to Watch",
+	"6":             "Plan to Watch",
+}
+
+// malAnime is an <anime> entry of a MyAnimeList export, only the fields we
+// keep are read
+type malAnime struct {
+	Title           string `xml:"series_title"`
+	Episodes        string `xml:"series_episodes"`
+	WatchedEpisodes string `xml:"my_watched_episodes"`
+	Score           string `xml:"my_score"`
+	Status          string `xml:"my_status"`
+}
+
+// MALImporter imports MyAnimeList XML exports, see ReadMAL. It takes no settings.
+type MALImporter struct{}
+
+// Format returns "mal"
+func (MALImporter) Format() string {
+	return "mal"
+}
+
+// Read reads a MyAnimeList export
+func (MALImporter) Read(r io.Reader, settings map[string]string) (*Result, error) {
+	rows, err := ReadMAL(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Rows: rows}, nil
+}
+
+// ReadMAL reads the anime list of a MyAnimeList XML export, gzipped or not as
+// downloaded from MyAnimeList. Scores (1 to 10) become rankings, unscored entries
+// leave the ranking out, and an unknown episode count, as for airing series, is
+// taken to be the watched episodes.
+func ReadMAL(r io.Reader) ([]models.ImportRow, error) {
+	input := bufio.NewReader(r)
+	if magic, _ := input.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(input)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		defer gz.Close()
+		input = bufio.NewReader(gz)
+	}
+
+	decoder := xml.NewDecoder(input)
+	var rows []models.ImportRow
+	root := ""
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root == "" {
+			root = start.Name.Local
+			if root != "myanimelist" {
+				return nil, fmt.Errorf("%w: not a MyAnimeList export", ErrInvalidFile)
+			}
+			continue
+		}
+		if start.Name.Local != "anime" {
+			if start.Name.Local == "manga" {
+				return nil, fmt.Errorf("%w: manga lists can't be imported", ErrInvalidFile)
+			}
+			continue
+		}
+
+		line, _ := decoder.InputPos()
+		var anime malAnime
+		if err := decoder.DecodeElement(&anime, &start); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		rows = append(rows, readAnime(line, anime))
+	}
+
+	if root == "" {
+		return nil, fmt.Errorf("%w: not a MyAnimeList export", ErrInvalidFile)
+	}
+	return rows, nil
+}
+
+// readAnime maps an anime entry onto a row
+func readAnime(line int, anime malAnime) models.ImportRow {
+	row := models.ImportRow{
+		Line:   line,
+		Serie:  models.Serie{Title: strings.TrimSpace(anime.Title)},
+		Fields: map[string]bool{"title": true},
+	}
+	var problems []string
+	number := func(name, value string) int {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 {
+			problems = append(problems, fmt.Sprintf("%s: %q isn't a whole number", name, value))
+		}
+		return n
+	}
+
+	if status, ok := malStatuses[strings.ToLower(strings.TrimSpace(anime.Status))]; ok {
+		row.Serie.Status = status
+		row.Fields["status"] = true
+	} else {
+		problems = append(problems, fmt.Sprintf("my_status: unknown status %q", anime.Status))
+	}
+
+	watched := number("my_watched_episodes", anime.WatchedEpisodes)
+	total := number("series_episodes", anime.Episodes)
+	if total == 0 {
+		total = watched
+	}
+	row.Serie.CurrentEpisode, row.Serie.TotalEpisodes = watched, total
+	row.Fields["lastEpisodeWatched"], row.Fields["totalEpisodes"] = true, true
+
+	if score := number("my_score", anime.Score); score > 0 {
+		row.Serie.Ranking = score
+		row.Fields["ranking"] = true
+	}
+
+	if len(problems) > 0 {
+		row.Err = errors.New(strings.Join(problems, "; "))
+	}
+	return row
+}
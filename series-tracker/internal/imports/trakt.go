@@ -0,0 +1,148 @@
+package imports
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"series-tracker/internal/models"
+)
+
+// traktItem is an entry of a Trakt backup list, the watched, watchlist & ratings
+// lists share the same shape with different fields filled in
+type traktItem struct {
+	Type     string          `json:"type"`      // "show", "movie", "season" or "episode", missing in watched lists
+	Show     *traktShow      `json:"show"`      // Show the entry is about, nil for movies
+	Season   json.RawMessage `json:"season"`    // Set when the entry is about a single season
+	Episode  json.RawMessage `json:"episode"`   // Set when the entry is about a single episode
+	Seasons  []traktSeason   `json:"seasons"`   // Watched episodes, only in watched lists
+	Rating   *int            `json:"rating"`    // Rating from 1 to 10, only in ratings lists
+	ListedAt string          `json:"listed_at"` // Only in watchlists
+}
+
+// traktShow is a show as described by Trakt
+type traktShow struct {
+	Title         string `json:"title"`
+	AiredEpisodes int    `json:"aired_episodes"` // Only in backups made with extended info
+}
+
+// traktSeason is a season of watched episodes
+type traktSeason struct {
+	Number   int `json:"number"`
+	Episodes []struct {
+		Number int `json:"number"`
+	} `json:"episodes"`
+}
+
+// TraktImporter imports the shows of a Trakt JSON backup: the watched shows,
+// watchlist & ratings lists, either one list per file or several of them in an
+// object keyed by list name. A show found in several lists becomes a single row:
+//
+//   - Watched shows are being watched, or completed once every aired episode was
+//     watched, with the distinct episodes watched outside of specials as progress
+//   - Shows only in the watchlist are planned to be watched
+//   - Ratings become rankings
+//
+// Movies, seasons & single episodes are left out. It takes no settings.
+type TraktImporter struct{}
+
+// Format returns "trakt"
+func (TraktImporter) Format() string {
+	return "trakt"
+}
+
+// Read reads a Trakt backup
+func (TraktImporter) Read(r io.Reader, settings map[string]string) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ImportRow
+	var aired []int // Aired episodes of every row, 0 when unknown
+	byTitle := map[string]int{}
+	err = walkJSONItems(data, nil, func(line int, raw json.RawMessage) error {
+		var item traktItem
+		if err := json.Unmarshal(raw, &item); err != nil || item.Show == nil {
+			// Not a show entry, e.g. a movie
+			return nil
+		}
+		if (item.Type != "" && item.Type != "show") || item.Season != nil || item.Episode != nil {
+			return nil
+		}
+
+		title := strings.TrimSpace(item.Show.Title)
+		index, ok := byTitle[strings.ToLower(title)]
+		if !ok {
+			index = len(rows)
+			byTitle[strings.ToLower(title)] = index
+			rows = append(rows, models.ImportRow{
+				Line:   line,
+				Serie:  models.Serie{Title: title},
+				Fields: map[string]bool{"title": true},
+			})
+			aired = append(aired, 0)
+		}
+		aired[index] = max(aired[index], item.Show.AiredEpisodes)
+		mergeTraktItem(&rows[index], item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if row.Serie.Title == "" {
+			row.Err = errors.New("show without a title")
+		}
+		if row.Fields["lastEpisodeWatched"] && aired[i] > 0 && row.Serie.CurrentEpisode >= aired[i] {
+			row.Serie.Status = "Completed"
+		}
+	}
+	return &Result{Rows: rows}, nil
+}
+
+// mergeTraktItem adds what a list entry tells about a show to its row
+func mergeTraktItem(row *models.ImportRow, item traktItem) {
+	serie, fields := &row.Serie, row.Fields
+
+	if aired := item.Show.AiredEpisodes; aired > serie.TotalEpisodes {
+		serie.TotalEpisodes = aired
+		fields["totalEpisodes"] = true
+	}
+
+	switch {
+	case item.Rating != nil:
+		serie.Ranking = *item.Rating
+		fields["ranking"] = true
+	case item.Seasons != nil:
+		watched := map[[2]int]bool{}
+		for _, season := range item.Seasons {
+			// Season 0 holds the specials
+			if season.Number == 0 {
+				continue
+			}
+			for _, episode := range season.Episodes {
+				watched[[2]int{season.Number, episode.Number}] = true
+			}
+		}
+		serie.CurrentEpisode = len(watched)
+		fields["lastEpisodeWatched"] = true
+		serie.Status = "Watching"
+		fields["status"] = true
+	case item.ListedAt != "":
+		// Watching takes precedence over the watchlist whatever the list order
+		if !fields["lastEpisodeWatched"] {
+			serie.Status = "Plan to Watch"
+			fields["status"] = true
+		}
+	}
+
+	// The total is unknown without extended info, it's at least what was watched
+	if serie.CurrentEpisode > serie.TotalEpisodes {
+		serie.TotalEpisodes = serie.CurrentEpisode
+		fields["totalEpisodes"] = true
+	}
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"series-tracker/internal/services"
+)
+
+// IdempotencyCleanup periodically deletes the idempotency keys whose TTL ran out
+type IdempotencyCleanup struct {
+	service  services.IdempotencyService
+	interval time.Duration
+}
+
+// NewIdempotencyCleanup returns an IdempotencyCleanup job with the given
+// dependencies, expired keys are deleted once every interval
+func NewIdempotencyCleanup(service services.IdempotencyService, interval time.Duration) *IdempotencyCleanup {
+	return &IdempotencyCleanup{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Start runs the job in the background, once right away and then on every tick.
+// The returned function stops the job.
+func (j *IdempotencyCleanup) Start() (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(j.interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			j.run()
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// run deletes expired keys once, failures are logged and retried on the next tick
+func (j *IdempotencyCleanup) run() {
+	deleted, err := j.service.PurgeExpired(context.Background())
+	if err != nil {
+		log.Printf("idempotency cleanup: failed to delete expired keys: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("idempotency cleanup: deleted %d expired keys", deleted)
+	}
+}
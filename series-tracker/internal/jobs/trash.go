@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"series-tracker/internal/services"
+)
+
+// TrashRetention periodically hard-deletes series that have been in the trash for
+// longer than the retention period
+type TrashRetention struct {
+	service   services.SeriesService
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewTrashRetention returns a TrashRetention job with the given dependencies, the
+// trash is checked once every interval
+func NewTrashRetention(service services.SeriesService, retention, interval time.Duration) *TrashRetention {
+	return &TrashRetention{
+		service:   service,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Start runs the job in the background, once right away and then on every tick.
+// The returned function stops the job.
+func (j *TrashRetention) Start() (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(j.interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			j.run()
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// run purges expired series once, failures are logged and retried on the next tick
+func (j *TrashRetention) run() {
+	// Purges are recorded in the audit log as done by the system
+	ctx := services.WithRequestInfo(context.Background(), services.RequestInfo{Actor: "system"})
+	purged, err := j.service.PurgeExpiredTrash(ctx, j.retention)
+	if err != nil {
+		log.Printf("trash retention: failed to purge expired series: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("trash retention: purged %d series", purged)
+	}
+}
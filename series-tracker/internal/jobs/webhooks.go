@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"series-tracker/internal/services"
+)
+
+// WebhookDispatcher periodically attempts the due webhook deliveries, they're
+// queued along with the changes raising them by services.StageWebhookDeliveries
+type WebhookDispatcher struct {
+	service  services.WebhookService
+	interval time.Duration
+}
+
+// NewWebhookDispatcher returns a WebhookDispatcher with the given dependencies, due
+// deliveries are checked once every interval
+func NewWebhookDispatcher(service services.WebhookService, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Start runs the job in the background, the returned function stops it
+func (j *WebhookDispatcher) Start() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go j.deliver(ctx)
+	return cancel
+}
+
+// deliver attempts due deliveries on every tick, draining full batches right away
+func (j *WebhookDispatcher) deliver(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			attempted, err := j.service.DeliverDue(ctx)
+			if err != nil {
+				log.Printf("webhooks: failed to deliver: %v", err)
+			}
+			if attempted == 0 || ctx.Err() != nil {
+				break
+			}
+		}
+	}
+}
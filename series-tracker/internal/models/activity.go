@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Activity represents a notable change of a series followers of an actor are told
+// about, derived from the audit log.
+type Activity struct {
+	ID              string    // Unique identifier for the activity, stable across reads
+	Kind            string    // What happened; "added", "completed", "ranked"
+	Actor           string    // Who made the change
+	Serie           Serie     // Series after the change
+	PreviousRanking int       // Ranking before the change, only for "ranked"
+	At              time.Time // Moment of the change
+}
+
+// ActivityFilter represents the criteria activities are picked by, zero values are
+// ignored.
+type ActivityFilter struct {
+	Statuses []string // Only activities leaving their series with one of these statuses
+	Kinds    []string // Only activities of these kinds
+	Limit    int      // Maximum number of activities returned
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// AuditEntry represents a single recorded mutation of a series, as stored in the
+// audit log and as returned by the audit endpoint.
+type AuditEntry struct {
+	ID        int                    `json:"id"`        // Unique identifier for the entry
+	Action    string                 `json:"action"`    // Mutation performed; "create", "update", "delete", "status", "upvote", ...
+	SerieID   int                    `json:"serieId"`   // ID of the mutated series
+	Actor     string                 `json:"actor"`     // Who performed the mutation
+	RequestID string                 `json:"requestId"` // ID of the request that triggered the mutation
+	CreatedAt time.Time              `json:"createdAt"` // Moment the mutation was recorded
+	Before    *Serie                 `json:"before"`    // Series before the mutation, nil on creation
+	After     *Serie                 `json:"after"`     // Series after the mutation, nil on deletion
+	Changes   map[string]FieldChange `json:"changes"`   // Fields that changed keyed by their JSON name
+}
+
+// FieldChange represents the old and new values of a single series field.
+type FieldChange struct {
+	From any `json:"from"` // Value before the mutation
+	To   any `json:"to"`   // Value after the mutation
+}
+
+// AuditFilter represents the criteria used to search the audit log, zero values
+// are ignored.
+type AuditFilter struct {
+	SerieID   int       // Only entries for this series
+	Actor     string    // Only entries by this actor
+	Action    string    // Only entries for this action
+	RequestID string    // Only entries triggered by this request
+	Since     time.Time // Only entries recorded at or after this moment
+	Until     time.Time // Only entries recorded before this moment
+	Limit     int       // Maximum number of entries returned
+}
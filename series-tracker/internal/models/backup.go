@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// BackupManifest describes the content of a backup archive, it's stored as
+// manifest.json at the root of the archive. Series have no artwork, archives only
+// hold tables.
+type BackupManifest struct {
+	Format    string        `json:"format"`    // Always "series-tracker-backup"
+	Version   int           `json:"version"`   // Schema version of the tables, restores migrate older ones forward
+	CreatedAt time.Time     `json:"createdAt"` // Moment the backup was taken
+	Tables    []BackupTable `json:"tables"`    // Tables held by the archive, in restore order
+}
+
+// BackupTable describes a table of a backup archive, stored as a JSON array of
+// rows keyed by column name.
+type BackupTable struct {
+	Name   string `json:"name"`   // Name of the table
+	Path   string `json:"path"`   // Path of the file in the archive
+	Rows   int    `json:"rows"`   // Number of rows of the table
+	SHA256 string `json:"sha256"` // Hex encoded SHA-256 of the file's content
+}
+
+// RestoreReport represents the outcome of a restore.
+type RestoreReport struct {
+	Version    int            `json:"version"`    // Schema version the backup was taken with
+	Migrated   bool           `json:"migrated"`   // Whether the backup was migrated forward to the current version
+	BackedUpAt *time.Time     `json:"backedUpAt"` // Moment the backup was taken, unknown for version 0
+	Tables     map[string]int `json:"tables"`     // Rows restored per table
+}
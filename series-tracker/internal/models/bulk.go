@@ -0,0 +1,36 @@
+package models
+
+// BulkOperation represents a single operation of a bulk request.
+type BulkOperation struct {
+	Op     string `json:"op"`               // Operation to run; "create", "update", "delete", "status", "episode"
+	ID     int    `json:"id,omitempty"`     // ID of the series to operate on, unused by create
+	Serie  *Serie `json:"serie,omitempty"`  // Series values, used by create and update
+	Status string `json:"status,omitempty"` // New status, used by status
+}
+
+// BulkRequest represents the payload of a bulk request.
+type BulkRequest struct {
+	Atomic     bool            `json:"atomic"`     // Run every operation in one transaction, all-or-nothing, instead of best-effort
+	Operations []BulkOperation `json:"operations"` // Operations to run, in order
+}
+
+// BulkResult represents the outcome of a single operation of a bulk request.
+type BulkResult struct {
+	Index   int    `json:"index"`           // Position of the operation in the request
+	Op      string `json:"op"`              // Operation that was run
+	Status  int    `json:"status"`          // HTTP status code the operation would have gotten on its own
+	Applied bool   `json:"applied"`         // Whether the operation's changes were kept
+	Serie   *Serie `json:"serie,omitempty"` // Resulting series, if any
+	Error   string `json:"error,omitempty"` // Why the operation failed or wasn't applied
+
+	Err error `json:"-"` // Error returned by the operation, nil on success
+}
+
+// BulkResponse represents the response to a bulk request.
+type BulkResponse struct {
+	Atomic    bool         `json:"atomic"`          // Whether operations ran in one transaction
+	Succeeded int          `json:"succeeded"`       // Number of applied operations
+	Failed    int          `json:"failed"`          // Number of operations that weren't applied
+	Results   []BulkResult `json:"results"`         // Outcome of every operation, in request order
+	Error     string       `json:"error,omitempty"` // Why the request as a whole was rejected, no operation ran then
+}
@@ -0,0 +1,10 @@
+package models
+
+// CacheStats represents the counters of an in-process cache as returned by the
+// cache stats endpoint.
+type CacheStats struct {
+	Hits     int64 `json:"hits"`     // Lookups answered from the cache
+	Misses   int64 `json:"misses"`   // Lookups that had to go to the wrapped repository
+	Entries  int   `json:"entries"`  // Entries currently cached
+	Capacity int   `json:"capacity"` // Maximum number of entries cached
+}
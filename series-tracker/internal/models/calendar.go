@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// AiringSchedule represents when the episodes of a series air, every EveryDays days
+// from the first one given.
+type AiringSchedule struct {
+	SerieID        int       `json:"serieId"`        // Series the schedule belongs to
+	FirstEpisode   int       `json:"firstEpisode"`   // Episode airing at FirstAirAt, 1 by default
+	FirstAirAt     time.Time `json:"firstAirAt"`     // Moment FirstEpisode airs
+	EveryDays      int       `json:"everyDays"`      // Days between two episodes, 7 by default
+	RuntimeMinutes int       `json:"runtimeMinutes"` // Length of an episode, 30 by default
+}
+
+// WatchSession represents a planned session of watching a series.
+type WatchSession struct {
+	ID        int       `json:"id"`        // Unique identifier for the session
+	SerieID   int       `json:"serieId"`   // Series planned to be watched
+	StartsAt  time.Time `json:"startsAt"`  // Moment the session starts
+	Episodes  int       `json:"episodes"`  // Episodes planned to be watched, 1 by default
+	Minutes   int       `json:"minutes"`   // Length of the session, the runtime of the episodes by default
+	CreatedAt time.Time `json:"createdAt"` // Moment the session was planned
+}
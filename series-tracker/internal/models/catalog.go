@@ -0,0 +1,14 @@
+package models
+
+// CatalogTitle represents a title of the catalog ingested from a public dataset,
+// used to autocomplete & fill in series.
+type CatalogTitle struct {
+	ID            string   `json:"id"`                  // Identifier of the title in the dataset, e.g. tt0903747
+	Title         string   `json:"title"`               // Main title
+	OriginalTitle string   `json:"originalTitle"`       // Title in the original language
+	Type          string   `json:"type"`                // Kind of title, e.g. tvSeries
+	StartYear     int      `json:"startYear,omitempty"` // Year it started airing, 0 when unknown
+	EndYear       int      `json:"endYear,omitempty"`   // Year it ended, 0 when unknown or still airing
+	Genres        []string `json:"genres"`              // Genres of the title
+	TotalEpisodes int      `json:"totalEpisodes"`       // Quantity of episodes listed in the dataset, 0 when unknown
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// SerieChange represents a committed mutation of a series as pushed to watchers.
+type SerieChange struct {
+	Seq       uint64    `json:"seq"`       // Position in the change stream, increasing by one per change
+	Action    string    `json:"action"`    // Mutation performed; "create", "update", "delete", "status", "upvote", ...
+	SerieID   int       `json:"serieId"`   // ID of the mutated series
+	Actor     string    `json:"actor"`     // Who performed the mutation
+	RequestID string    `json:"requestId"` // ID of the request that triggered the mutation
+	At        time.Time `json:"at"`        // Moment the mutation was recorded
+	Before    *Serie    `json:"before"`    // Series before the mutation, nil on creation
+	Serie     *Serie    `json:"serie"`     // Series after the mutation, nil once deleted or purged
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Types of the events stored by the event-sourced series storage
+const (
+	EventSerieCreated       = "SerieCreated"       // Data holds the whole Serie
+	EventSerieUpdated       = "SerieUpdated"       // Data holds the whole Serie
+	EventStatusChanged      = "StatusChanged"      // Data holds a Status
+	EventUpvoted            = "Upvoted"            // No data
+	EventDownvoted          = "Downvoted"          // No data
+	EventEpisodeIncremented = "EpisodeIncremented" // No data
+	EventSerieDeleted       = "SerieDeleted"       // No data, the series is trashed at the event's creation time
+	EventSerieRestored      = "SerieRestored"      // No data
+	EventSeriePurged        = "SeriePurged"        // No data
+)
+
+// SerieEvent represents a single change to a series as stored in the event store.
+type SerieEvent struct {
+	Seq       int64           `json:"seq"`       // Global position of the event in the store
+	SerieID   int             `json:"serieId"`   // ID of the series the event belongs to
+	Version   int             `json:"version"`   // Position of the event within its series, starting at 1
+	Type      string          `json:"type"`      // Type of the event; "SerieCreated", "Upvoted", ...
+	Data      json.RawMessage `json:"data"`      // Type specific payload
+	CreatedAt time.Time       `json:"createdAt"` // Moment the event was stored
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// FeedToken represents the secret giving access to a feed of an actor. The token is
+// only returned when it's created.
+type FeedToken struct {
+	Feed      string    `json:"feed"`      // Feed the token opens; "calendar", "activity"
+	Token     string    `json:"token"`     // Secret token
+	URL       string    `json:"url"`       // Path of the feed, token included
+	CreatedAt time.Time `json:"createdAt"` // Moment the token was created
+}
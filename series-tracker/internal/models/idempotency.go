@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// IdempotencyRecord represents the first request made with an idempotency key and,
+// once it finished, the response replayed to repeats of it.
+type IdempotencyRecord struct {
+	Key         string    // Idempotency-Key header sent by the client
+	Fingerprint string    // Hash of the method, path & body of the first request
+	Status      int       // Status code of the response, 0 while the request is in progress
+	ContentType string    // Content type of the response
+	Body        []byte    // Body of the response
+	CreatedAt   time.Time // Moment the first request arrived
+	ExpiresAt   time.Time // Moment the key can be used for a new request
+}
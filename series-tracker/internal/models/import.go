@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// ImportRow represents a series read from an imported file. Only the fields listed
+// in Fields were given, the others keep their current value on update.
+type ImportRow struct {
+	Line   int             // Line of the file the row starts on
+	Serie  Serie           // Values read from the row
+	Fields map[string]bool // JSON names of the fields present in the row
+	Err    error           // Why the row couldn't be read, nil if it could
+}
+
+// ImportOptions represents how an import is run.
+type ImportOptions struct {
+	DryRun     bool                // Only report what would happen, without writing anything
+	OnConflict string              // What to do with rows whose title is taken; "update" (default), "skip", "rename"
+	Progress   func(processed int) // Called after each row with how many were processed, may be nil
+}
+
+// ImportResult represents the outcome of importing a single row.
+type ImportResult struct {
+	Line   int    `json:"line"`            // Line of the file the row starts on
+	Title  string `json:"title"`           // Title read from the row
+	Action string `json:"action"`          // What happened, or would happen on a dry run; "create", "update", "rename", "skip", "error"
+	Serie  *Serie `json:"serie,omitempty"` // Resulting series, without ID when it would be created by a dry run. Renamed ones hold their new title.
+	Error  string `json:"error,omitempty"` // Why the row was rejected
+}
+
+// ImportReport represents the outcome of an import.
+type ImportReport struct {
+	DryRun         bool           `json:"dryRun"`                   // Whether nothing was actually written
+	Created        int            `json:"created"`                  // Number of series created, renamed ones included
+	Renamed        int            `json:"renamed"`                  // Number of series created under a new title since theirs was taken
+	Updated        int            `json:"updated"`                  // Number of series updated
+	Skipped        int            `json:"skipped"`                  // Number of rows left out as they wouldn't change anything or their title is taken
+	Failed         int            `json:"failed"`                   // Number of rejected rows
+	IgnoredColumns []string       `json:"ignoredColumns,omitempty"` // Columns of the file not mapped to any field
+	Results        []ImportResult `json:"results"`                  // Outcome of every row, in file order
+}
+
+// ImportJob represents an import running in the background.
+type ImportJob struct {
+	ID         string        `json:"id"`                   // Unique identifier for the job
+	Format     string        `json:"format"`               // Format of the imported file
+	Status     string        `json:"status"`               // Progress of the job; "queued", "running", "succeeded", "failed"
+	DryRun     bool          `json:"dryRun"`               // Whether the import only reports what it would do
+	Total      int           `json:"total"`                // Number of rows read from the file, 0 until it's read
+	Processed  int           `json:"processed"`            // Number of rows imported so far
+	Report     *ImportReport `json:"report,omitempty"`     // Outcome of the import once it succeeded
+	Error      string        `json:"error,omitempty"`      // Why the import failed
+	CreatedAt  time.Time     `json:"createdAt"`            // Moment the job was queued
+	StartedAt  *time.Time    `json:"startedAt,omitempty"`  // Moment the job started running
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"` // Moment the job succeeded or failed
+}
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"slices"
+	"time"
+)
+
 // Serie represents a series as stored in the database and as expected
 // in JSON responses to the frontend.
 type Serie struct {
@@ -9,6 +14,21 @@ type Serie struct {
 	Status         string `json:"status"`             // Current status of the series; "Watching", "Plan to Watch", "Dropped", "Completed"
 	CurrentEpisode int    `json:"lastEpisodeWatched"` // Last episode watched of the series
 	TotalEpisodes  int    `json:"totalEpisodes"`      // Quantity of episodes in the series
+
+	Year   int      `json:"year,omitempty"`   // Year the series started airing, 0 when unknown
+	Genres []string `json:"genres,omitempty"` // Genres of the series; "Drama", "Comedy", ...
+
+	DeletedAt *time.Time `json:"deletedAt,omitempty"` // Moment the series was moved to the trash, nil if it isn't trashed
+}
+
+// Equal reports whether two series hold the same values, no genres and an empty
+// list of genres alike.
+func (s Serie) Equal(other Serie) bool {
+	sameDeletion := s.DeletedAt == other.DeletedAt ||
+		(s.DeletedAt != nil && other.DeletedAt != nil && s.DeletedAt.Equal(*other.DeletedAt))
+	return s.ID == other.ID && s.Title == other.Title && s.Ranking == other.Ranking && s.Status == other.Status &&
+		s.CurrentEpisode == other.CurrentEpisode && s.TotalEpisodes == other.TotalEpisodes &&
+		s.Year == other.Year && slices.Equal(s.Genres, other.Genres) && sameDeletion
 }
 
 // Status represents the payload for updating a series' status.
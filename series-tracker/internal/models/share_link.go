@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// ShareLink represents a public, read-only view of the series list handed out by an
+// actor. The token opening it is only returned when the link is created.
+type ShareLink struct {
+	ID           int        `json:"id"`                  // Unique identifier for the link
+	Token        string     `json:"token,omitempty"`     // Secret token
+	URL          string     `json:"url,omitempty"`       // Path of the shared view, token included
+	Statuses     []string   `json:"statuses"`            // Only series with one of these statuses are shown, all when empty
+	HideRankings bool       `json:"hideRankings"`        // Whether rankings are left out of the view
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"` // Moment the link stops working, nil if it never expires
+	CreatedAt    time.Time  `json:"createdAt"`           // Moment the link was created
+}
+
+// ShareLinkInput represents the payload for creating a share link.
+type ShareLinkInput struct {
+	Statuses     []string   `json:"statuses"`     // Only series with one of these statuses are shown, all when empty
+	HideRankings bool       `json:"hideRankings"` // Whether rankings are left out of the view
+	ExpiresAt    *time.Time `json:"expiresAt"`    // Moment the link stops working, nil if it never expires
+}
+
+// SharedSerie represents a series as shown through a share link.
+type SharedSerie struct {
+	Title          string `json:"title"`              // Title of the series
+	Ranking        *int   `json:"ranking,omitempty"`  // Score of the series, nil when the link hides rankings
+	Status         string `json:"status"`             // Current status of the series
+	CurrentEpisode int    `json:"lastEpisodeWatched"` // Last episode watched of the series
+	TotalEpisodes  int    `json:"totalEpisodes"`      // Quantity of episodes in the series
+}
+
+// SharedList represents the series list shown through a share link.
+type SharedList struct {
+	Series    []SharedSerie `json:"series"`              // Series shown, best ranked first or by title when rankings are hidden
+	ExpiresAt *time.Time    `json:"expiresAt,omitempty"` // Moment the link stops working, nil if it never expires
+}
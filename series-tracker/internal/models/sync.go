@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// SyncChanges represents the series changed since a sync token, as returned to
+// offline-capable clients.
+type SyncChanges struct {
+	Token   string           `json:"token"`   // Token to sync from next time
+	Reset   bool             `json:"reset"`   // Whether the client must replace its copy by created, on a first sync or when its token can't be synced from anymore
+	Created []Serie          `json:"created"` // Series created since the token, every series on a reset
+	Updated []Serie          `json:"updated"` // Series changed since the token, restored ones included
+	Deleted []SerieTombstone `json:"deleted"` // Series trashed or purged since the token
+
+	Seq int64 `json:"-"` // Latest change sequence number, the token stands for it
+}
+
+// SerieTombstone represents a series deleted since a sync token.
+type SerieTombstone struct {
+	ID        int       `json:"id"`        // ID of the deleted series
+	DeletedAt time.Time `json:"deletedAt"` // Moment the series was moved to the trash
+	Purged    bool      `json:"purged"`    // Whether the series is gone for good instead of in the trash
+}
+
+// SyncPush represents the edits queued by a client while offline.
+type SyncPush struct {
+	Token string          `json:"token"` // Token the client synced from before making the edits
+	Edits []BulkOperation `json:"edits"` // Edits to apply, in order
+}
+
+// SyncResult represents the outcome of a single queued edit.
+type SyncResult struct {
+	Index   int    `json:"index"`           // Position of the edit in the push
+	Op      string `json:"op"`              // Operation of the edit
+	ID      int    `json:"id,omitempty"`    // ID of the series edited, unused by create
+	Outcome string `json:"outcome"`         // "applied", "conflict" or "failed"
+	Serie   *Serie `json:"serie,omitempty"` // Series after the edit once applied, the server's version on a conflict, nil once deleted or on a taken title
+	Error   string `json:"error,omitempty"` // Why the edit wasn't applied
+
+	Err error `json:"-"` // Error returned by the edit, nil unless it failed or took a taken title
+}
+
+// SyncPushResponse represents the response to a push of queued edits.
+type SyncPushResponse struct {
+	Applied   int          `json:"applied"`   // Number of edits applied
+	Conflicts int          `json:"conflicts"` // Number of edits left out because the series changed on the server
+	Failed    int          `json:"failed"`    // Number of edits that failed
+	Results   []SyncResult `json:"results"`   // Outcome of every edit, in push order
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook represents a URL notified of series changes. The secret signing its
+// deliveries is only returned when the webhook is created.
+type Webhook struct {
+	ID        int       `json:"id"`               // Unique identifier for the webhook
+	URL       string    `json:"url"`              // Where deliveries are POSTed
+	Secret    string    `json:"secret,omitempty"` // Key of the HMAC-SHA256 signature sent with every delivery
+	Events    []string  `json:"events"`           // Events delivered; "series.created", "episode.incremented", ... or "*" for all
+	CreatedAt time.Time `json:"createdAt"`        // Moment the webhook was registered
+}
+
+// WebhookInput represents the payload for registering a webhook.
+type WebhookInput struct {
+	URL    string   `json:"url"`    // Where deliveries are POSTed, http or https
+	Events []string `json:"events"` // Events delivered, "*" for all
+}
+
+// WebhookDelivery represents a single event sent, or to be sent, to a webhook along
+// with the outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID            int             `json:"id"`                           // Unique identifier for the delivery
+	WebhookID     int             `json:"webhookId"`                    // Webhook the event is delivered to
+	Event         string          `json:"event"`                        // Event delivered
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"` // Body POSTed to the webhook
+	Status        string          `json:"status"`                       // "pending", "succeeded" or "failed"
+	Attempts      int             `json:"attempts"`                     // Attempts made so far
+	ResponseCode  *int            `json:"responseCode"`                 // HTTP status of the latest attempt, nil if it got no response
+	Error         string          `json:"error,omitempty"`              // Why the latest attempt failed
+	ReplayOf      *int            `json:"replayOf,omitempty"`           // Delivery this one replays
+	CreatedAt     time.Time       `json:"createdAt"`                    // Moment the delivery was queued
+	LastAttemptAt *time.Time      `json:"lastAttemptAt,omitempty"`      // Moment of the latest attempt
+	NextAttemptAt *time.Time      `json:"nextAttemptAt,omitempty"`      // Moment of the next attempt while pending
+}
+
+// WebhookEvent represents the JSON body POSTed to webhooks.
+type WebhookEvent struct {
+	ID         string    `json:"id"`         // Unique identifier for the event, kept on replays
+	Event      string    `json:"event"`      // Event name; "series.created", "series.completed", ...
+	OccurredAt time.Time `json:"occurredAt"` // Moment the change was recorded
+	Actor      string    `json:"actor"`      // Who performed the change
+	RequestID  string    `json:"requestId"`  // ID of the request that triggered the change
+	Serie      *Serie    `json:"serie"`      // Series after the change, nil once deleted or purged
+	Previous   *Serie    `json:"previous"`   // Series before the change, nil on creation
+}
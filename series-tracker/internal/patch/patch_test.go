@@ -0,0 +1,151 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// assertJSON fails unless got & want hold the same JSON value
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("result isn't JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("expectation isn't JSON: %v", err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+// Examples of RFC 7396 appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, test := range tests {
+		got, err := MergePatch([]byte(test.doc), []byte(test.patch))
+		if err != nil {
+			t.Errorf("MergePatch(%s, %s): %v", test.doc, test.patch, err)
+			continue
+		}
+		assertJSON(t, got, test.want)
+	}
+}
+
+func TestMergePatchInvalid(t *testing.T) {
+	if _, err := MergePatch([]byte(`{}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("malformed patch = %v, want ErrInvalidPatch", err)
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	tests := []struct {
+		name, doc, patch string
+		want             string // Empty when the patch must fail
+	}{
+		// Examples of RFC 6902 appendix A
+		{"A.1 add object member", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"A.2 add array element", `{"foo":["bar","baz"]}`,
+			`[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"A.3 remove object member", `{"baz":"qux","foo":"bar"}`,
+			`[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"A.4 remove array element", `{"foo":["bar","qux","baz"]}`,
+			`[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"A.5 replace", `{"baz":"qux","foo":"bar"}`,
+			`[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"A.6 move", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"A.7 move array element", `{"foo":["all","grass","cows","eat"]}`,
+			`[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{"A.8 test", `{"baz":"qux","foo":["a",2,"c"]}`,
+			`[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			`{"baz":"qux","foo":["a",2,"c"]}`},
+		{"A.9 failing test", `{"baz":"qux"}`,
+			`[{"op":"test","path":"/baz","value":"bar"}]`, ``},
+		{"A.10 add nested member", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{"A.11 unrecognized members", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz","value":"qux","xyz":123}]`, `{"foo":"bar","baz":"qux"}`},
+		{"A.12 add to nonexistent target", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz/bat","value":"qux"}]`, ``},
+		{"A.14 escape ordering", `{"/":9,"~1":10}`,
+			`[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`},
+		{"A.15 strings aren't numbers", `{"/":9,"~1":10}`,
+			`[{"op":"test","path":"/~01","value":"10"}]`, ``},
+		{"A.16 add array value", `{"foo":["bar"]}`,
+			`[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+
+		// Arrays
+		{"insert first", `{"a":[1,2]}`, `[{"op":"add","path":"/a/0","value":0}]`, `{"a":[0,1,2]}`},
+		{"insert at length", `{"a":[1,2]}`, `[{"op":"add","path":"/a/2","value":3}]`, `{"a":[1,2,3]}`},
+		{"insert past length", `{"a":[1,2]}`, `[{"op":"add","path":"/a/3","value":3}]`, ``},
+		{"insert in nested array", `{"a":[[1],[2]]}`, `[{"op":"add","path":"/a/1/-","value":3}]`, `{"a":[[1],[2,3]]}`},
+		{"remove last", `{"a":[1,2,3]}`, `[{"op":"remove","path":"/a/2"}]`, `{"a":[1,2]}`},
+		{"remove with dash", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/-"}]`, ``},
+		{"remove past end", `{"a":[1,2]}`, `[{"op":"remove","path":"/a/2"}]`, ``},
+		{"leading zero index", `{"a":[1,2]}`, `[{"op":"replace","path":"/a/01","value":3}]`, ``},
+		{"replace array element", `{"a":[1,2]}`, `[{"op":"replace","path":"/a/1","value":3}]`, `{"a":[1,3]}`},
+
+		// Move & copy
+		{"move into child", `{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/c"}]`, ``},
+		{"move to sibling with shared prefix", `{"a":1}`, `[{"op":"move","from":"/a","path":"/ab"}]`, `{"ab":1}`},
+		{"move onto itself", `{"a":1}`, `[{"op":"move","from":"/a","path":"/a"}]`, `{"a":1}`},
+		{"move missing", `{"a":1}`, `[{"op":"move","from":"/b","path":"/c"}]`, ``},
+		{"copy isn't aliased", `{"a":{"b":1},"l":[{"x":1}]}`,
+			`[{"op":"copy","from":"/a","path":"/c"},{"op":"add","path":"/c/d","value":2},
+			  {"op":"copy","from":"/l/0","path":"/l/-"},{"op":"replace","path":"/l/1/x","value":2}]`,
+			`{"a":{"b":1},"c":{"b":1,"d":2},"l":[{"x":1},{"x":2}]}`},
+
+		// Escapes
+		{"slash escape", `{}`, `[{"op":"add","path":"/a~1b","value":1}]`, `{"a/b":1}`},
+		{"tilde escape", `{}`, `[{"op":"add","path":"/a~0b","value":1}]`, `{"a~b":1}`},
+
+		// Whole patch
+		{"failure rolls everything back", `{"a":1}`,
+			`[{"op":"replace","path":"/a","value":2},{"op":"test","path":"/a","value":1}]`, ``},
+		{"replace root", `{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+		{"add null", `{}`, `[{"op":"add","path":"/a","value":null}]`, `{"a":null}`},
+		{"missing value", `{}`, `[{"op":"add","path":"/a"}]`, ``},
+		{"unknown op", `{}`, `[{"op":"merge","path":"/a","value":1}]`, ``},
+		{"invalid pointer", `{}`, `[{"op":"add","path":"a","value":1}]`, ``},
+		{"not an array", `{}`, `{"op":"add","path":"/a","value":1}`, ``},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := JSONPatch([]byte(test.doc), []byte(test.patch))
+			if test.want == "" {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Errorf("got %s, %v, want ErrInvalidPatch", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("JSONPatch: %v", err)
+			}
+			assertJSON(t, got, test.want)
+		})
+	}
+}
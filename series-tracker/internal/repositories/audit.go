@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"series-tracker/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// AuditRepository defines all the methods to be implemented for audit log data access
+type AuditRepository interface {
+	// CreateAuditEntry inserts a new entry into the audit log
+	CreateAuditEntry(models.AuditEntry) (*models.AuditEntry, error)
+	// GetAuditEntries returns the entries matching the filter, most recent first
+	GetAuditEntries(models.AuditFilter) ([]models.AuditEntry, error)
+	// GetAuditEntriesBySerieIDs returns the entries of every given series in a single
+	// query, most recent first
+	GetAuditEntriesBySerieIDs(ids []int) ([]models.AuditEntry, error)
+}
+
+// auditRepository holds all the dependencies for the repository, db is either
+// the shared *sql.DB or a *sql.Tx when running inside a UnitOfWork
+type auditRepository struct {
+	db DBTX
+}
+
+// NewAuditRepository creates a new AuditRepository with the given DB connection
+func NewAuditRepository(dbConn *sql.DB) AuditRepository {
+	return &auditRepository{
+		db: dbConn,
+	}
+}
+
+// CreateAuditEntry inserts a new entry into the audit log, ID and creation time are
+// filled in by the database.
+func (r *auditRepository) CreateAuditEntry(e models.AuditEntry) (*models.AuditEntry, error) {
+	// Encode the series snapshots & changes as JSON
+	before, err := json.Marshal(e.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := json.Marshal(e.After)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build query
+	query := `INSERT INTO audit_log (action, serie_id, actor, request_id, before, after, changes)
+            VALUES ($1, $2, $3, $4, $5, $6, $7)
+            RETURNING id, created_at`
+
+	// Execute the query & fill in generated values
+	if err := r.db.QueryRow(query, e.Action, e.SerieID, e.Actor, e.RequestID, before, after, changes).Scan(&e.ID, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// GetAuditEntries returns the entries matching the filter, most recent first.
+func (r *auditRepository) GetAuditEntries(f models.AuditFilter) ([]models.AuditEntry, error) {
+	// Build the WHERE clause from the non-zero filter fields
+	conditions := []string{}
+	args := []any{}
+	addCondition := func(column string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s $%d", column, len(args)))
+	}
+	if f.SerieID != 0 {
+		addCondition("serie_id =", f.SerieID)
+	}
+	if f.Actor != "" {
+		addCondition("actor =", f.Actor)
+	}
+	if f.Action != "" {
+		addCondition("action =", f.Action)
+	}
+	if f.RequestID != "" {
+		addCondition("request_id =", f.RequestID)
+	}
+	if !f.Since.IsZero() {
+		addCondition("created_at >=", f.Since)
+	}
+	if !f.Until.IsZero() {
+		addCondition("created_at <", f.Until)
+	}
+
+	query := `SELECT id, action, serie_id, actor, request_id, created_at, before, after, changes FROM audit_log`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if f.Limit > 0 {
+		args = append(args, f.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return r.scanEntries(query, args...)
+}
+
+// GetAuditEntriesBySerieIDs returns the entries of every given series, most recent first.
+func (r *auditRepository) GetAuditEntriesBySerieIDs(ids []int) ([]models.AuditEntry, error) {
+	query := `SELECT id, action, serie_id, actor, request_id, created_at, before, after, changes
+            FROM audit_log
+            WHERE serie_id = ANY($1)
+            ORDER BY created_at DESC, id DESC`
+
+	return r.scanEntries(query, pq.Array(ids))
+}
+
+// scanEntries runs a query returning audit log rows & decodes their JSON columns
+func (r *auditRepository) scanEntries(query string, args ...any) ([]models.AuditEntry, error) {
+	// Query the DB
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Scan results into AuditEntry & decode the JSON columns
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var e models.AuditEntry
+		var before, after, changes []byte
+		if err := rows.Scan(&e.ID, &e.Action, &e.SerieID, &e.Actor, &e.RequestID, &e.CreatedAt, &before, &after, &changes); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(before, &e.Before); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(after, &e.After); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
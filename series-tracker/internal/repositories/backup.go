@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// backupTable is a table saved by backups, serial is the column filled by its
+// sequence, empty when it has none
+type backupTable struct {
+	name   string
+	key    string
+	serial string
+}
+
+// backupTables lists every table holding tracker data, parents before the tables
+// referencing them. Idempotency keys are short-lived & left out, so is the sync
+// bookkeeping since restores send every client back to a full sync, so is the
+// catalog since it's ingested again from its dataset.
+var backupTables = []backupTable{
+	{name: "series", key: "id", serial: "id"},
+	{name: "audit_log", key: "id", serial: "id"},
+	{name: "serie_events", key: "seq", serial: "seq"},
+	{name: "serie_snapshots", key: "serie_id"},
+	{name: "webhooks", key: "id", serial: "id"},
+	{name: "webhook_deliveries", key: "id", serial: "id"},
+	{name: "airing_schedules", key: "serie_id"},
+	{name: "watch_sessions", key: "id", serial: "id"},
+	{name: "feed_tokens", key: "actor, feed"},
+	{name: "share_links", key: "id", serial: "id"},
+}
+
+// BackupRepository defines all the methods to be implemented for dumping & loading
+// the whole database
+type BackupRepository interface {
+	// Tables returns the names of the tables backed up, in restore order
+	Tables() []string
+	// Dump returns every row of every table as a JSON array of objects keyed by
+	// column name, all read from the same snapshot of the database
+	Dump() (map[string]json.RawMessage, error)
+	// Restore replaces the content of every table by the given rows, as returned
+	// by Dump, in a single transaction. Tables left out end up empty.
+	Restore(tables map[string]json.RawMessage) error
+}
+
+// backupRepository holds all the dependencies for the repository, eventSourced
+// tells whether series are stored as events
+type backupRepository struct {
+	db           *sql.DB
+	eventSourced bool
+}
+
+// NewBackupRepository creates a new BackupRepository with the given DB connection
+func NewBackupRepository(dbConn *sql.DB) BackupRepository {
+	return &backupRepository{
+		db: dbConn,
+	}
+}
+
+// NewEventSourcedBackupRepository creates a new BackupRepository with the given DB
+// connection for series stored as events, restored series missing from the event
+// store get their events recorded
+func NewEventSourcedBackupRepository(dbConn *sql.DB) BackupRepository {
+	return &backupRepository{
+		db:           dbConn,
+		eventSourced: true,
+	}
+}
+
+// Tables returns the names of the backed up tables.
+func (r *backupRepository) Tables() []string {
+	names := make([]string, len(backupTables))
+	for i, table := range backupTables {
+		names[i] = table.name
+	}
+	return names
+}
+
+// Dump reads every table in a read only, repeatable read transaction so the tables
+// are consistent with each other.
+func (r *backupRepository) Dump() (map[string]json.RawMessage, error) {
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	dump := make(map[string]json.RawMessage, len(backupTables))
+	for _, table := range backupTables {
+		query := fmt.Sprintf(`SELECT COALESCE(json_agg(t ORDER BY %s), '[]') FROM %s t`, table.key, table.name)
+		var rows []byte
+		if err := tx.QueryRow(query).Scan(&rows); err != nil {
+			return nil, fmt.Errorf("failed to dump %s: %w", table.name, err)
+		}
+		dump[table.name] = rows
+	}
+
+	return dump, nil
+}
+
+// Restore empties every table & loads the given rows, resetting the sequences past
+// the restored IDs. Columns missing from the rows are set to NULL.
+func (r *backupRepository) Restore(tables map[string]json.RawMessage) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		// TRUNCATE locks the tables, writers wait until the restore is over
+		if _, err := tx.Exec("TRUNCATE " + strings.Join(r.Tables(), ", ") + " RESTART IDENTITY"); err != nil {
+			return err
+		}
+
+		for _, table := range backupTables {
+			rows, ok := tables[table.name]
+			if !ok {
+				continue
+			}
+			insert := fmt.Sprintf(`INSERT INTO %[1]s SELECT * FROM json_populate_recordset(NULL::%[1]s, $1)`, table.name)
+			if _, err := tx.Exec(insert, []byte(rows)); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", table.name, err)
+			}
+
+			if table.serial == "" {
+				continue
+			}
+			reset := fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('%[1]s', '%[2]s'), COALESCE(MAX(%[2]s), 0) + 1, false) FROM %[1]s`, table.name, table.serial)
+			if _, err := tx.Exec(reset); err != nil {
+				return fmt.Errorf("failed to reset the sequence of %s: %w", table.name, err)
+			}
+		}
+
+		if r.eventSourced {
+			// Backups taken with table storage hold no events
+			if _, err := bootstrap(tx); err != nil {
+				return err
+			}
+		}
+
+		// Series changed behind every sync token's back, clients have to start over
+		if _, err := tx.Exec(`DELETE FROM serie_tombstones`); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT INTO sync_resets (seq) VALUES (` + nextChangeSeq + `)`)
+		return err
+	})
+}
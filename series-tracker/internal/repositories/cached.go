@@ -0,0 +1,324 @@
+package repositories
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"series-tracker/internal/cache"
+	"series-tracker/internal/models"
+)
+
+// Prefixes of the cache keys, single series are keyed by ID and lists by the
+// parameters of the query that produced them
+const (
+	serieKeyPrefix = "serie:"
+	listKeyPrefix  = "list:"
+)
+
+// CachedSeriesRepository defines a SeriesRepository that caches the reads of the
+// repository it wraps
+type CachedSeriesRepository interface {
+	SeriesRepository
+	// WrapUnitOfWork returns a UnitOfWork whose writes invalidate this cache, every
+	// transaction touching series must go through it
+	WrapUnitOfWork(uow UnitOfWork) UnitOfWork
+	// Stats returns the hit/miss counters of the cache
+	Stats() models.CacheStats
+	// Clear drops every cached entry, for when series were written behind the
+	// cache's back
+	Clear()
+}
+
+// cachedSeriesRepository holds all the dependencies for the repository. generation
+// is bumped on every invalidation so reads that started before a write don't store
+// stale results after it.
+type cachedSeriesRepository struct {
+	repo SeriesRepository
+
+	mu         sync.Mutex
+	lru        *cache.LRU[string, any]
+	generation uint64
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewCachedSeriesRepository wraps repo with an in-process LRU cache holding at most
+// size entries
+func NewCachedSeriesRepository(repo SeriesRepository, size int) CachedSeriesRepository {
+	return &cachedSeriesRepository{
+		repo: repo,
+		lru:  cache.NewLRU[string, any](size),
+	}
+}
+
+// serieKey returns the cache key of a single series
+func serieKey(id int) string {
+	return serieKeyPrefix + strconv.Itoa(id)
+}
+
+// lookup returns the cached value under key, counting the hit or miss, along with
+// the generation to hand to store on a miss
+func (r *cachedSeriesRepository) lookup(key string) (any, uint64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.lru.Get(key)
+	if ok {
+		r.hits.Add(1)
+	} else {
+		r.misses.Add(1)
+	}
+	return value, r.generation, ok
+}
+
+// store caches value under key unless the cache was invalidated since generation
+func (r *cachedSeriesRepository) store(key string, value any, generation uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if generation == r.generation {
+		r.lru.Add(key, value)
+	}
+}
+
+// invalidate drops the given series and every cached list
+func (r *cachedSeriesRepository) invalidate(ids ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	for _, id := range ids {
+		r.lru.Remove(serieKey(id))
+	}
+	r.lru.RemoveFunc(func(key string) bool {
+		return strings.HasPrefix(key, listKeyPrefix)
+	})
+}
+
+// Clear drops every cached entry.
+func (r *cachedSeriesRepository) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	r.lru.RemoveFunc(func(string) bool { return true })
+}
+
+// cachedList returns the list cached under key or loads and caches it, callers get
+// their own copy so they can't alter the cached one
+func (r *cachedSeriesRepository) cachedList(key string, load func() ([]models.Serie, error)) ([]models.Serie, error) {
+	value, generation, ok := r.lookup(key)
+	if ok {
+		return append([]models.Serie{}, value.([]models.Serie)...), nil
+	}
+
+	series, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.store(key, append([]models.Serie{}, series...), generation)
+
+	return series, nil
+}
+
+// Stats returns the hit/miss counters of the cache.
+func (r *cachedSeriesRepository) Stats() models.CacheStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return models.CacheStats{
+		Hits:     r.hits.Load(),
+		Misses:   r.misses.Load(),
+		Entries:  r.lru.Len(),
+		Capacity: r.lru.Capacity(),
+	}
+}
+
+// GetAllSeries returns the cached list of all series, loading it on a miss.
+func (r *cachedSeriesRepository) GetAllSeries() ([]models.Serie, error) {
+	return r.cachedList(listKeyPrefix+"all", r.repo.GetAllSeries)
+}
+
+// GetTrashedSeries returns the cached list of trashed series, loading it on a miss.
+func (r *cachedSeriesRepository) GetTrashedSeries() ([]models.Serie, error) {
+	return r.cachedList(listKeyPrefix+"trash", r.repo.GetTrashedSeries)
+}
+
+// GetSerieByID returns the cached series, loading it on a miss.
+func (r *cachedSeriesRepository) GetSerieByID(id int) (*models.Serie, error) {
+	key := serieKey(id)
+	value, generation, ok := r.lookup(key)
+	if ok {
+		serie := value.(models.Serie)
+		return &serie, nil
+	}
+
+	serie, err := r.repo.GetSerieByID(id)
+	if err != nil {
+		return nil, err
+	}
+	r.store(key, *serie, generation)
+
+	return serie, nil
+}
+
+// GetSerieByIDForUpdate always goes to the wrapped repository, locks are only
+// meaningful on the database.
+func (r *cachedSeriesRepository) GetSerieByIDForUpdate(id int) (*models.Serie, error) {
+	return r.repo.GetSerieByIDForUpdate(id)
+}
+
+// GetChangesSince always goes to the wrapped repository, changes are read up to the
+// database's sync horizon.
+func (r *cachedSeriesRepository) GetChangesSince(since int64) (*models.SyncChanges, error) {
+	return r.repo.GetChangesSince(since)
+}
+
+// IsSyncable always goes to the wrapped repository, sync state isn't cached.
+func (r *cachedSeriesRepository) IsSyncable(since int64) (bool, error) {
+	return r.repo.IsSyncable(since)
+}
+
+// GetChangeSeq always goes to the wrapped repository, change numbers aren't cached.
+func (r *cachedSeriesRepository) GetChangeSeq(id int) (int64, error) {
+	return r.repo.GetChangeSeq(id)
+}
+
+// CreateNewSerie creates the series and drops every cached list.
+func (r *cachedSeriesRepository) CreateNewSerie(s models.Serie) (*models.Serie, error) {
+	defer r.invalidate()
+	return r.repo.CreateNewSerie(s)
+}
+
+// UpdateSerie updates the series and drops it from the cache.
+func (r *cachedSeriesRepository) UpdateSerie(s models.Serie) (*models.Serie, error) {
+	defer r.invalidate(s.ID)
+	return r.repo.UpdateSerie(s)
+}
+
+// DeleteSerie trashes the series and drops it from the cache.
+func (r *cachedSeriesRepository) DeleteSerie(id int) error {
+	defer r.invalidate(id)
+	return r.repo.DeleteSerie(id)
+}
+
+// RestoreSerie restores the series and drops it from the cache.
+func (r *cachedSeriesRepository) RestoreSerie(id int) (*models.Serie, error) {
+	defer r.invalidate(id)
+	return r.repo.RestoreSerie(id)
+}
+
+// PurgeSerie purges the series and drops it from the cache.
+func (r *cachedSeriesRepository) PurgeSerie(id int) (*models.Serie, error) {
+	defer r.invalidate(id)
+	return r.repo.PurgeSerie(id)
+}
+
+// PurgeTrashedBefore purges the expired series and drops them from the cache.
+func (r *cachedSeriesRepository) PurgeTrashedBefore(cutoff time.Time) ([]models.Serie, error) {
+	purged, err := r.repo.PurgeTrashedBefore(cutoff)
+
+	ids := make([]int, 0, len(purged))
+	for _, s := range purged {
+		ids = append(ids, s.ID)
+	}
+	r.invalidate(ids...)
+
+	return purged, err
+}
+
+// WrapUnitOfWork returns a UnitOfWork whose writes invalidate this cache.
+func (r *cachedSeriesRepository) WrapUnitOfWork(uow UnitOfWork) UnitOfWork {
+	return &cachedUnitOfWork{
+		uow:   uow,
+		cache: r,
+	}
+}
+
+// cachedUnitOfWork holds all the dependencies for the unit of work
+type cachedUnitOfWork struct {
+	uow   UnitOfWork
+	cache *cachedSeriesRepository
+}
+
+// Do runs fn through the wrapped UnitOfWork, recording which series it writes and
+// invalidating them once the transaction is over, committed or not
+func (u *cachedUnitOfWork) Do(fn func(repos *Repositories) error) error {
+	tracker := &writeTracker{}
+	defer func() {
+		if tracker.written {
+			u.cache.invalidate(tracker.ids...)
+		}
+	}()
+
+	return u.uow.Do(func(repos *Repositories) error {
+		// Reads inside the transaction skip the cache since they may see
+		// uncommitted data, writes are only tracked
+		tracked := *repos
+		tracked.Series = &trackingSeriesRepository{SeriesRepository: repos.Series, tracker: tracker}
+		return fn(&tracked)
+	})
+}
+
+// writeTracker records the series written during a transaction
+type writeTracker struct {
+	written bool
+	ids     []int
+}
+
+// track records a write touching the given series
+func (t *writeTracker) track(ids ...int) {
+	t.written = true
+	t.ids = append(t.ids, ids...)
+}
+
+// trackingSeriesRepository passes every call through to the transaction-bound
+// repository, recording the series touched by writes
+type trackingSeriesRepository struct {
+	SeriesRepository
+	tracker *writeTracker
+}
+
+// CreateNewSerie creates the series, recording the write.
+func (r *trackingSeriesRepository) CreateNewSerie(s models.Serie) (*models.Serie, error) {
+	r.tracker.track()
+	return r.SeriesRepository.CreateNewSerie(s)
+}
+
+// UpdateSerie updates the series, recording the write.
+func (r *trackingSeriesRepository) UpdateSerie(s models.Serie) (*models.Serie, error) {
+	r.tracker.track(s.ID)
+	return r.SeriesRepository.UpdateSerie(s)
+}
+
+// DeleteSerie trashes the series, recording the write.
+func (r *trackingSeriesRepository) DeleteSerie(id int) error {
+	r.tracker.track(id)
+	return r.SeriesRepository.DeleteSerie(id)
+}
+
+// RestoreSerie restores the series, recording the write.
+func (r *trackingSeriesRepository) RestoreSerie(id int) (*models.Serie, error) {
+	r.tracker.track(id)
+	return r.SeriesRepository.RestoreSerie(id)
+}
+
+// PurgeSerie purges the series, recording the write.
+func (r *trackingSeriesRepository) PurgeSerie(id int) (*models.Serie, error) {
+	r.tracker.track(id)
+	return r.SeriesRepository.PurgeSerie(id)
+}
+
+// PurgeTrashedBefore purges the expired series, recording the write.
+func (r *trackingSeriesRepository) PurgeTrashedBefore(cutoff time.Time) ([]models.Serie, error) {
+	purged, err := r.SeriesRepository.PurgeTrashedBefore(cutoff)
+	r.tracker.track()
+	for _, s := range purged {
+		r.tracker.track(s.ID)
+	}
+	return purged, err
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"sync"
+	"testing"
+
+	"series-tracker/internal/models"
+)
+
+// stubSeriesRepository serves series from memory, counting reads. Methods it
+// doesn't override panic through the nil embedded interface.
+type stubSeriesRepository struct {
+	SeriesRepository
+
+	mu     sync.Mutex
+	series map[int]models.Serie
+	reads  int
+	// loaded, when set, is called by GetSerieByID once the series was read
+	loaded func()
+}
+
+func (r *stubSeriesRepository) GetSerieByID(id int) (*models.Serie, error) {
+	r.mu.Lock()
+	r.reads++
+	serie := r.series[id]
+	r.mu.Unlock()
+
+	if r.loaded != nil {
+		r.loaded()
+	}
+	return &serie, nil
+}
+
+func (r *stubSeriesRepository) GetAllSeries() ([]models.Serie, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reads++
+	series := []models.Serie{}
+	for _, s := range r.series {
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+func (r *stubSeriesRepository) UpdateSerie(s models.Serie) (*models.Serie, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.series[s.ID] = s
+	return &s, nil
+}
+
+func (r *stubSeriesRepository) readCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reads
+}
+
+func TestCachedSeriesRepositoryHitsAndInvalidation(t *testing.T) {
+	stub := &stubSeriesRepository{series: map[int]models.Serie{1: {ID: 1, Title: "Dark"}}}
+	repo := NewCachedSeriesRepository(stub, 8)
+
+	for range 2 {
+		if _, err := repo.GetSerieByID(1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.GetAllSeries(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stub.readCount() != 2 {
+		t.Errorf("%d reads reached the repository, want 2", stub.readCount())
+	}
+
+	if _, err := repo.UpdateSerie(models.Serie{ID: 1, Title: "Dark (2017)"}); err != nil {
+		t.Fatal(err)
+	}
+	serie, _ := repo.GetSerieByID(1)
+	series, _ := repo.GetAllSeries()
+	if serie.Title != "Dark (2017)" || series[0].Title != "Dark (2017)" {
+		t.Errorf("read %q & %q after the update", serie.Title, series[0].Title)
+	}
+
+	if stats := repo.Stats(); stats.Hits != 2 || stats.Misses != 4 || stats.Entries != 2 {
+		t.Errorf("stats = %+v, want 2 hits, 4 misses & 2 entries", stats)
+	}
+}
+
+// A read that started before a write must not cache what it read once the write
+// invalidated the cache
+func TestCachedSeriesRepositoryGenerationDropsStaleReads(t *testing.T) {
+	stub := &stubSeriesRepository{series: map[int]models.Serie{1: {ID: 1, Title: "Dark"}}}
+	repo := NewCachedSeriesRepository(stub, 8)
+
+	read, written := make(chan struct{}), make(chan struct{})
+	stub.loaded = func() {
+		close(read)
+		<-written
+	}
+
+	var stale *models.Serie
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		stale, _ = repo.GetSerieByID(1)
+	}()
+
+	<-read
+	stub.loaded = nil
+	if _, err := repo.UpdateSerie(models.Serie{ID: 1, Title: "Dark (2017)"}); err != nil {
+		t.Fatal(err)
+	}
+	close(written)
+	wg.Wait()
+
+	if stale.Title != "Dark" {
+		t.Fatalf("the concurrent read returned %q", stale.Title)
+	}
+	serie, _ := repo.GetSerieByID(1)
+	if serie.Title != "Dark (2017)" {
+		t.Errorf("read %q after the update, the stale read was cached", serie.Title)
+	}
+	if stub.readCount() != 2 {
+		t.Errorf("%d reads reached the repository, want 2", stub.readCount())
+	}
+}
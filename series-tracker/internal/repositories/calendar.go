@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"series-tracker/internal/models"
+)
+
+// CalendarRepository defines all the methods to be implemented for airing schedule
+// & watch session data access. Rows aren't removed along with their series, the
+// ones of purged series are left for callers to skip.
+type CalendarRepository interface {
+	// GetSchedule finds the airing schedule of a series
+	GetSchedule(serieID int) (*models.AiringSchedule, error)
+	// GetSchedules returns every airing schedule
+	GetSchedules() ([]models.AiringSchedule, error)
+	// SetSchedule creates or replaces the airing schedule of a series
+	SetSchedule(models.AiringSchedule) (*models.AiringSchedule, error)
+	// DeleteSchedule deletes the airing schedule of a series
+	DeleteSchedule(serieID int) error
+	// CreateSession inserts a new watch session
+	CreateSession(models.WatchSession) (*models.WatchSession, error)
+	// GetSessions returns the watch sessions of a series ordered by start
+	GetSessions(serieID int) ([]models.WatchSession, error)
+	// GetSessionsBetween returns the watch sessions of every series starting within
+	// [from, to) ordered by start
+	GetSessionsBetween(from, to time.Time) ([]models.WatchSession, error)
+	// DeleteSession deletes a watch session of a series
+	DeleteSession(serieID, id int) error
+}
+
+// calendarRepository holds all the dependencies for the repository
+type calendarRepository struct {
+	db DBTX
+}
+
+// NewCalendarRepository creates a new CalendarRepository with the given DB connection
+func NewCalendarRepository(dbConn *sql.DB) CalendarRepository {
+	return &calendarRepository{
+		db: dbConn,
+	}
+}
+
+// sessionColumns are the columns scanned by scanSessions, in order
+const sessionColumns = `id, serie_id, starts_at, episodes, minutes, created_at`
+
+// GetSchedule finds a schedule, returns sql.ErrNoRows if the series has none.
+func (r *calendarRepository) GetSchedule(serieID int) (*models.AiringSchedule, error) {
+	var s models.AiringSchedule
+	err := r.db.QueryRow(`SELECT serie_id, first_episode, first_air_at, every_days, runtime_minutes
+            FROM airing_schedules WHERE serie_id = $1`, serieID).
+		Scan(&s.SerieID, &s.FirstEpisode, &s.FirstAirAt, &s.EveryDays, &s.RuntimeMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// GetSchedules returns every schedule ordered by series ID.
+func (r *calendarRepository) GetSchedules() ([]models.AiringSchedule, error) {
+	rows, err := r.db.Query(`SELECT serie_id, first_episode, first_air_at, every_days, runtime_minutes
+            FROM airing_schedules ORDER BY serie_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []models.AiringSchedule{}
+	for rows.Next() {
+		var s models.AiringSchedule
+		if err := rows.Scan(&s.SerieID, &s.FirstEpisode, &s.FirstAirAt, &s.EveryDays, &s.RuntimeMinutes); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+
+	return schedules, rows.Err()
+}
+
+// SetSchedule upserts the schedule of a series in a single statement.
+func (r *calendarRepository) SetSchedule(s models.AiringSchedule) (*models.AiringSchedule, error) {
+	query := `INSERT INTO airing_schedules (serie_id, first_episode, first_air_at, every_days, runtime_minutes)
+            VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (serie_id) DO UPDATE
+              SET first_episode = EXCLUDED.first_episode, first_air_at = EXCLUDED.first_air_at,
+                  every_days = EXCLUDED.every_days, runtime_minutes = EXCLUDED.runtime_minutes`
+
+	if _, err := r.db.Exec(query, s.SerieID, s.FirstEpisode, s.FirstAirAt, s.EveryDays, s.RuntimeMinutes); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// DeleteSchedule deletes a schedule, returns sql.ErrNoRows if the series has none.
+func (r *calendarRepository) DeleteSchedule(serieID int) error {
+	result, err := r.db.Exec(`DELETE FROM airing_schedules WHERE serie_id = $1`, serieID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// CreateSession inserts a session, ID and creation time are filled in by the database.
+func (r *calendarRepository) CreateSession(s models.WatchSession) (*models.WatchSession, error) {
+	query := `INSERT INTO watch_sessions (serie_id, starts_at, episodes, minutes)
+            VALUES ($1, $2, $3, $4)
+            RETURNING id, created_at`
+
+	if err := r.db.QueryRow(query, s.SerieID, s.StartsAt, s.Episodes, s.Minutes).Scan(&s.ID, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// GetSessions returns the sessions of a series ordered by start.
+func (r *calendarRepository) GetSessions(serieID int) ([]models.WatchSession, error) {
+	return r.scanSessions(`SELECT `+sessionColumns+` FROM watch_sessions
+            WHERE serie_id = $1
+            ORDER BY starts_at, id`, serieID)
+}
+
+// GetSessionsBetween returns the sessions starting within [from, to) ordered by start.
+func (r *calendarRepository) GetSessionsBetween(from, to time.Time) ([]models.WatchSession, error) {
+	return r.scanSessions(`SELECT `+sessionColumns+` FROM watch_sessions
+            WHERE starts_at >= $1 AND starts_at < $2
+            ORDER BY starts_at, id`, from, to)
+}
+
+// DeleteSession deletes a session, returns sql.ErrNoRows if the series has no such
+// session.
+func (r *calendarRepository) DeleteSession(serieID, id int) error {
+	result, err := r.db.Exec(`DELETE FROM watch_sessions WHERE id = $1 AND serie_id = $2`, id, serieID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// scanSessions runs a query returning session rows
+func (r *calendarRepository) scanSessions(query string, args ...any) ([]models.WatchSession, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.WatchSession{}
+	for rows.Next() {
+		var s models.WatchSession
+		if err := rows.Scan(&s.ID, &s.SerieID, &s.StartsAt, &s.Episodes, &s.Minutes, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"database/sql"
+	"strings"
+
+	"series-tracker/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// catalogColumns are the columns catalog titles are read from, in scan order
+const catalogColumns = "id, title, original_title, type, start_year, end_year, genres, total_episodes"
+
+// likeEscaper escapes the wildcards of LIKE patterns, backslash being the default
+// escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// CatalogRepository defines all the methods to be implemented for catalog data access
+type CatalogRepository interface {
+	// ReplaceCatalog replaces every title of the catalog by the given ones
+	ReplaceCatalog(titles []models.CatalogTitle) error
+	// SearchTitles returns at most limit titles starting with prefix, ignoring case
+	SearchTitles(prefix string, limit int) ([]models.CatalogTitle, error)
+	// FindTitle returns the title best matching the given one exactly, ignoring case
+	FindTitle(title string) (*models.CatalogTitle, error)
+}
+
+// catalogRepository holds all the dependencies for the repository
+type catalogRepository struct {
+	db *sql.DB
+}
+
+// NewCatalogRepository creates a new CatalogRepository with the given DB connection
+func NewCatalogRepository(dbConn *sql.DB) CatalogRepository {
+	return &catalogRepository{
+		db: dbConn,
+	}
+}
+
+// ReplaceCatalog deletes every title & copies the new ones in within a single
+// transaction. Unlike with TRUNCATE, searches & auto-fill aren't blocked meanwhile
+// and keep seeing the previous catalog until it commits. Concurrent replacements
+// wait for each other.
+func (r *catalogRepository) ReplaceCatalog(titles []models.CatalogTitle) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`LOCK TABLE catalog_titles IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM catalog_titles`); err != nil {
+			return err
+		}
+
+		stmt, err := tx.Prepare(pq.CopyIn("catalog_titles",
+			"id", "title", "original_title", "type", "start_year", "end_year", "genres", "total_episodes"))
+		if err != nil {
+			return err
+		}
+		for _, t := range titles {
+			_, err := stmt.Exec(t.ID, t.Title, t.OriginalTitle, t.Type, t.StartYear, t.EndYear, pq.Array(t.Genres), t.TotalEpisodes)
+			if err != nil {
+				stmt.Close()
+				return err
+			}
+		}
+		// Flush the copy
+		if _, err := stmt.Exec(); err != nil {
+			stmt.Close()
+			return err
+		}
+		return stmt.Close()
+	})
+}
+
+// SearchTitles returns the titles starting with prefix, the exact match first then
+// the ones with the most episodes.
+func (r *catalogRepository) SearchTitles(prefix string, limit int) ([]models.CatalogTitle, error) {
+	rows, err := r.db.Query(`SELECT `+catalogColumns+` FROM catalog_titles
+            WHERE lower(title) LIKE $1
+            ORDER BY lower(title) = lower($2) DESC, total_episodes DESC, title
+            LIMIT $3`,
+		likeEscaper.Replace(strings.ToLower(prefix))+"%", prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	titles := []models.CatalogTitle{}
+	for rows.Next() {
+		var t models.CatalogTitle
+		if err := rows.Scan(catalogFields(&t)...); err != nil {
+			return nil, err
+		}
+		titles = append(titles, t)
+	}
+
+	return titles, rows.Err()
+}
+
+// FindTitle returns sql.ErrNoRows when no title matches. Among titles sharing the
+// name, the one with the most episodes, then the latest, wins.
+func (r *catalogRepository) FindTitle(title string) (*models.CatalogTitle, error) {
+	var t models.CatalogTitle
+	err := r.db.QueryRow(`SELECT `+catalogColumns+` FROM catalog_titles
+            WHERE lower(title) = lower($1)
+            ORDER BY total_episodes DESC, start_year DESC
+            LIMIT 1`, title).Scan(catalogFields(&t)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// catalogFields returns the destinations of catalogColumns within t
+func catalogFields(t *models.CatalogTitle) []any {
+	return []any{&t.ID, &t.Title, &t.OriginalTitle, &t.Type, &t.StartYear, &t.EndYear, pq.Array(&t.Genres), &t.TotalEpisodes}
+}
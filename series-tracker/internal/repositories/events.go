@@ -0,0 +1,617 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"series-tracker/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// snapshotEvery is how many events a series accumulates between snapshots
+const snapshotEvery = 50
+
+// EventSourcedSeriesRepository defines a SeriesRepository backed by an append-only
+// event store, the series table is kept as a projection of the events
+type EventSourcedSeriesRepository interface {
+	SeriesRepository
+	// GetSerieEvents returns every event of a series in order
+	GetSerieEvents(id int) ([]models.SerieEvent, error)
+	// GetSerieAt rebuilds a series as it was at the given moment
+	GetSerieAt(id int, at time.Time) (*models.Serie, error)
+	// Bootstrap records a SerieCreated event for every series in the projection that
+	// has no events yet, e.g. rows seeded before switching storage modes
+	Bootstrap() (int, error)
+	// Replay rebuilds the series projection and snapshots from scratch out of the
+	// event store, returning how many events were replayed
+	Replay() (int, error)
+}
+
+// eventSeriesRepository holds all the dependencies for the repository, db is either
+// the shared *sql.DB or a *sql.Tx when running inside a UnitOfWork
+type eventSeriesRepository struct {
+	db DBTX
+}
+
+// NewEventSourcedSeriesRepository creates a new EventSourcedSeriesRepository with the
+// given DB connection
+func NewEventSourcedSeriesRepository(dbConn *sql.DB) EventSourcedSeriesRepository {
+	return &eventSeriesRepository{
+		db: dbConn,
+	}
+}
+
+// atomic runs fn inside a transaction so events and projection are always written
+// together, reusing the surrounding transaction when bound to a UnitOfWork
+func (r *eventSeriesRepository) atomic(fn func(db DBTX) error) error {
+	return inTx(r.db, fn)
+}
+
+// projection returns a table backed repository over the same connection, used for
+// the reads served straight from the series projection
+func (r *eventSeriesRepository) projection() *seriesRepository {
+	return &seriesRepository{db: r.db}
+}
+
+// GetAllSeries returns a list of all series from the projection.
+func (r *eventSeriesRepository) GetAllSeries() ([]models.Serie, error) {
+	return r.projection().GetAllSeries()
+}
+
+// GetTrashedSeries returns a list of all trashed series from the projection.
+func (r *eventSeriesRepository) GetTrashedSeries() ([]models.Serie, error) {
+	return r.projection().GetTrashedSeries()
+}
+
+// GetChangesSince returns the changes recorded in the projection.
+func (r *eventSeriesRepository) GetChangesSince(since int64) (*models.SyncChanges, error) {
+	return r.projection().GetChangesSince(since)
+}
+
+// IsSyncable tells whether the projection's changes can be synced from a token.
+func (r *eventSeriesRepository) IsSyncable(since int64) (bool, error) {
+	return r.projection().IsSyncable(since)
+}
+
+// GetChangeSeq returns the latest change of a series recorded in the projection.
+func (r *eventSeriesRepository) GetChangeSeq(id int) (int64, error) {
+	return r.projection().GetChangeSeq(id)
+}
+
+// GetSerieByID rebuilds a series out of its latest snapshot and the events after it.
+func (r *eventSeriesRepository) GetSerieByID(id int) (*models.Serie, error) {
+	serie, _, err := loadSerie(r.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if serie == nil || serie.DeletedAt != nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return serie, nil
+}
+
+// GetSerieByIDForUpdate locks the series' projection row before rebuilding it, so
+// concurrent writers wait for each other instead of racing on the next version.
+func (r *eventSeriesRepository) GetSerieByIDForUpdate(id int) (*models.Serie, error) {
+	if _, err := r.projection().GetSerieByIDForUpdate(id); err != nil {
+		return nil, err
+	}
+
+	return r.GetSerieByID(id)
+}
+
+// CreateNewSerie records a SerieCreated event under a freshly allocated ID.
+func (r *eventSeriesRepository) CreateNewSerie(s models.Serie) (*models.Serie, error) {
+	var created *models.Serie
+	err := r.atomic(func(db DBTX) error {
+		// IDs keep coming from the projection's sequence so both modes agree
+		if err := db.QueryRow(`SELECT nextval(pg_get_serial_sequence('series', 'id'))`).Scan(&s.ID); err != nil {
+			return err
+		}
+		s.DeletedAt = nil
+
+		var err error
+		created, err = appendEvent(db, nil, 0, s.ID, models.EventSerieCreated, s)
+		return err
+	})
+	if err != nil {
+		return nil, conflictError(err)
+	}
+
+	return created, nil
+}
+
+// UpdateSerie records the event that best describes the difference between the
+// stored series and the given one, falling back to a full SerieUpdated.
+func (r *eventSeriesRepository) UpdateSerie(s models.Serie) (*models.Serie, error) {
+	var updated *models.Serie
+	err := r.atomic(func(db DBTX) error {
+		current, version, err := loadSerie(db, s.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.DeletedAt != nil {
+			return sql.ErrNoRows
+		}
+
+		eventType, payload := updateEvent(*current, s)
+		if eventType == "" {
+			// Nothing changed, there's nothing to record
+			updated = current
+			return nil
+		}
+
+		updated, err = appendEvent(db, current, version, s.ID, eventType, payload)
+		return err
+	})
+	if err != nil {
+		return nil, conflictError(err)
+	}
+
+	return updated, nil
+}
+
+// DeleteSerie records a SerieDeleted event, moving the series to the trash.
+func (r *eventSeriesRepository) DeleteSerie(id int) error {
+	return r.atomic(func(db DBTX) error {
+		current, version, err := loadSerie(db, id)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.DeletedAt != nil {
+			return sql.ErrNoRows
+		}
+
+		_, err = appendEvent(db, current, version, id, models.EventSerieDeleted, nil)
+		return err
+	})
+}
+
+// RestoreSerie records a SerieRestored event, taking the series out of the trash.
+func (r *eventSeriesRepository) RestoreSerie(id int) (*models.Serie, error) {
+	var restored *models.Serie
+	err := r.atomic(func(db DBTX) error {
+		current, version, err := loadSerie(db, id)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.DeletedAt == nil {
+			return sql.ErrNoRows
+		}
+
+		restored, err = appendEvent(db, current, version, id, models.EventSerieRestored, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+// PurgeSerie records a SeriePurged event, removing a trashed series from the
+// projection for good. Its events are kept.
+func (r *eventSeriesRepository) PurgeSerie(id int) (*models.Serie, error) {
+	var purged *models.Serie
+	err := r.atomic(func(db DBTX) error {
+		current, version, err := loadSerie(db, id)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.DeletedAt == nil {
+			return sql.ErrNoRows
+		}
+
+		if _, err := appendEvent(db, current, version, id, models.EventSeriePurged, nil); err != nil {
+			return err
+		}
+		purged = current
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+// PurgeTrashedBefore records a SeriePurged event for every series trashed before
+// the cutoff.
+func (r *eventSeriesRepository) PurgeTrashedBefore(cutoff time.Time) ([]models.Serie, error) {
+	purged := []models.Serie{}
+	err := r.atomic(func(db DBTX) error {
+		// Find the expired series in the projection
+		expired, err := (&seriesRepository{db: db}).scanSeries(`SELECT `+serieColumns+`, deleted_at
+            FROM series
+            WHERE deleted_at IS NOT NULL AND deleted_at < $1`, cutoff)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range expired {
+			_, version, err := loadSerie(db, s.ID)
+			if err != nil {
+				return err
+			}
+			if _, err := appendEvent(db, &s, version, s.ID, models.EventSeriePurged, nil); err != nil {
+				return err
+			}
+			purged = append(purged, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+// GetSerieEvents returns every event of a series in order.
+func (r *eventSeriesRepository) GetSerieEvents(id int) ([]models.SerieEvent, error) {
+	return queryEvents(r.db, `SELECT seq, serie_id, version, type, data, created_at
+            FROM serie_events
+            WHERE serie_id = $1
+            ORDER BY version`, id)
+}
+
+// GetSerieAt rebuilds a series from the events stored up to the given moment,
+// snapshots are skipped since they may be more recent than that.
+func (r *eventSeriesRepository) GetSerieAt(id int, at time.Time) (*models.Serie, error) {
+	events, err := queryEvents(r.db, `SELECT seq, serie_id, version, type, data, created_at
+            FROM serie_events
+            WHERE serie_id = $1 AND created_at <= $2
+            ORDER BY version`, id, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var serie *models.Serie
+	for _, event := range events {
+		if serie, err = applyEvent(serie, event); err != nil {
+			return nil, err
+		}
+	}
+	if serie == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	return serie, nil
+}
+
+// Bootstrap records a SerieCreated event for every projection row without events.
+func (r *eventSeriesRepository) Bootstrap() (int, error) {
+	var bootstrapped int
+	err := r.atomic(func(db DBTX) error {
+		var err error
+		bootstrapped, err = bootstrap(db)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return bootstrapped, nil
+}
+
+// Replay rebuilds the series projection and snapshots out of the event store.
+func (r *eventSeriesRepository) Replay() (int, error) {
+	var replayed int
+	err := r.atomic(func(db DBTX) error {
+		// Make sure no series is lost because it predates the event store
+		if _, err := bootstrap(db); err != nil {
+			return err
+		}
+
+		events, err := queryEvents(db, `SELECT seq, serie_id, version, type, data, created_at
+            FROM serie_events
+            ORDER BY seq`)
+		if err != nil {
+			return err
+		}
+
+		// Fold every series in memory
+		states := map[int]*models.Serie{}
+		versions := map[int]int{}
+		for _, event := range events {
+			state, err := applyEvent(states[event.SerieID], event)
+			if err != nil {
+				return fmt.Errorf("failed to apply event %d: %w", event.Seq, err)
+			}
+			states[event.SerieID] = state
+			versions[event.SerieID] = event.Version
+		}
+
+		// Rebuild the projection and snapshots from scratch
+		if _, err := db.Exec(`DELETE FROM serie_snapshots`); err != nil {
+			return err
+		}
+		if _, err := db.Exec(`DELETE FROM series`); err != nil {
+			return err
+		}
+		for id, state := range states {
+			if err := project(db, id, state); err != nil {
+				return err
+			}
+			if state != nil {
+				if err := saveSnapshot(db, state, versions[id]); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Keep the ID sequence ahead of every ID ever handed out
+		if _, err := db.Exec(`SELECT setval(pg_get_serial_sequence('series', 'id'),
+            GREATEST((SELECT MAX(serie_id) FROM serie_events), 1))`); err != nil {
+			return err
+		}
+
+		// Every series got new change numbers, clients have to start over like after
+		// a restore
+		if _, err := db.Exec(`DELETE FROM serie_tombstones`); err != nil {
+			return err
+		}
+		if _, err := db.Exec(`INSERT INTO sync_resets (seq) VALUES (` + nextChangeSeq + `)`); err != nil {
+			return err
+		}
+
+		replayed = len(events)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return replayed, nil
+}
+
+// bootstrap records a SerieCreated event for every projection row without events
+func bootstrap(db DBTX) (int, error) {
+	missing, err := (&seriesRepository{db: db}).scanSeries(`SELECT ` + serieColumns + `, deleted_at
+            FROM series s
+            WHERE NOT EXISTS (SELECT 1 FROM serie_events e WHERE e.serie_id = s.id)`)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range missing {
+		if _, err := appendEvent(db, nil, 0, s.ID, models.EventSerieCreated, s); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(missing), nil
+}
+
+// appendEvent stores the next event of a series, applies it to the current state and
+// writes the result to the projection, snapshotting every snapshotEvery events.
+// Returns the state after the event, nil once the series is purged.
+func appendEvent(db DBTX, current *models.Serie, version int, serieID int, eventType string, payload any) (*models.Serie, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store the event, the unique (serie_id, version) pair rejects concurrent appends
+	event := models.SerieEvent{
+		SerieID: serieID,
+		Version: version + 1,
+		Type:    eventType,
+		Data:    data,
+	}
+	query := `INSERT INTO serie_events (serie_id, version, type, data)
+            VALUES ($1, $2, $3, $4)
+            RETURNING seq, created_at`
+	if err := db.QueryRow(query, event.SerieID, event.Version, event.Type, []byte(event.Data)).Scan(&event.Seq, &event.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	next, err := applyEvent(current, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project(db, serieID, next); err != nil {
+		return nil, err
+	}
+
+	if next == nil {
+		// Purged series have nothing left to snapshot
+		if _, err := db.Exec(`DELETE FROM serie_snapshots WHERE serie_id = $1`, serieID); err != nil {
+			return nil, err
+		}
+	} else if event.Version%snapshotEvery == 0 {
+		if err := saveSnapshot(db, next, event.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	return next, nil
+}
+
+// applyEvent returns the state of a series after the given event, state is nil
+// before the series is created and after it's purged
+func applyEvent(state *models.Serie, event models.SerieEvent) (*models.Serie, error) {
+	if event.Type == models.EventSerieCreated {
+		var created models.Serie
+		if err := json.Unmarshal(event.Data, &created); err != nil {
+			return nil, err
+		}
+		created.ID = event.SerieID
+		return &created, nil
+	}
+
+	if state == nil {
+		return nil, fmt.Errorf("%s event for series %d before it was created", event.Type, event.SerieID)
+	}
+	next := *state
+
+	switch event.Type {
+	case models.EventSerieUpdated:
+		var updated models.Serie
+		if err := json.Unmarshal(event.Data, &updated); err != nil {
+			return nil, err
+		}
+		updated.ID = next.ID
+		updated.DeletedAt = next.DeletedAt
+		next = updated
+	case models.EventStatusChanged:
+		var status models.Status
+		if err := json.Unmarshal(event.Data, &status); err != nil {
+			return nil, err
+		}
+		next.Status = status.Status
+	case models.EventUpvoted:
+		next.Ranking += 1
+	case models.EventDownvoted:
+		next.Ranking -= 1
+	case models.EventEpisodeIncremented:
+		next.CurrentEpisode += 1
+	case models.EventSerieDeleted:
+		deletedAt := event.CreatedAt
+		next.DeletedAt = &deletedAt
+	case models.EventSerieRestored:
+		next.DeletedAt = nil
+	case models.EventSeriePurged:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown event type %q", event.Type)
+	}
+
+	return &next, nil
+}
+
+// updateEvent picks the event describing the change from current to updated, an
+// empty type means there's no change at all
+func updateEvent(current, updated models.Serie) (string, any) {
+	updated.ID = current.ID
+	updated.DeletedAt = current.DeletedAt
+
+	// Check the single field changes first, they make for a more useful history
+	changed := func(modify func(s *models.Serie)) bool {
+		expected := current
+		modify(&expected)
+		return expected.Equal(updated)
+	}
+	switch {
+	case current.Equal(updated):
+		return "", nil
+	case changed(func(s *models.Serie) { s.Ranking += 1 }):
+		return models.EventUpvoted, nil
+	case changed(func(s *models.Serie) { s.Ranking -= 1 }):
+		return models.EventDownvoted, nil
+	case changed(func(s *models.Serie) { s.CurrentEpisode += 1 }):
+		return models.EventEpisodeIncremented, nil
+	case changed(func(s *models.Serie) { s.Status = updated.Status }):
+		return models.EventStatusChanged, models.Status{Status: updated.Status}
+	default:
+		return models.EventSerieUpdated, updated
+	}
+}
+
+// loadSerie rebuilds the current state of a series from its latest snapshot and
+// the events after it, returning the state and its version. State is nil when the
+// series doesn't exist or was purged.
+func loadSerie(db DBTX, id int) (*models.Serie, int, error) {
+	var state *models.Serie
+	version := 0
+
+	// Start from the latest snapshot when there is one
+	var data []byte
+	err := db.QueryRow(`SELECT version, data FROM serie_snapshots WHERE serie_id = $1`, id).Scan(&version, &data)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, 0, err
+	default:
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Apply every event after it
+	events, err := queryEvents(db, `SELECT seq, serie_id, version, type, data, created_at
+            FROM serie_events
+            WHERE serie_id = $1 AND version > $2
+            ORDER BY version`, id, version)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, event := range events {
+		if state, err = applyEvent(state, event); err != nil {
+			return nil, 0, err
+		}
+		version = event.Version
+	}
+
+	return state, version, nil
+}
+
+// queryEvents runs a query returning event rows, all rows are read before returning
+// so the connection is free for the next statement
+func queryEvents(db DBTX, query string, args ...any) ([]models.SerieEvent, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.SerieEvent{}
+	for rows.Next() {
+		var e models.SerieEvent
+		var data []byte
+		if err := rows.Scan(&e.Seq, &e.SerieID, &e.Version, &e.Type, &data, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Data = data
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// project writes the state of a series to the series table, numbering the change,
+// removing its row and leaving a tombstone when the state is nil
+func project(db DBTX, id int, state *models.Serie) error {
+	if state == nil {
+		_, err := db.Exec(withTombstones(`DELETE FROM series WHERE id = $1
+            RETURNING `+serieColumns+`, deleted_at`), id)
+		return err
+	}
+
+	query := `WITH seq AS (SELECT ` + nextChangeSeq + ` AS n)
+            INSERT INTO series (id, title, ranking, status, current_episode, total_episodes, year, genres, deleted_at,
+                                created_seq, change_seq)
+            SELECT $1::integer, $2::varchar, $3::integer, $4::varchar, $5::integer, $6::integer, $7::integer,
+                   COALESCE($8::varchar[], '{}'), $9::timestamptz, n, n
+            FROM seq
+            ON CONFLICT (id) DO UPDATE
+            SET title = EXCLUDED.title, ranking = EXCLUDED.ranking, status = EXCLUDED.status,
+                current_episode = EXCLUDED.current_episode, total_episodes = EXCLUDED.total_episodes,
+                year = EXCLUDED.year, genres = EXCLUDED.genres, deleted_at = EXCLUDED.deleted_at,
+                change_seq = EXCLUDED.change_seq`
+	_, err := db.Exec(query, id, state.Title, state.Ranking, state.Status, state.CurrentEpisode, state.TotalEpisodes,
+		state.Year, pq.Array(state.Genres), state.DeletedAt)
+	return err
+}
+
+// saveSnapshot stores the state of a series at the given version, replacing the
+// previous snapshot
+func saveSnapshot(db DBTX, state *models.Serie, version int) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO serie_snapshots (serie_id, version, data)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (serie_id) DO UPDATE
+            SET version = EXCLUDED.version, data = EXCLUDED.data, created_at = NOW()`
+	_, err = db.Exec(query, state.ID, version, data)
+	return err
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FeedTokenRepository defines all the methods to be implemented for feed token data
+// access, tokens are only ever stored hashed
+type FeedTokenRepository interface {
+	// SetToken stores the token hash of an actor's feed, replacing the previous one,
+	// returning when it was stored
+	SetToken(actor, feed, hash string) (time.Time, error)
+	// DeleteToken deletes the token of an actor's feed
+	DeleteToken(actor, feed string) error
+	// FindActor returns the actor whose feed the token hash opens
+	FindActor(feed, hash string) (string, error)
+}
+
+// feedTokenRepository holds all the dependencies for the repository
+type feedTokenRepository struct {
+	db DBTX
+}
+
+// NewFeedTokenRepository creates a new FeedTokenRepository with the given DB connection
+func NewFeedTokenRepository(dbConn *sql.DB) FeedTokenRepository {
+	return &feedTokenRepository{
+		db: dbConn,
+	}
+}
+
+// SetToken upserts the token hash in a single statement.
+func (r *feedTokenRepository) SetToken(actor, feed, hash string) (time.Time, error) {
+	query := `INSERT INTO feed_tokens (actor, feed, token_hash)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (actor, feed) DO UPDATE
+              SET token_hash = EXCLUDED.token_hash, created_at = NOW()
+            RETURNING created_at`
+
+	var createdAt time.Time
+	err := r.db.QueryRow(query, actor, feed, hash).Scan(&createdAt)
+	return createdAt, err
+}
+
+// DeleteToken deletes a token, returns sql.ErrNoRows if the actor has none.
+func (r *feedTokenRepository) DeleteToken(actor, feed string) error {
+	result, err := r.db.Exec(`DELETE FROM feed_tokens WHERE actor = $1 AND feed = $2`, actor, feed)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// FindActor finds the owner of a token, returns sql.ErrNoRows if no token matches.
+func (r *feedTokenRepository) FindActor(feed, hash string) (string, error) {
+	var actor string
+	err := r.db.QueryRow(`SELECT actor FROM feed_tokens WHERE feed = $1 AND token_hash = $2`, feed, hash).Scan(&actor)
+	return actor, err
+}
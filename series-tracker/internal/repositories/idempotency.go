@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"series-tracker/internal/models"
+)
+
+// IdempotencyRepository defines all the methods to be implemented for idempotency key
+// data access
+type IdempotencyRepository interface {
+	// ClaimKey records a request in progress under the record's key, unless an
+	// unexpired record already holds it. claimed reports whether it was recorded,
+	// otherwise the existing record is returned.
+	ClaimKey(models.IdempotencyRecord) (existing *models.IdempotencyRecord, claimed bool, err error)
+	// CompleteKey stores the response of the request holding a key
+	CompleteKey(key string, status int, contentType string, body []byte) error
+	// DeleteKey releases a key
+	DeleteKey(key string) error
+	// DeleteExpiredKeys deletes every record expired at now, returning how many
+	DeleteExpiredKeys(now time.Time) (int64, error)
+}
+
+// idempotencyRepository holds all the dependencies for the repository
+type idempotencyRepository struct {
+	db DBTX
+}
+
+// NewIdempotencyRepository creates a new IdempotencyRepository with the given DB connection
+func NewIdempotencyRepository(dbConn *sql.DB) IdempotencyRepository {
+	return &idempotencyRepository{
+		db: dbConn,
+	}
+}
+
+// ClaimKey inserts the record, taking over expired ones, in a single statement so
+// concurrent requests with the same key can't both claim it.
+func (r *idempotencyRepository) ClaimKey(rec models.IdempotencyRecord) (*models.IdempotencyRecord, bool, error) {
+	claim := `INSERT INTO idempotency_keys (key, fingerprint, expires_at)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (key) DO UPDATE
+              SET fingerprint = EXCLUDED.fingerprint, status = 0, content_type = '', body = NULL,
+                  created_at = NOW(), expires_at = EXCLUDED.expires_at
+              WHERE idempotency_keys.expires_at <= NOW()
+            RETURNING key`
+	get := `SELECT key, fingerprint, status, content_type, body, created_at, expires_at
+            FROM idempotency_keys WHERE key = $1`
+
+	// The holding record may be released between both statements, claim again then
+	for {
+		var key string
+		err := r.db.QueryRow(claim, rec.Key, rec.Fingerprint, rec.ExpiresAt).Scan(&key)
+		if err == nil {
+			return nil, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, err
+		}
+
+		var existing models.IdempotencyRecord
+		err = r.db.QueryRow(get, rec.Key).
+			Scan(&existing.Key, &existing.Fingerprint, &existing.Status, &existing.ContentType, &existing.Body, &existing.CreatedAt, &existing.ExpiresAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return &existing, false, nil
+	}
+}
+
+// CompleteKey stores the response of the request holding a key.
+func (r *idempotencyRepository) CompleteKey(key string, status int, contentType string, body []byte) error {
+	query := `UPDATE idempotency_keys SET status = $1, content_type = $2, body = $3 WHERE key = $4`
+
+	_, err := r.db.Exec(query, status, contentType, body, key)
+	return err
+}
+
+// DeleteKey releases a key.
+func (r *idempotencyRepository) DeleteKey(key string) error {
+	_, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE key = $1`, key)
+	return err
+}
+
+// DeleteExpiredKeys deletes every record expired at now.
+func (r *idempotencyRepository) DeleteExpiredKeys(now time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import "series-tracker/internal/models"
+
+// NewNotifyingUnitOfWork wraps a UnitOfWork so every audit entry recorded in a
+// transaction is handed to notify once that transaction committed, in order.
+// Rolled back transactions notify nothing.
+func NewNotifyingUnitOfWork(uow UnitOfWork, notify func(entry models.AuditEntry)) UnitOfWork {
+	return &notifyingUnitOfWork{
+		uow:    uow,
+		notify: notify,
+	}
+}
+
+// notifyingUnitOfWork holds all the dependencies for the unit of work
+type notifyingUnitOfWork struct {
+	uow    UnitOfWork
+	notify func(entry models.AuditEntry)
+}
+
+// Do runs fn through the wrapped UnitOfWork, collecting the audit entries it
+// records and notifying them after commit
+func (u *notifyingUnitOfWork) Do(fn func(repos *Repositories) error) error {
+	collector := &collectingAuditRepository{}
+	err := u.uow.Do(func(repos *Repositories) error {
+		collector.AuditRepository = repos.Audit
+
+		collected := *repos
+		collected.Audit = collector
+		return fn(&collected)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range collector.entries {
+		u.notify(entry)
+	}
+	return nil
+}
+
+// collectingAuditRepository passes every call through to the transaction-bound
+// repository, keeping the entries it creates
+type collectingAuditRepository struct {
+	AuditRepository
+	entries []models.AuditEntry
+}
+
+// CreateAuditEntry creates the entry, keeping it once stored.
+func (r *collectingAuditRepository) CreateAuditEntry(e models.AuditEntry) (*models.AuditEntry, error) {
+	created, err := r.AuditRepository.CreateAuditEntry(e)
+	if err != nil {
+		return nil, err
+	}
+	r.entries = append(r.entries, *created)
+	return created, nil
+}
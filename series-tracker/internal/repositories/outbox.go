@@ -0,0 +1,52 @@
+package repositories
+
+import "series-tracker/internal/models"
+
+// NewOutboxUnitOfWork wraps a UnitOfWork so every audit entry recorded in a
+// transaction is handed to stage right away, along with the repositories bound to
+// that same transaction. Whatever stage writes commits or rolls back with the
+// entry, an error from it rolls the transaction back.
+func NewOutboxUnitOfWork(uow UnitOfWork, stage func(repos *Repositories, entry models.AuditEntry) error) UnitOfWork {
+	return &outboxUnitOfWork{
+		uow:   uow,
+		stage: stage,
+	}
+}
+
+// outboxUnitOfWork holds all the dependencies for the unit of work
+type outboxUnitOfWork struct {
+	uow   UnitOfWork
+	stage func(repos *Repositories, entry models.AuditEntry) error
+}
+
+// Do runs fn through the wrapped UnitOfWork, staging the audit entries it records
+// within its transaction
+func (u *outboxUnitOfWork) Do(fn func(repos *Repositories) error) error {
+	return u.uow.Do(func(repos *Repositories) error {
+		staged := *repos
+		staged.Audit = &stagingAuditRepository{
+			AuditRepository: repos.Audit,
+			stage:           func(entry models.AuditEntry) error { return u.stage(repos, entry) },
+		}
+		return fn(&staged)
+	})
+}
+
+// stagingAuditRepository passes every call through to the transaction-bound
+// repository, staging the entries it creates
+type stagingAuditRepository struct {
+	AuditRepository
+	stage func(entry models.AuditEntry) error
+}
+
+// CreateAuditEntry creates the entry, then stages it once stored.
+func (r *stagingAuditRepository) CreateAuditEntry(e models.AuditEntry) (*models.AuditEntry, error) {
+	created, err := r.AuditRepository.CreateAuditEntry(e)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.stage(*created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
@@ -3,27 +3,77 @@ package repositories
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
 	"series-tracker/internal/models"
+
+	"github.com/lib/pq"
 )
 
+// ErrConflict is returned when a write collides with a unique constraint, such as
+// a title that's already taken
+var ErrConflict = errors.New("conflict")
+
+// uniqueViolation is the SQLSTATE of unique constraint violations
+const uniqueViolation = "23505"
+
+// conflictError turns a unique constraint violation into ErrConflict, other errors
+// are returned as is
+func conflictError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return fmt.Errorf("%w: %s", ErrConflict, pqErr.Detail)
+	}
+	return err
+}
+
 // SeriesRepository defines all the methods to be implemented for series data access
 type SeriesRepository interface {
 	// GetAllSeries returns a list of all series from the database
 	GetAllSeries() ([]models.Serie, error)
-	// CreateNewSerie inserts a new series into the database
+	// CreateNewSerie inserts a new series into the database, ErrConflict if its
+	// title is taken
 	CreateNewSerie(models.Serie) (*models.Serie, error)
 	// GetSerieByID finds a series by its ID in the database
 	GetSerieByID(id int) (*models.Serie, error)
-	// UpdateSerie updates a series with all values detailed in a Serie struct based on its ID
+	// GetSerieByIDForUpdate finds a series by its ID and locks its row until the
+	// surrounding transaction ends, only meaningful inside a UnitOfWork
+	GetSerieByIDForUpdate(id int) (*models.Serie, error)
+	// UpdateSerie updates a series with all values detailed in a Serie struct based on
+	// its ID, sql.ErrNoRows if there's no such series outside the trash & ErrConflict
+	// if its new title is taken
 	UpdateSerie(models.Serie) (*models.Serie, error)
-	// DeleteSerie deletes a series by its ID
+	// DeleteSerie moves a series to the trash by setting its deletion timestamp,
+	// sql.ErrNoRows if there's no such series outside the trash
 	DeleteSerie(id int) error
+	// GetTrashedSeries returns a list of all series currently in the trash
+	GetTrashedSeries() ([]models.Serie, error)
+	// RestoreSerie takes a series out of the trash by its ID, sql.ErrNoRows if it
+	// isn't in the trash
+	RestoreSerie(id int) (*models.Serie, error)
+	// PurgeSerie permanently deletes a trashed series by its ID, returning the removed
+	// series, sql.ErrNoRows if it isn't in the trash
+	PurgeSerie(id int) (*models.Serie, error)
+	// PurgeTrashedBefore permanently deletes all series trashed before the given time,
+	// returning the removed series
+	PurgeTrashedBefore(cutoff time.Time) ([]models.Serie, error)
+	// GetChangesSince returns the series changed after the given change sequence
+	// number along with the latest one. Every live series is returned as created,
+	// with Reset set, when since is 0 or can't be synced from anymore.
+	GetChangesSince(since int64) (*models.SyncChanges, error)
+	// IsSyncable tells whether changes can be synced from the given change sequence,
+	// false when GetChangesSince would start the client over
+	IsSyncable(since int64) (bool, error)
+	// GetChangeSeq returns the sequence number of the latest change of a series,
+	// trashed & purged ones included
+	GetChangeSeq(id int) (int64, error)
 }
 
-// seriesRepository holds all the dependencies for the repository
+// seriesRepository holds all the dependencies for the repository, db is either
+// the shared *sql.DB or a *sql.Tx when running inside a UnitOfWork
 type seriesRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
 // NewSeriesRepository creates a new SeriesRepository with the given DB connection
@@ -33,9 +83,39 @@ func NewSeriesRepository(dbConn *sql.DB) SeriesRepository {
 	}
 }
 
-// DeleteSerie deletes a series by its ID.
+// serieColumns are the columns scanned into a series by serieFields, in order
+const serieColumns = `id, title, ranking, status, current_episode, total_episodes, year, genres`
+
+// serieFields returns the scan destinations of serieColumns in s, followed by extra
+// ones for the columns selected after them
+func serieFields(s *models.Serie, extra ...any) []any {
+	return append([]any{&s.ID, &s.Title, &s.Ranking, &s.Status, &s.CurrentEpisode, &s.TotalEpisodes, &s.Year, pq.Array(&s.Genres)}, extra...)
+}
+
+// nextChangeSeq is the SQL expression numbering every write to a series, rows keep
+// the number of their latest change in change_seq. The first number a transaction
+// takes is held until it ends, see sync_horizon() in init.sql.
+const nextChangeSeq = `next_change_seq()`
+
+// withTombstones wraps a DELETE statement returning the removed series rows so each
+// of them leaves a tombstone numbered like any other change, the statement returns
+// the same rows
+func withTombstones(deleteQuery string) string {
+	return `WITH purged AS (` + deleteQuery + `),
+            tombstones AS (
+              INSERT INTO serie_tombstones (serie_id, change_seq, deleted_at)
+              SELECT id, ` + nextChangeSeq + `, COALESCE(deleted_at, NOW()) FROM purged
+              ON CONFLICT (serie_id) DO UPDATE
+              SET change_seq = EXCLUDED.change_seq, deleted_at = EXCLUDED.deleted_at
+            )
+            SELECT ` + serieColumns + `, deleted_at FROM purged`
+}
+
+// DeleteSerie moves a series to the trash by setting its deletion timestamp, returns
+// sql.ErrNoRows if there's no such series outside the trash.
 func (r *seriesRepository) DeleteSerie(id int) error {
-	query := `DELETE FROM series WHERE id = $1`
+	query := `UPDATE series SET deleted_at = NOW(), change_seq = ` + nextChangeSeq + `
+            WHERE id = $1 AND deleted_at IS NULL`
 	result, err := r.db.Exec(query, id)
 	if err != nil {
 		return err
@@ -46,7 +126,7 @@ func (r *seriesRepository) DeleteSerie(id int) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return errors.New("mmgvo")
+		return sql.ErrNoRows
 	}
 
 	return nil
@@ -57,8 +137,8 @@ func (r *seriesRepository) GetAllSeries() ([]models.Serie, error) {
 	// Create return slice
 	series := []models.Serie{}
 
-	// Query the DB
-	rows, err := r.db.Query("SELECT id, title, ranking, status, current_episode, total_episodes FROM series")
+	// Query the DB, trashed series are left out
+	rows, err := r.db.Query("SELECT " + serieColumns + " FROM series WHERE deleted_at IS NULL")
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +147,7 @@ func (r *seriesRepository) GetAllSeries() ([]models.Serie, error) {
 	// Scan results into Serie & append to Series slice
 	for rows.Next() {
 		var s models.Serie
-		if err := rows.Scan(&s.ID, &s.Title, &s.Ranking, &s.Status, &s.CurrentEpisode, &s.TotalEpisodes); err != nil {
+		if err := rows.Scan(serieFields(&s)...); err != nil {
 			return nil, err
 		}
 		series = append(series, s)
@@ -76,64 +156,158 @@ func (r *seriesRepository) GetAllSeries() ([]models.Serie, error) {
 	return series, nil
 }
 
-// CreateNewSeries inserts a new series into the database.
-func (r *seriesRepository) CreateNewSerie(s models.Serie) (*models.Serie, error) {
-	// Build query
-	query := `INSERT INTO series (title, ranking, status, current_episode, total_episodes)
-            VALUES ($1, $2, $3, $4, $5)`
+// GetTrashedSeries returns a list of all series currently in the trash, most
+// recently deleted first.
+func (r *seriesRepository) GetTrashedSeries() ([]models.Serie, error) {
+	query := `SELECT ` + serieColumns + `, deleted_at
+            FROM series
+            WHERE deleted_at IS NOT NULL
+            ORDER BY deleted_at DESC`
 
-	// Execute the query
-	result, err := r.db.Exec(query, s.Title, s.Ranking, s.Status, s.CurrentEpisode, s.TotalEpisodes)
+	return r.scanSeries(query)
+}
+
+// RestoreSerie takes a series out of the trash by its ID, returns sql.ErrNoRows if
+// it isn't in the trash.
+func (r *seriesRepository) RestoreSerie(id int) (*models.Serie, error) {
+	query := `UPDATE series SET deleted_at = NULL, change_seq = ` + nextChangeSeq + `
+            WHERE id = $1 AND deleted_at IS NOT NULL`
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return r.GetSerieByID(id)
+}
+
+// PurgeSerie permanently deletes a trashed series by its ID, series that aren't in
+// the trash are left untouched & sql.ErrNoRows returned. A tombstone is left behind.
+func (r *seriesRepository) PurgeSerie(id int) (*models.Serie, error) {
+	query := withTombstones(`DELETE FROM series WHERE id = $1 AND deleted_at IS NOT NULL
+            RETURNING ` + serieColumns + `, deleted_at`)
+
+	purged, err := r.scanSeries(query, id)
 	if err != nil {
 		return nil, err
 	}
+	if len(purged) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &purged[0], nil
+}
+
+// PurgeTrashedBefore permanently deletes all series trashed before the cutoff, each
+// leaving a tombstone behind.
+func (r *seriesRepository) PurgeTrashedBefore(cutoff time.Time) ([]models.Serie, error) {
+	query := withTombstones(`DELETE FROM series WHERE deleted_at IS NOT NULL AND deleted_at < $1
+            RETURNING ` + serieColumns + `, deleted_at`)
+
+	return r.scanSeries(query, cutoff)
+}
+
+// scanSeries runs a query returning series rows, deletion timestamp included
+func (r *seriesRepository) scanSeries(query string, args ...any) ([]models.Serie, error) {
+	// Create return slice
+	series := []models.Serie{}
 
-	// Update input struct's ID to match the DB
-	id, err := result.LastInsertId()
+	// Query the DB
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
-	s.ID = int(id)
+	defer rows.Close()
+
+	// Scan results into Serie & append to Series slice
+	for rows.Next() {
+		var s models.Serie
+		var deletedAt sql.NullTime
+		if err := rows.Scan(serieFields(&s, &deletedAt)...); err != nil {
+			return nil, err
+		}
+		if deletedAt.Valid {
+			s.DeletedAt = &deletedAt.Time
+		}
+		series = append(series, s)
+	}
+
+	return series, rows.Err()
+}
+
+// CreateNewSeries inserts a new series into the database.
+func (r *seriesRepository) CreateNewSerie(s models.Serie) (*models.Serie, error) {
+	// Build query, lib/pq doesn't support LastInsertId so the ID comes back
+	// through RETURNING. The creation is numbered like any other change.
+	query := `WITH seq AS (SELECT ` + nextChangeSeq + ` AS n)
+            INSERT INTO series (title, ranking, status, current_episode, total_episodes, year, genres, created_seq, change_seq)
+            SELECT $1::varchar, $2::integer, $3::varchar, $4::integer, $5::integer, $6::integer, COALESCE($7::varchar[], '{}'), n, n
+            FROM seq
+            RETURNING id`
+
+	// Execute the query & update input struct's ID to match the DB
+	if err := r.db.QueryRow(query, s.Title, s.Ranking, s.Status, s.CurrentEpisode, s.TotalEpisodes, s.Year, pq.Array(s.Genres)).Scan(&s.ID); err != nil {
+		return nil, conflictError(err)
+	}
 
 	return &s, nil
 }
 
 // GetSerieByID finds a Serie by its ID in the database.
 func (r *seriesRepository) GetSerieByID(id int) (*models.Serie, error) {
-	// Create series struct for response
-	var serie models.Serie
+	// Build the query
+	query := `SELECT ` + serieColumns + `
+            FROM series
+            WHERE id = $1 AND deleted_at IS NULL`
 
+	return r.getSerie(query, id)
+}
+
+// GetSerieByIDForUpdate finds a Serie by its ID in the database, locking the row
+// with SELECT ... FOR UPDATE so concurrent read-modify-write flows are serialized.
+func (r *seriesRepository) GetSerieByIDForUpdate(id int) (*models.Serie, error) {
 	// Build the query
-	query := `SELECT id, title, ranking, status, current_episode, total_episodes
+	query := `SELECT ` + serieColumns + `
             FROM series
-            WHERE id = $1`
+            WHERE id = $1 AND deleted_at IS NULL
+            FOR UPDATE`
+
+	return r.getSerie(query, id)
+}
+
+// getSerie runs a query expected to return a single series row and scans it
+func (r *seriesRepository) getSerie(query string, args ...any) (*models.Serie, error) {
+	// Create series struct for response
+	var serie models.Serie
 
 	// Execute the query & scan into Serie struct
-	if err := r.db.QueryRow(query, id).Scan(
-		&serie.ID,
-		&serie.Title,
-		&serie.Ranking,
-		&serie.Status,
-		&serie.CurrentEpisode,
-		&serie.TotalEpisodes,
-	); err != nil {
+	if err := r.db.QueryRow(query, args...).Scan(serieFields(&serie)...); err != nil {
 		return nil, err
 	}
 
 	return &serie, nil
 }
 
-// UpdateSerie updates a serie with all values detailed in a Serie struct based on its ID
+// UpdateSerie updates a serie with all values detailed in a Serie struct based on its ID,
+// returns sql.ErrNoRows if there's no such series outside the trash
 func (r *seriesRepository) UpdateSerie(s models.Serie) (*models.Serie, error) {
 	// Build the query
 	query := `UPDATE series 
-            SET title = $1, ranking = $2, status = $3, current_episode = $4, total_episodes = $5
-            WHERE id = $6`
+            SET title = $1, ranking = $2, status = $3, current_episode = $4, total_episodes = $5,
+                year = $6, genres = COALESCE($7::varchar[], '{}'), change_seq = ` + nextChangeSeq + `
+            WHERE id = $8 AND deleted_at IS NULL`
 
 	// Execute the query
-	result, err := r.db.Exec(query, s.Title, s.Ranking, s.Status, s.CurrentEpisode, s.TotalEpisodes, s.ID)
+	result, err := r.db.Exec(query, s.Title, s.Ranking, s.Status, s.CurrentEpisode, s.TotalEpisodes, s.Year, pq.Array(s.Genres), s.ID)
 	if err != nil {
-		return nil, err
+		return nil, conflictError(err)
 	}
 
 	// Check rows affected to see if update was successful
@@ -142,8 +316,131 @@ func (r *seriesRepository) UpdateSerie(s models.Serie) (*models.Serie, error) {
 		return nil, err
 	}
 	if rowsAffected == 0 {
-		return nil, errors.New("aaa")
+		return nil, sql.ErrNoRows
 	}
 
 	return &s, nil
 }
+
+// syncState returns the latest change number handed out and the one of the latest
+// restore, tokens outside of them can't be synced from
+func syncState(db DBTX) (last, reset int64, err error) {
+	err = db.QueryRow(`SELECT CASE WHEN is_called THEN last_value ELSE 0 END, (SELECT COALESCE(MAX(seq), 0) FROM sync_resets)
+            FROM series_change_seq`).Scan(&last, &reset)
+	return last, reset, err
+}
+
+// IsSyncable tells whether changes can be synced from the given token, only reading
+// the sequence and the latest reset.
+func (r *seriesRepository) IsSyncable(since int64) (bool, error) {
+	last, reset, err := syncState(r.db)
+	if err != nil {
+		return false, err
+	}
+	// Changes from before the latest restore, or numbers never handed out, can't be
+	// synced from, the client starts over
+	return since > 0 && since >= reset && since <= last, nil
+}
+
+// GetChangesSince reads the changes up to the sync horizon: every change numbered
+// below it is committed or rolled back, so none can show up later behind the
+// returned sequence number while writes carry on.
+func (r *seriesRepository) GetChangesSince(since int64) (*models.SyncChanges, error) {
+	changes := &models.SyncChanges{Created: []models.Serie{}, Updated: []models.Serie{}, Deleted: []models.SerieTombstone{}}
+	err := inTx(r.db, func(db DBTX) error {
+		if err := db.QueryRow(`SELECT sync_horizon()`).Scan(&changes.Seq); err != nil {
+			return err
+		}
+		syncable, err := (&seriesRepository{db: db}).IsSyncable(since)
+		if err != nil {
+			return err
+		}
+
+		// Series created past the horizon come with the next changes instead
+		if !syncable {
+			changes.Reset = true
+			rows, err := db.Query(`SELECT `+serieColumns+` FROM series
+                WHERE deleted_at IS NULL AND COALESCE(created_seq, 0) <= $1`, changes.Seq)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				var s models.Serie
+				if err := rows.Scan(serieFields(&s)...); err != nil {
+					return err
+				}
+				changes.Created = append(changes.Created, s)
+			}
+			return rows.Err()
+		}
+
+		rows, err := db.Query(`SELECT `+serieColumns+`, deleted_at, COALESCE(created_seq > $1, FALSE)
+            FROM series
+            WHERE change_seq > $1 AND change_seq <= $2
+            ORDER BY change_seq`, since, changes.Seq)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var s models.Serie
+			var deletedAt sql.NullTime
+			var created bool
+			if err := rows.Scan(serieFields(&s, &deletedAt, &created)...); err != nil {
+				return err
+			}
+			switch {
+			case deletedAt.Valid:
+				changes.Deleted = append(changes.Deleted, models.SerieTombstone{ID: s.ID, DeletedAt: deletedAt.Time})
+			case created:
+				changes.Created = append(changes.Created, s)
+			default:
+				changes.Updated = append(changes.Updated, s)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		tombstones, err := db.Query(`SELECT serie_id, deleted_at FROM serie_tombstones
+            WHERE change_seq > $1 AND change_seq <= $2
+            ORDER BY change_seq`, since, changes.Seq)
+		if err != nil {
+			return err
+		}
+		defer tombstones.Close()
+
+		for tombstones.Next() {
+			tombstone := models.SerieTombstone{Purged: true}
+			if err := tombstones.Scan(&tombstone.ID, &tombstone.DeletedAt); err != nil {
+				return err
+			}
+			changes.Deleted = append(changes.Deleted, tombstone)
+		}
+		return tombstones.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// GetChangeSeq finds the latest change of a series or its tombstone, returns
+// sql.ErrNoRows if there's neither. Series restored from a backup without change
+// numbers are at 0.
+func (r *seriesRepository) GetChangeSeq(id int) (int64, error) {
+	query := `SELECT change_seq FROM series WHERE id = $1
+            UNION ALL
+            SELECT change_seq FROM serie_tombstones WHERE serie_id = $1
+            LIMIT 1`
+
+	var seq sql.NullInt64
+	if err := r.db.QueryRow(query, id).Scan(&seq); err != nil {
+		return 0, err
+	}
+	return seq.Int64, nil
+}
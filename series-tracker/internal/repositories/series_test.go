@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestConflictError(t *testing.T) {
+	taken := &pq.Error{Code: uniqueViolation, Detail: "Key (title)=(Dark) already exists."}
+	if err := conflictError(taken); !errors.Is(err, ErrConflict) || err.Error() != "conflict: Key (title)=(Dark) already exists." {
+		t.Errorf("conflictError(unique violation) = %v", err)
+	}
+
+	for _, err := range []error{sql.ErrNoRows, &pq.Error{Code: "23503"}} {
+		if got := conflictError(err); got != err {
+			t.Errorf("conflictError(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
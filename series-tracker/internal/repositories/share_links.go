@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"series-tracker/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ShareLinkRepository defines all the methods to be implemented for share link data
+// access, tokens are only ever stored hashed
+type ShareLinkRepository interface {
+	// CreateShareLink inserts a new share link of an actor opened by the token hash
+	CreateShareLink(actor, hash string, link models.ShareLink) (*models.ShareLink, error)
+	// GetShareLinks returns the share links of an actor, newest first
+	GetShareLinks(actor string) ([]models.ShareLink, error)
+	// DeleteShareLink deletes a share link of an actor by its ID
+	DeleteShareLink(actor string, id int) error
+	// FindShareLink finds the share link the token hash opens
+	FindShareLink(hash string) (*models.ShareLink, error)
+}
+
+// shareLinkRepository holds all the dependencies for the repository
+type shareLinkRepository struct {
+	db DBTX
+}
+
+// NewShareLinkRepository creates a new ShareLinkRepository with the given DB connection
+func NewShareLinkRepository(dbConn *sql.DB) ShareLinkRepository {
+	return &shareLinkRepository{
+		db: dbConn,
+	}
+}
+
+// CreateShareLink inserts a link, ID and creation time are filled in by the database.
+func (r *shareLinkRepository) CreateShareLink(actor, hash string, l models.ShareLink) (*models.ShareLink, error) {
+	query := `INSERT INTO share_links (actor, token_hash, statuses, hide_rankings, expires_at)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id, created_at`
+
+	err := r.db.QueryRow(query, actor, hash, pq.Array(l.Statuses), l.HideRankings, l.ExpiresAt).
+		Scan(&l.ID, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+// GetShareLinks returns the links of an actor ordered by descending ID.
+func (r *shareLinkRepository) GetShareLinks(actor string) ([]models.ShareLink, error) {
+	rows, err := r.db.Query(`SELECT id, statuses, hide_rankings, expires_at, created_at
+            FROM share_links WHERE actor = $1 ORDER BY id DESC`, actor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []models.ShareLink{}
+	for rows.Next() {
+		var l models.ShareLink
+		if err := rows.Scan(&l.ID, pq.Array(&l.Statuses), &l.HideRankings, &l.ExpiresAt, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+
+	return links, rows.Err()
+}
+
+// DeleteShareLink deletes a link, returns sql.ErrNoRows if the actor has no such link.
+func (r *shareLinkRepository) DeleteShareLink(actor string, id int) error {
+	result, err := r.db.Exec(`DELETE FROM share_links WHERE actor = $1 AND id = $2`, actor, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// FindShareLink finds a link, returns sql.ErrNoRows if no link matches. Expired links
+// are returned all the same.
+func (r *shareLinkRepository) FindShareLink(hash string) (*models.ShareLink, error) {
+	var l models.ShareLink
+	err := r.db.QueryRow(`SELECT id, statuses, hide_rankings, expires_at, created_at
+            FROM share_links WHERE token_hash = $1`, hash).
+		Scan(&l.ID, pq.Array(&l.Statuses), &l.HideRankings, &l.ExpiresAt, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// DBTX is the subset of methods shared by *sql.DB and *sql.Tx, letting repositories
+// run the same queries either directly or inside a transaction
+type DBTX interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// Repositories groups the repositories handed to a UnitOfWork callback, all of
+// them bound to the same transaction
+type Repositories struct {
+	Series   SeriesRepository
+	Audit    AuditRepository
+	Webhooks WebhookRepository
+}
+
+// UnitOfWork defines a way to run several repository calls as a single transaction
+type UnitOfWork interface {
+	// Do runs fn inside a transaction, committing if fn returns nil and rolling
+	// back otherwise
+	Do(fn func(repos *Repositories) error) error
+}
+
+// unitOfWork holds all the dependencies for the unit of work, newSeries binds the
+// configured series storage to each transaction
+type unitOfWork struct {
+	db        *sql.DB
+	newSeries func(db DBTX) SeriesRepository
+}
+
+// NewUnitOfWork creates a new UnitOfWork with the given DB connection
+func NewUnitOfWork(dbConn *sql.DB) UnitOfWork {
+	return &unitOfWork{
+		db:        dbConn,
+		newSeries: func(db DBTX) SeriesRepository { return &seriesRepository{db: db} },
+	}
+}
+
+// NewEventSourcedUnitOfWork creates a new UnitOfWork with the given DB connection
+// whose series repository is backed by the event store
+func NewEventSourcedUnitOfWork(dbConn *sql.DB) UnitOfWork {
+	return &unitOfWork{
+		db:        dbConn,
+		newSeries: func(db DBTX) SeriesRepository { return &eventSeriesRepository{db: db} },
+	}
+}
+
+// Do runs fn inside a transaction, committing if fn returns nil and rolling back
+// otherwise.
+func (u *unitOfWork) Do(fn func(repos *Repositories) error) error {
+	return withTx(u.db, func(tx *sql.Tx) error {
+		// Bind every repository to the transaction
+		return fn(&Repositories{
+			Series:   u.newSeries(tx),
+			Audit:    &auditRepository{db: tx},
+			Webhooks: &webhookRepository{db: tx},
+		})
+	})
+}
+
+// inTx runs fn inside a transaction, reusing db when it already is one
+func inTx(db DBTX, fn func(db DBTX) error) error {
+	dbConn, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+	return withTx(dbConn, func(tx *sql.Tx) error {
+		return fn(tx)
+	})
+}
+
+// withTx runs fn inside a transaction, committing if fn returns nil and rolling
+// back otherwise. Panics inside fn also roll back before being re-raised.
+func withTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Make sure the transaction never outlives a panic
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"series-tracker/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// WebhookRepository defines all the methods to be implemented for webhook data access
+type WebhookRepository interface {
+	// CreateWebhook inserts a new webhook into the database
+	CreateWebhook(models.Webhook) (*models.Webhook, error)
+	// GetWebhooks returns every webhook, secrets included
+	GetWebhooks() ([]models.Webhook, error)
+	// GetWebhookByID finds a webhook by its ID, secret included
+	GetWebhookByID(id int) (*models.Webhook, error)
+	// DeleteWebhook permanently deletes a webhook & its deliveries by its ID
+	DeleteWebhook(id int) error
+	// EnqueueDeliveries queues a delivery of the event for every webhook
+	// subscribed to it, due right away, returning how many were queued
+	EnqueueDeliveries(event string, payload []byte) (int64, error)
+	// CreateDelivery inserts a new delivery, due right away
+	CreateDelivery(models.WebhookDelivery) (*models.WebhookDelivery, error)
+	// GetDeliveryByID finds a delivery by its ID
+	GetDeliveryByID(id int) (*models.WebhookDelivery, error)
+	// GetDeliveries returns the latest deliveries to a webhook, most recent first
+	GetDeliveries(webhookID, limit int) ([]models.WebhookDelivery, error)
+	// ClaimDueDeliveries returns up to limit pending deliveries due at now, pushing
+	// their next attempt to leaseUntil so they aren't claimed twice
+	ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error)
+	// UpdateDelivery stores the outcome of an attempt
+	UpdateDelivery(models.WebhookDelivery) error
+}
+
+// webhookRepository holds all the dependencies for the repository
+type webhookRepository struct {
+	db DBTX
+}
+
+// NewWebhookRepository creates a new WebhookRepository with the given DB connection
+func NewWebhookRepository(dbConn *sql.DB) WebhookRepository {
+	return &webhookRepository{
+		db: dbConn,
+	}
+}
+
+// deliveryColumns are the columns scanned by scanDeliveries, in order
+const deliveryColumns = `id, webhook_id, event, payload, status, attempts, response_code, error, replay_of,
+            created_at, last_attempt_at, next_attempt_at`
+
+// CreateWebhook inserts a new webhook, ID and creation time are filled in by the database.
+func (r *webhookRepository) CreateWebhook(w models.Webhook) (*models.Webhook, error) {
+	query := `INSERT INTO webhooks (url, secret, events)
+            VALUES ($1, $2, $3)
+            RETURNING id, created_at`
+
+	if err := r.db.QueryRow(query, w.URL, w.Secret, pq.Array(w.Events)).Scan(&w.ID, &w.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// GetWebhooks returns every webhook ordered by ID.
+func (r *webhookRepository) GetWebhooks() ([]models.Webhook, error) {
+	rows, err := r.db.Query(`SELECT id, url, secret, events, created_at FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		var w models.Webhook
+		if err := rows.Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Events), &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// GetWebhookByID finds a webhook by its ID, returns sql.ErrNoRows if it doesn't exist.
+func (r *webhookRepository) GetWebhookByID(id int) (*models.Webhook, error) {
+	var w models.Webhook
+	err := r.db.QueryRow(`SELECT id, url, secret, events, created_at FROM webhooks WHERE id = $1`, id).
+		Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Events), &w.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// DeleteWebhook deletes a webhook, returns sql.ErrNoRows if it doesn't exist.
+func (r *webhookRepository) DeleteWebhook(id int) error {
+	result, err := r.db.Exec(`DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// EnqueueDeliveries queues the event for every matching webhook in a single statement.
+func (r *webhookRepository) EnqueueDeliveries(event string, payload []byte) (int64, error) {
+	query := `INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at)
+            SELECT id, $1::varchar, $2::jsonb, NOW() FROM webhooks
+            WHERE $1 = ANY(events) OR '*' = ANY(events)`
+
+	result, err := r.db.Exec(query, event, payload)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// CreateDelivery inserts a new pending delivery, ID and creation time are filled in
+// by the database.
+func (r *webhookRepository) CreateDelivery(d models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	query := `INSERT INTO webhook_deliveries (webhook_id, event, payload, replay_of, next_attempt_at)
+            VALUES ($1, $2, $3, $4, NOW())
+            RETURNING ` + deliveryColumns
+
+	deliveries, err := r.scanDeliveries(query, d.WebhookID, d.Event, []byte(d.Payload), d.ReplayOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deliveries[0], nil
+}
+
+// GetDeliveryByID finds a delivery by its ID, returns sql.ErrNoRows if it doesn't exist.
+func (r *webhookRepository) GetDeliveryByID(id int) (*models.WebhookDelivery, error) {
+	deliveries, err := r.scanDeliveries(`SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &deliveries[0], nil
+}
+
+// GetDeliveries returns the latest deliveries to a webhook, most recent first.
+func (r *webhookRepository) GetDeliveries(webhookID, limit int) ([]models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+            WHERE webhook_id = $1
+            ORDER BY created_at DESC, id DESC
+            LIMIT $2`
+
+	return r.scanDeliveries(query, webhookID, limit)
+}
+
+// ClaimDueDeliveries leases due deliveries in a single statement, skipping rows another
+// instance is claiming at the same time.
+func (r *webhookRepository) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	query := `UPDATE webhook_deliveries SET next_attempt_at = $2
+            WHERE id IN (
+              SELECT id FROM webhook_deliveries
+              WHERE status = 'pending' AND next_attempt_at <= $1
+              ORDER BY next_attempt_at
+              LIMIT $3
+              FOR UPDATE SKIP LOCKED
+            )
+            RETURNING ` + deliveryColumns
+
+	return r.scanDeliveries(query, now, leaseUntil, limit)
+}
+
+// UpdateDelivery stores the status, attempt count & outcome of the latest attempt.
+func (r *webhookRepository) UpdateDelivery(d models.WebhookDelivery) error {
+	query := `UPDATE webhook_deliveries
+            SET status = $1, attempts = $2, response_code = $3, error = $4, last_attempt_at = $5, next_attempt_at = $6
+            WHERE id = $7`
+
+	_, err := r.db.Exec(query, d.Status, d.Attempts, d.ResponseCode, d.Error, d.LastAttemptAt, d.NextAttemptAt, d.ID)
+	return err
+}
+
+// scanDeliveries runs a query returning delivery rows
+func (r *webhookRepository) scanDeliveries(query string, args ...any) ([]models.WebhookDelivery, error) {
+	// Query the DB
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Scan results into WebhookDelivery, nullable columns go through sql.Null types
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var payload []byte
+		var responseCode, replayOf sql.NullInt64
+		var lastAttemptAt, nextAttemptAt sql.NullTime
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &payload, &d.Status, &d.Attempts, &responseCode, &d.Error,
+			&replayOf, &d.CreatedAt, &lastAttemptAt, &nextAttemptAt); err != nil {
+			return nil, err
+		}
+		d.Payload = payload
+		if responseCode.Valid {
+			code := int(responseCode.Int64)
+			d.ResponseCode = &code
+		}
+		if replayOf.Valid {
+			id := int(replayOf.Int64)
+			d.ReplayOf = &id
+		}
+		if lastAttemptAt.Valid {
+			d.LastAttemptAt = &lastAttemptAt.Time
+		}
+		if nextAttemptAt.Valid {
+			d.NextAttemptAt = &nextAttemptAt.Time
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
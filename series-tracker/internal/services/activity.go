@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"series-tracker/internal/models"
+)
+
+// Activity kinds
+const (
+	ActivityAdded     = "added"     // A series was created
+	ActivityCompleted = "completed" // A series was moved to Completed
+	ActivityRanked    = "ranked"    // The ranking of a series changed
+)
+
+// Set of valid activity kinds
+var validActivityKinds = map[string]bool{
+	ActivityAdded:     true,
+	ActivityCompleted: true,
+	ActivityRanked:    true,
+}
+
+// Number of activities returned when no limit is given & at most
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 200
+)
+
+// ActivityService defines all the methods to be implemented for reading the
+// activity of actors
+type ActivityService interface {
+	// GetActivity returns the latest activities of an actor matching the filter,
+	// most recent first
+	GetActivity(ctx context.Context, actor string, filter models.ActivityFilter) ([]models.Activity, error)
+}
+
+// activityService holds all the dependencies for the service
+type activityService struct {
+	audit AuditService
+}
+
+// NewActivityService returns an activityService with the given dependencies
+func NewActivityService(audit AuditService) ActivityService {
+	return &activityService{
+		audit: audit,
+	}
+}
+
+// GetActivity derives activities from the latest audit entries of an actor, the
+// most recent of which are looked through
+func (s *activityService) GetActivity(ctx context.Context, actor string, filter models.ActivityFilter) ([]models.Activity, error) {
+	statuses := map[string]bool{}
+	for _, status := range filter.Statuses {
+		if !IsValidStatus(status) {
+			return nil, fmt.Errorf("%w: invalid status %q", ErrInvalidInput, status)
+		}
+		statuses[status] = true
+	}
+	kinds := map[string]bool{}
+	for _, kind := range filter.Kinds {
+		if !validActivityKinds[kind] {
+			return nil, fmt.Errorf("%w: unknown activity kind %q", ErrInvalidInput, kind)
+		}
+		kinds[kind] = true
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultActivityLimit
+	}
+	filter.Limit = min(filter.Limit, maxActivityLimit)
+
+	entries, err := s.audit.GetAuditEntries(models.AuditFilter{Actor: actor})
+	if err != nil {
+		return nil, err
+	}
+
+	activities := []models.Activity{}
+	for _, entry := range entries {
+		for _, activity := range entryActivities(entry) {
+			if len(statuses) > 0 && !statuses[activity.Serie.Status] {
+				continue
+			}
+			if len(kinds) > 0 && !kinds[activity.Kind] {
+				continue
+			}
+			activities = append(activities, activity)
+			if len(activities) == filter.Limit {
+				return activities, nil
+			}
+		}
+	}
+	return activities, nil
+}
+
+// entryActivities returns the activities an audit entry stands for, none when it
+// doesn't leave a live series behind
+func entryActivities(entry models.AuditEntry) []models.Activity {
+	if entry.After == nil || entry.Action == ActionDelete || entry.Action == ActionPurge {
+		return nil
+	}
+
+	activity := func(kind string) models.Activity {
+		return models.Activity{
+			ID:    fmt.Sprintf("%d-%s", entry.ID, kind),
+			Kind:  kind,
+			Actor: entry.Actor,
+			Serie: *entry.After,
+			At:    entry.CreatedAt,
+		}
+	}
+
+	var activities []models.Activity
+	if entry.Action == ActionCreate {
+		activities = append(activities, activity(ActivityAdded))
+	}
+	if entry.After.Status == "Completed" && (entry.Before == nil || entry.Before.Status != "Completed") {
+		activities = append(activities, activity(ActivityCompleted))
+	}
+	if entry.Before != nil && entry.Before.Ranking != entry.After.Ranking {
+		ranked := activity(ActivityRanked)
+		ranked.PreviousRanking = entry.Before.Ranking
+		activities = append(activities, ranked)
+	}
+	return activities
+}
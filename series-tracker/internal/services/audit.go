@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// Actions recorded in the audit log, one per mutating service method
+const (
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionDelete   = "delete"
+	ActionRestore  = "restore"
+	ActionPurge    = "purge"
+	ActionStatus   = "status"
+	ActionUpvote   = "upvote"
+	ActionDownvote = "downvote"
+	ActionEpisode  = "episode"
+)
+
+// maxAuditLimit caps how many audit entries are returned by a single query
+const maxAuditLimit = 1000
+
+// AuditService defines all the methods to be implemented for reading the audit log
+type AuditService interface {
+	// GetAuditEntries returns the audit entries matching the filter, most recent first
+	GetAuditEntries(filter models.AuditFilter) ([]models.AuditEntry, error)
+	// GetHistories returns the audit entries of several series at once keyed by series
+	// ID, most recent first
+	GetHistories(ids []int) (map[int][]models.AuditEntry, error)
+}
+
+// auditService holds all the dependencies for the service
+type auditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+// NewAuditService returns an auditService with the given dependencies
+func NewAuditService(auditRepo repositories.AuditRepository) AuditService {
+	return &auditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// GetAuditEntries returns the audit entries matching the filter, the limit is
+// clamped so a single request can't dump the whole log
+func (s *auditService) GetAuditEntries(filter models.AuditFilter) ([]models.AuditEntry, error) {
+	if filter.Limit <= 0 || filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+
+	return s.auditRepo.GetAuditEntries(filter)
+}
+
+// GetHistories returns the audit entries of several series at once keyed by series ID
+func (s *auditService) GetHistories(ids []int) (map[int][]models.AuditEntry, error) {
+	entries, err := s.auditRepo.GetAuditEntriesBySerieIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group entries by series, every requested series gets a list even if empty
+	histories := make(map[int][]models.AuditEntry, len(ids))
+	for _, id := range ids {
+		histories[id] = []models.AuditEntry{}
+	}
+	for _, entry := range entries {
+		histories[entry.SerieID] = append(histories[entry.SerieID], entry)
+	}
+	return histories, nil
+}
+
+// recordAudit writes an audit entry for a series mutation through the given
+// transaction-bound repositories, before or after may be nil
+func recordAudit(ctx context.Context, repos *repositories.Repositories, action string, before, after *models.Serie) error {
+	// Work out which series the entry belongs to
+	serieID := 0
+	if after != nil {
+		serieID = after.ID
+	} else if before != nil {
+		serieID = before.ID
+	}
+
+	changes, err := diffSeries(before, after)
+	if err != nil {
+		return err
+	}
+
+	info := RequestInfoFrom(ctx)
+	_, err = repos.Audit.CreateAuditEntry(models.AuditEntry{
+		Action:    action,
+		SerieID:   serieID,
+		Actor:     info.Actor,
+		RequestID: info.RequestID,
+		Before:    before,
+		After:     after,
+		Changes:   changes,
+	})
+	return err
+}
+
+// diffSeries compares two series field by field using their JSON representation,
+// so changes are keyed the same way the API exposes them
+func diffSeries(before, after *models.Serie) (map[string]models.FieldChange, error) {
+	beforeFields, err := serieFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := serieFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]models.FieldChange{}
+	for name, value := range afterFields {
+		if old, ok := beforeFields[name]; !ok || !reflect.DeepEqual(old, value) {
+			changes[name] = models.FieldChange{From: beforeFields[name], To: value}
+		}
+	}
+	for name, value := range beforeFields {
+		if _, ok := afterFields[name]; !ok {
+			changes[name] = models.FieldChange{From: value, To: nil}
+		}
+	}
+
+	return changes, nil
+}
+
+// serieFields flattens a series into its JSON fields, nil gives no fields
+func serieFields(serie *models.Serie) (map[string]any, error) {
+	fields := map[string]any{}
+	if serie == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(serie)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
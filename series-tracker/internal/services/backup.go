@@ -0,0 +1,332 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+
+	"github.com/lib/pq"
+)
+
+// BackupFormat identifies backup archives in their manifest
+const BackupFormat = "series-tracker-backup"
+
+// BackupVersion is the schema version of the backups taken, it's bumped whenever
+// the shape of a backed up table changes along with a migration in backupMigrations
+const BackupVersion = 2
+
+// backupManifestPath is where the manifest sits in a backup archive
+const backupManifestPath = "manifest.json"
+
+// maxBackupDataSize caps the decompressed size of all the files of a backup archive
+// together, keeping small archives from decompressing into gigabytes
+const maxBackupDataSize = 512 << 20
+
+// backupMigrations migrate the tables of a backup from the version they're keyed by
+// to the next one, backups they can't migrate are reported as invalid input
+var backupMigrations = map[int]func(tables map[string]json.RawMessage) error{
+	0: migrateSeriesList,
+	1: migrateSerieDetails,
+}
+
+// BackupService defines all the methods to be implemented for backing up & restoring
+// the whole tracker
+type BackupService interface {
+	// WriteBackup writes a zip archive of every table to w, along with a manifest
+	// describing it
+	WriteBackup(ctx context.Context, w io.Writer) error
+	// Restore replaces every table by the content of a backup archive, migrating it
+	// forward when it was taken with an older version. Version 0 is a plain JSON
+	// array of series as returned by GET /api/series.
+	Restore(ctx context.Context, archive []byte) (*models.RestoreReport, error)
+}
+
+// backupService holds all the dependencies for the service
+type backupService struct {
+	repo     repositories.BackupRepository
+	restored func()
+}
+
+// NewBackupService returns a backupService with the given dependencies, restored
+// is called after every restore, e.g. to drop caches, and may be nil
+func NewBackupService(repo repositories.BackupRepository, restored func()) BackupService {
+	return &backupService{
+		repo:     repo,
+		restored: restored,
+	}
+}
+
+// WriteBackup dumps every table & writes them to a zip archive, one JSON file per
+// table listed in manifest.json with its row count & checksum.
+func (s *backupService) WriteBackup(ctx context.Context, w io.Writer) error {
+	dump, err := s.repo.Dump()
+	if err != nil {
+		return err
+	}
+
+	manifest := models.BackupManifest{
+		Format:    BackupFormat,
+		Version:   BackupVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+	archive := zip.NewWriter(w)
+	for _, name := range s.repo.Tables() {
+		var rows []json.RawMessage
+		if err := json.Unmarshal(dump[name], &rows); err != nil {
+			return fmt.Errorf("failed to read the rows of %s: %w", name, err)
+		}
+
+		table := models.BackupTable{Name: name, Path: "tables/" + name + ".json", Rows: len(rows)}
+		sum := sha256.Sum256(dump[name])
+		table.SHA256 = hex.EncodeToString(sum[:])
+		if err := writeArchiveFile(archive, table.Path, dump[name], manifest.CreatedAt); err != nil {
+			return err
+		}
+		manifest.Tables = append(manifest.Tables, table)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeArchiveFile(archive, backupManifestPath, data, manifest.CreatedAt); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// writeArchiveFile adds a compressed file to a zip archive
+func writeArchiveFile(archive *zip.Writer, path string, data []byte, modified time.Time) error {
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	return err
+}
+
+// Restore validates a backup, migrates it to the current version & restores it in
+// a single transaction, leaving the tracker untouched when anything goes wrong.
+// Rows the database rejects are reported as invalid input.
+func (s *backupService) Restore(ctx context.Context, archive []byte) (*models.RestoreReport, error) {
+	manifest, tables, err := s.readBackup(archive)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Version > BackupVersion {
+		return nil, fmt.Errorf("%w: backup version %d is newer than the supported version %d", ErrInvalidInput, manifest.Version, BackupVersion)
+	}
+
+	report := &models.RestoreReport{Version: manifest.Version, Migrated: manifest.Version < BackupVersion, Tables: map[string]int{}}
+	if !manifest.CreatedAt.IsZero() {
+		report.BackedUpAt = &manifest.CreatedAt
+	}
+	for version := manifest.Version; version < BackupVersion; version++ {
+		if err := backupMigrations[version](tables); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range s.repo.Tables() {
+		var rows []json.RawMessage
+		if data, ok := tables[name]; ok {
+			if err := json.Unmarshal(data, &rows); err != nil {
+				return nil, fmt.Errorf("%w: %s isn't an array of rows", ErrInvalidInput, name)
+			}
+		}
+		report.Tables[name] = len(rows)
+	}
+
+	err = s.repo.Restore(tables)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && (pqErr.Code.Class() == "22" || pqErr.Code.Class() == "23") {
+		// Data exceptions & integrity constraint violations
+		return nil, fmt.Errorf("%w: the backup holds rows the database rejects: %s", ErrInvalidInput, pqErr.Message)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.restored != nil {
+		s.restored()
+	}
+	return report, nil
+}
+
+// readBackup reads the manifest & tables of a backup archive, checking every table
+// against the manifest. A JSON array is read as a version 0 backup.
+func (s *backupService) readBackup(archive []byte) (models.BackupManifest, map[string]json.RawMessage, error) {
+	var manifest models.BackupManifest
+	if trimmed := bytes.TrimSpace(archive); len(trimmed) > 0 && trimmed[0] == '[' {
+		return manifest, map[string]json.RawMessage{"series": trimmed}, nil
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return manifest, nil, fmt.Errorf("%w: not a backup archive", ErrInvalidInput)
+	}
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+
+	budget := int64(maxBackupDataSize)
+	data, err := readArchiveFile(files, backupManifestPath, &budget)
+	if err != nil {
+		return manifest, nil, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil || manifest.Format != BackupFormat {
+		return manifest, nil, fmt.Errorf("%w: %s doesn't describe a backup", ErrInvalidInput, backupManifestPath)
+	}
+	if manifest.Version < 1 {
+		return manifest, nil, fmt.Errorf("%w: invalid backup version %d", ErrInvalidInput, manifest.Version)
+	}
+
+	known := map[string]bool{}
+	for _, name := range s.repo.Tables() {
+		known[name] = true
+	}
+	tables := make(map[string]json.RawMessage, len(manifest.Tables))
+	for _, table := range manifest.Tables {
+		if !known[table.Name] {
+			return manifest, nil, fmt.Errorf("%w: unknown table %q", ErrInvalidInput, table.Name)
+		}
+		if _, ok := tables[table.Name]; ok {
+			return manifest, nil, fmt.Errorf("%w: table %q is listed twice", ErrInvalidInput, table.Name)
+		}
+
+		data, err := readArchiveFile(files, table.Path, &budget)
+		if err != nil {
+			return manifest, nil, err
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != table.SHA256 {
+			return manifest, nil, fmt.Errorf("%w: %s is corrupted, its checksum doesn't match", ErrInvalidInput, table.Path)
+		}
+		var rows []json.RawMessage
+		if err := json.Unmarshal(data, &rows); err != nil || len(rows) != table.Rows {
+			return manifest, nil, fmt.Errorf("%w: %s doesn't hold the %d rows of %s", ErrInvalidInput, table.Path, table.Rows, table.Name)
+		}
+		tables[table.Name] = data
+	}
+
+	return manifest, tables, nil
+}
+
+// readArchiveFile returns the content of a file of a backup archive, taking its size
+// off the remaining budget. Files past the budget are rejected, whatever size their
+// header claims.
+func readArchiveFile(files map[string]*zip.File, path string, budget *int64) ([]byte, error) {
+	file, ok := files[path]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is missing from the archive", ErrInvalidInput, path)
+	}
+	tooLarge := fmt.Errorf("%w: the backup holds more than %dMB of data", ErrInvalidInput, maxBackupDataSize>>20)
+	if file.UncompressedSize64 > uint64(*budget) {
+		return nil, tooLarge
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s can't be read: %v", ErrInvalidInput, path, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, *budget+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s can't be read: %v", ErrInvalidInput, path, err)
+	}
+	if int64(len(data)) > *budget {
+		return nil, tooLarge
+	}
+	*budget -= int64(len(data))
+	return data, nil
+}
+
+// migrateSeriesList turns a version 0 backup, a JSON array of series as returned by
+// GET /api/series, into the series table. Series without an ID get the next free one.
+func migrateSeriesList(tables map[string]json.RawMessage) error {
+	var series []models.Serie
+	if err := json.Unmarshal(tables["series"], &series); err != nil {
+		return fmt.Errorf("%w: expected a JSON array of series", ErrInvalidInput)
+	}
+
+	nextID := 1
+	for _, serie := range series {
+		nextID = max(nextID, serie.ID+1)
+	}
+	type row struct {
+		ID             int        `json:"id"`
+		Title          string     `json:"title"`
+		Ranking        int        `json:"ranking"`
+		Status         string     `json:"status"`
+		CurrentEpisode int        `json:"current_episode"`
+		TotalEpisodes  int        `json:"total_episodes"`
+		DeletedAt      *time.Time `json:"deleted_at"`
+		Year           int        `json:"year"`
+		Genres         []string   `json:"genres"`
+	}
+	rows := make([]row, len(series))
+	for i, serie := range series {
+		if err := validateSerie(serie); err != nil {
+			return fmt.Errorf("%w (series %d of the list)", err, i+1)
+		}
+		if serie.ID == 0 {
+			serie.ID = nextID
+			nextID++
+		}
+		if serie.Genres == nil {
+			serie.Genres = []string{}
+		}
+		rows[i] = row{serie.ID, serie.Title, serie.Ranking, serie.Status, serie.CurrentEpisode, serie.TotalEpisodes, serie.DeletedAt, serie.Year, serie.Genres}
+	}
+
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	tables["series"] = data
+	return nil
+}
+
+// migrateSerieDetails gives the series of a version 1 backup the year & genres
+// columns, they're unknown until edited
+func migrateSerieDetails(tables map[string]json.RawMessage) error {
+	if tables["series"] == nil {
+		return nil
+	}
+
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(tables["series"], &rows); err != nil {
+		return fmt.Errorf("%w: series table isn't a JSON array of rows", ErrInvalidInput)
+	}
+	for _, row := range rows {
+		if isJSONNull(row["year"]) {
+			row["year"] = json.RawMessage("0")
+		}
+		if isJSONNull(row["genres"]) {
+			row["genres"] = json.RawMessage("[]")
+		}
+	}
+
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	tables["series"] = data
+	return nil
+}
+
+// isJSONNull reports whether a value is missing or null
+func isJSONNull(value json.RawMessage) bool {
+	return value == nil || string(value) == "null"
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"errors"
+	"testing"
+)
+
+// archiveFiles zips the given contents, each file's header claiming the size given
+// for it, or its real one when missing
+func archiveFiles(t *testing.T, contents map[string][]byte, claimed map[string]uint64) map[string]*zip.File {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range contents {
+		var compressed bytes.Buffer
+		fw, _ := flate.NewWriter(&compressed, flate.BestCompression)
+		fw.Write(content)
+		fw.Close()
+
+		size, ok := claimed[name]
+		if !ok {
+			size = uint64(len(content))
+		}
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate, UncompressedSize64: size, CompressedSize64: uint64(compressed.Len())}
+		file, err := w.CreateRaw(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.Write(compressed.Bytes())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	return files
+}
+
+func TestReadArchiveFileBudget(t *testing.T) {
+	files := archiveFiles(t, map[string][]byte{
+		"small.json": bytes.Repeat([]byte{'a'}, 60),
+		"large.json": bytes.Repeat([]byte{'b'}, 200),
+		"lying.json": bytes.Repeat([]byte{'c'}, 200),
+	}, map[string]uint64{"lying.json": 10})
+
+	tests := []struct {
+		name   string
+		path   string
+		budget int64
+		ok     bool
+	}{
+		{"within the budget", "small.json", 100, true},
+		{"claimed size past the budget", "large.json", 100, false},
+		{"real size past the budget", "lying.json", 100, false},
+		{"exactly the budget", "large.json", 200, true},
+	}
+	for _, test := range tests {
+		budget := test.budget
+		data, err := readArchiveFile(files, test.path, &budget)
+		if test.ok && (err != nil || budget != test.budget-int64(len(data))) {
+			t.Errorf("%s: err = %v, budget left %d", test.name, err, budget)
+		}
+		if !test.ok && !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: err = %v, want ErrInvalidInput", test.name, err)
+		}
+	}
+
+	// The budget is shared by every file of the archive
+	budget := int64(100)
+	if _, err := readArchiveFile(files, "small.json", &budget); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readArchiveFile(files, "small.json", &budget); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("reading past the total = %v, want ErrInvalidInput", err)
+	}
+}
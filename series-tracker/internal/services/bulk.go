@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// Bulk operation names
+const (
+	BulkOpCreate  = "create"
+	BulkOpUpdate  = "update"
+	BulkOpDelete  = "delete"
+	BulkOpStatus  = "status"
+	BulkOpEpisode = "episode"
+)
+
+// MaxBulkOperations caps how many operations a single bulk request may hold
+const MaxBulkOperations = 500
+
+// ErrBulkAborted is returned when an atomic bulk request was rolled back because
+// one of its operations failed
+var ErrBulkAborted = errors.New("bulk request rolled back")
+
+// RunBulk runs a list of operations, either all in one transaction that's rolled back
+// as soon as one fails, or each in its own transaction. Results are returned in
+// order for both modes, alongside ErrBulkAborted when an atomic run was rolled back.
+func (s *seriesService) RunBulk(ctx context.Context, ops []models.BulkOperation, atomic bool) ([]models.BulkResult, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("%w: no operations given", ErrInvalidInput)
+	}
+	if len(ops) > MaxBulkOperations {
+		return nil, fmt.Errorf("%w: at most %d operations per request", ErrInvalidInput, MaxBulkOperations)
+	}
+
+	results := make([]models.BulkResult, len(ops))
+	for i, op := range ops {
+		results[i] = models.BulkResult{Index: i, Op: op.Op}
+	}
+
+	if !atomic {
+		// Best effort, every operation commits or fails on its own
+		for i, op := range ops {
+			err := s.uow.Do(func(repos *repositories.Repositories) error {
+				var err error
+				results[i].Serie, err = s.runBulkOperation(ctx, repos, op)
+				return err
+			})
+			results[i].Err = err
+			results[i].Applied = err == nil
+		}
+		return results, nil
+	}
+
+	// All or nothing, the first failure rolls every operation back
+	failed := -1
+	err := s.uow.Do(func(repos *repositories.Repositories) error {
+		for i, op := range ops {
+			serie, err := s.runBulkOperation(ctx, repos, op)
+			if err != nil {
+				failed = i
+				results[i].Err = err
+				return err
+			}
+			results[i].Serie = serie
+		}
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		for i := range results {
+			results[i].Applied = true
+		}
+		return results, nil
+	case failed < 0:
+		// The transaction itself failed, e.g. on commit
+		for i := range results {
+			results[i].Err = err
+		}
+	default:
+		// Everything but the failing operation was rolled back or never ran
+		for i := range results {
+			if i != failed {
+				results[i].Serie = nil
+				results[i].Err = fmt.Errorf("%w: operation %d failed", ErrBulkAborted, failed)
+			}
+		}
+	}
+	return results, ErrBulkAborted
+}
+
+// runBulkOperation runs a single bulk operation through the given transaction-bound
+// repositories, returning the resulting series if any
+func (s *seriesService) runBulkOperation(ctx context.Context, repos *repositories.Repositories, op models.BulkOperation) (*models.Serie, error) {
+	switch op.Op {
+	case BulkOpCreate:
+		if op.Serie == nil {
+			return nil, fmt.Errorf("%w: create requires a serie", ErrInvalidInput)
+		}
+		return s.createSerieIn(ctx, repos, *op.Serie)
+	case BulkOpUpdate:
+		if op.Serie == nil {
+			return nil, fmt.Errorf("%w: update requires a serie", ErrInvalidInput)
+		}
+		serie := *op.Serie
+		serie.ID = op.ID
+		return modifySerieIn(ctx, repos, ActionUpdate, op.ID, replaceSerie(serie))
+	case BulkOpDelete:
+		return nil, deleteSerieIn(ctx, repos, op.ID)
+	case BulkOpStatus:
+		return modifySerieIn(ctx, repos, ActionStatus, op.ID, setStatus(op.Status))
+	case BulkOpEpisode:
+		return modifySerieIn(ctx, repos, ActionEpisode, op.ID, incrementEpisode)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidInput, op.Op)
+	}
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"series-tracker/internal/ical"
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// Window of the calendar feed around the moment it's generated
+const (
+	calendarPast  = 30 * 24 * time.Hour
+	calendarAhead = 180 * 24 * time.Hour
+)
+
+// Defaults of airing schedules & watch sessions
+const (
+	defaultEveryDays      = 7
+	defaultRuntimeMinutes = 30
+)
+
+// Not found errors of the watch plan, they match ErrNotFound
+var (
+	ErrScheduleNotFound = notFoundError("airing schedule not found")
+	ErrSessionNotFound  = notFoundError("watch session not found")
+)
+
+// calendarStatuses are the statuses of the series shown in the calendar feed
+var calendarStatuses = map[string]bool{"Watching": true, "Plan to Watch": true}
+
+// CalendarService defines all the methods to be implemented for the airing schedules
+// & watch sessions of series, and the calendar feed built out of them
+type CalendarService interface {
+	// GetSchedule returns the airing schedule of a series
+	GetSchedule(ctx context.Context, serieID int) (*models.AiringSchedule, error)
+	// SetSchedule creates or replaces the airing schedule of a series
+	SetSchedule(ctx context.Context, schedule models.AiringSchedule) (*models.AiringSchedule, error)
+	// DeleteSchedule removes the airing schedule of a series
+	DeleteSchedule(ctx context.Context, serieID int) error
+	// CreateSession plans a watch session of a series
+	CreateSession(ctx context.Context, session models.WatchSession) (*models.WatchSession, error)
+	// GetSessions returns the watch sessions of a series ordered by start
+	GetSessions(ctx context.Context, serieID int) ([]models.WatchSession, error)
+	// DeleteSession cancels a watch session of a series
+	DeleteSession(ctx context.Context, serieID, id int) error
+	// Calendar returns the feed of an actor: the episodes airing & the watch sessions
+	// of the series being or planned to be watched, from 30 days before now to 180
+	// days after
+	Calendar(ctx context.Context, actor string, now time.Time) (*ical.Calendar, error)
+}
+
+// calendarService holds all the dependencies for the service
+type calendarService struct {
+	series SeriesService
+	repo   repositories.CalendarRepository
+}
+
+// NewCalendarService returns a calendarService with the given dependencies
+func NewCalendarService(series SeriesService, repo repositories.CalendarRepository) CalendarService {
+	return &calendarService{
+		series: series,
+		repo:   repo,
+	}
+}
+
+// GetSchedule returns the airing schedule of an existing series
+func (s *calendarService) GetSchedule(ctx context.Context, serieID int) (*models.AiringSchedule, error) {
+	if _, err := s.series.GetSerieByID(ctx, serieID); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.repo.GetSchedule(serieID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, err
+}
+
+// SetSchedule validates & stores the airing schedule of an existing series, the
+// fields left out are defaulted
+func (s *calendarService) SetSchedule(ctx context.Context, schedule models.AiringSchedule) (*models.AiringSchedule, error) {
+	if _, err := s.series.GetSerieByID(ctx, schedule.SerieID); err != nil {
+		return nil, err
+	}
+
+	if schedule.FirstEpisode == 0 {
+		schedule.FirstEpisode = 1
+	}
+	if schedule.EveryDays == 0 {
+		schedule.EveryDays = defaultEveryDays
+	}
+	if schedule.RuntimeMinutes == 0 {
+		schedule.RuntimeMinutes = defaultRuntimeMinutes
+	}
+	switch {
+	case schedule.FirstAirAt.IsZero():
+		return nil, fmt.Errorf("%w: firstAirAt is required", ErrInvalidInput)
+	case schedule.FirstEpisode < 0:
+		return nil, fmt.Errorf("%w: firstEpisode must be positive", ErrInvalidInput)
+	case schedule.EveryDays < 0 || schedule.EveryDays > 365:
+		return nil, fmt.Errorf("%w: everyDays must be between 1 and 365", ErrInvalidInput)
+	case schedule.RuntimeMinutes < 0 || schedule.RuntimeMinutes > 24*60:
+		return nil, fmt.Errorf("%w: runtimeMinutes must be between 1 and 1440", ErrInvalidInput)
+	}
+
+	return s.repo.SetSchedule(schedule)
+}
+
+// DeleteSchedule removes the airing schedule of an existing series
+func (s *calendarService) DeleteSchedule(ctx context.Context, serieID int) error {
+	if _, err := s.series.GetSerieByID(ctx, serieID); err != nil {
+		return err
+	}
+
+	err := s.repo.DeleteSchedule(serieID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrScheduleNotFound
+	}
+	return err
+}
+
+// CreateSession validates & stores a watch session of an existing series, lasting
+// the runtime of its episodes by default
+func (s *calendarService) CreateSession(ctx context.Context, session models.WatchSession) (*models.WatchSession, error) {
+	if _, err := s.series.GetSerieByID(ctx, session.SerieID); err != nil {
+		return nil, err
+	}
+
+	if session.Episodes == 0 {
+		session.Episodes = 1
+	}
+	if session.Minutes == 0 && session.Episodes > 0 {
+		runtime := defaultRuntimeMinutes
+		schedule, err := s.repo.GetSchedule(session.SerieID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if schedule != nil {
+			runtime = schedule.RuntimeMinutes
+		}
+		session.Minutes = min(session.Episodes*runtime, 24*60)
+	}
+	switch {
+	case session.StartsAt.IsZero():
+		return nil, fmt.Errorf("%w: startsAt is required", ErrInvalidInput)
+	case session.Episodes < 0 || session.Episodes > 100:
+		return nil, fmt.Errorf("%w: episodes must be between 1 and 100", ErrInvalidInput)
+	case session.Minutes < 0 || session.Minutes > 24*60:
+		return nil, fmt.Errorf("%w: minutes must be between 1 and 1440", ErrInvalidInput)
+	}
+
+	return s.repo.CreateSession(session)
+}
+
+// GetSessions returns the watch sessions of an existing series
+func (s *calendarService) GetSessions(ctx context.Context, serieID int) ([]models.WatchSession, error) {
+	if _, err := s.series.GetSerieByID(ctx, serieID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetSessions(serieID)
+}
+
+// DeleteSession cancels a watch session of an existing series
+func (s *calendarService) DeleteSession(ctx context.Context, serieID, id int) error {
+	if _, err := s.series.GetSerieByID(ctx, serieID); err != nil {
+		return err
+	}
+
+	err := s.repo.DeleteSession(serieID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSessionNotFound
+	}
+	return err
+}
+
+// Calendar builds the feed out of every schedule & session of the series being or
+// planned to be watched, trashed & purged ones are left out
+func (s *calendarService) Calendar(ctx context.Context, actor string, now time.Time) (*ical.Calendar, error) {
+	from, to := now.Add(-calendarPast), now.Add(calendarAhead)
+
+	list, err := s.series.GetAllSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	series := map[int]models.Serie{}
+	for _, serie := range list {
+		if calendarStatuses[serie.Status] {
+			series[serie.ID] = serie
+		}
+	}
+
+	schedules, err := s.repo.GetSchedules()
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.repo.GetSessionsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &ical.Calendar{
+		ProdID:      "-//series-tracker//calendar//EN",
+		Name:        "Series tracker: " + actor,
+		Description: "Episodes airing and planned watch sessions",
+		Refresh:     6 * time.Hour,
+		Events:      []ical.Event{},
+	}
+	for _, schedule := range schedules {
+		if serie, ok := series[schedule.SerieID]; ok {
+			cal.Events = append(cal.Events, airingEvents(serie, schedule, from, to)...)
+		}
+	}
+
+	// Upcoming sessions go through the episodes left in order
+	next := map[int]int{}
+	for _, session := range sessions {
+		serie, ok := series[session.SerieID]
+		if !ok {
+			continue
+		}
+		first, last := 0, 0
+		if !session.StartsAt.Before(now) {
+			if _, ok := next[serie.ID]; !ok {
+				next[serie.ID] = serie.CurrentEpisode + 1
+			}
+			first, last = next[serie.ID], next[serie.ID]+session.Episodes-1
+			next[serie.ID] = last + 1
+			if serie.TotalEpisodes > 0 {
+				last = min(last, serie.TotalEpisodes)
+			}
+		}
+		cal.Events = append(cal.Events, sessionEvent(serie, session, first, last))
+	}
+
+	sort.SliceStable(cal.Events, func(i, j int) bool {
+		return cal.Events[i].Start.Before(cal.Events[j].Start)
+	})
+	return cal, nil
+}
+
+// airingEvents returns an event per episode of a series airing within [from, to),
+// up to its last episode when its total is known
+func airingEvents(serie models.Serie, schedule models.AiringSchedule, from, to time.Time) []ical.Event {
+	every := time.Duration(schedule.EveryDays) * 24 * time.Hour
+	runtime := time.Duration(schedule.RuntimeMinutes) * time.Minute
+
+	// Skip straight to the first episode airing within the window
+	skipped := 0
+	if gap := from.Sub(schedule.FirstAirAt); gap > 0 {
+		skipped = int((gap + every - 1) / every)
+	}
+
+	var events []ical.Event
+	for i := skipped; ; i++ {
+		episode := schedule.FirstEpisode + i
+		airsAt := schedule.FirstAirAt.Add(time.Duration(i) * every)
+		if !airsAt.Before(to) || (serie.TotalEpisodes > 0 && episode > serie.TotalEpisodes) {
+			break
+		}
+
+		description := fmt.Sprintf("Episode %d of %s airs.", episode, serie.Title)
+		if episode <= serie.CurrentEpisode {
+			description += " Already watched."
+		}
+		events = append(events, ical.Event{
+			UID:         fmt.Sprintf("airing-%d-%d@series-tracker", serie.ID, episode),
+			Start:       airsAt,
+			End:         airsAt.Add(runtime),
+			Summary:     fmt.Sprintf("%s: episode %d", serie.Title, episode),
+			Description: description,
+			Categories:  []string{"Airing", serie.Status},
+		})
+	}
+	return events
+}
+
+// sessionEvent returns the event of a watch session, first & last are the episodes
+// it's planned to cover, 0 for past sessions
+func sessionEvent(serie models.Serie, session models.WatchSession, first, last int) ical.Event {
+	summary := "Watch " + serie.Title
+	switch {
+	case first == 0 || first > last:
+	case first == last:
+		summary += fmt.Sprintf(": episode %d", first)
+	default:
+		summary += fmt.Sprintf(": episodes %d to %d", first, last)
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("session-%d@series-tracker", session.ID),
+		Start:       session.StartsAt,
+		End:         session.StartsAt.Add(time.Duration(session.Minutes) * time.Minute),
+		Summary:     summary,
+		Description: fmt.Sprintf("Planned session of %d episode(s) of %s.", session.Episodes, serie.Title),
+		Categories:  []string{"Watch plan", serie.Status},
+	}
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// Bounds of catalog searches
+const (
+	DefaultCatalogLimit = 10
+	MaxCatalogLimit     = 50
+)
+
+// CatalogService defines all the methods to be implemented for the catalog of titles
+// ingested from a public dataset
+type CatalogService interface {
+	// SearchCatalog returns at most limit titles starting with q, DefaultCatalogLimit
+	// when limit is 0
+	SearchCatalog(ctx context.Context, q string, limit int) ([]models.CatalogTitle, error)
+	// FillSerie fills the episodes, year & genres a series lacks in from the title
+	// it matches, series matching none are left as is
+	FillSerie(ctx context.Context, serie *models.Serie) error
+}
+
+// catalogService holds all the dependencies for the service
+type catalogService struct {
+	repo repositories.CatalogRepository
+}
+
+// NewCatalogService returns a catalogService with the given dependencies
+func NewCatalogService(repo repositories.CatalogRepository) CatalogService {
+	return &catalogService{
+		repo: repo,
+	}
+}
+
+// SearchCatalog validates the query & limit before searching titles by prefix
+func (s *catalogService) SearchCatalog(ctx context.Context, q string, limit int) ([]models.CatalogTitle, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, fmt.Errorf("%w: q is required", ErrInvalidInput)
+	}
+	if limit == 0 {
+		limit = DefaultCatalogLimit
+	}
+	if limit < 0 || limit > MaxCatalogLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, MaxCatalogLimit)
+	}
+
+	return s.repo.SearchTitles(q, limit)
+}
+
+// FillSerie matches the title exactly, ignoring case, only values left at zero are
+// filled in
+func (s *catalogService) FillSerie(ctx context.Context, serie *models.Serie) error {
+	title, err := s.repo.FindTitle(strings.TrimSpace(serie.Title))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if serie.TotalEpisodes == 0 {
+		serie.TotalEpisodes = title.TotalEpisodes
+	}
+	if serie.Year == 0 {
+		serie.Year = title.StartYear
+	}
+	if len(serie.Genres) == 0 {
+		serie.Genres = title.Genres
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package services
+
+import "context"
+
+// RequestInfo describes who triggered a service call, it's recorded alongside
+// every mutation in the audit log
+type RequestInfo struct {
+	Actor     string // Who performed the call, "anonymous" when unknown
+	RequestID string // ID of the request that triggered the call
+}
+
+// requestInfoKey is the context key RequestInfo is stored under
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying the given RequestInfo
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFrom returns the RequestInfo carried by ctx, falling back to an
+// anonymous actor when there is none
+func RequestInfoFrom(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	if info.Actor == "" {
+		info.Actor = "anonymous"
+	}
+	return info
+}
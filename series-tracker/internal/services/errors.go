@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+
+	"series-tracker/internal/repositories"
+)
+
+// Errors returned, usually wrapped, by the services so handlers can tell client
+// mistakes apart from internal failures
+var (
+	// ErrInvalidInput is returned when the given data can't be applied
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrNotFound is returned when the requested series doesn't exist
+	ErrNotFound = errors.New("series not found")
+	// ErrConflict is returned when a change collides with existing data, such as a
+	// title that's already taken. It comes straight from the repositories.
+	ErrConflict = repositories.ErrConflict
+)
+
+// Not found errors for resources other than series, they match ErrNotFound
+var (
+	ErrWebhookNotFound  = notFoundError("webhook not found")
+	ErrDeliveryNotFound = notFoundError("webhook delivery not found")
+	ErrNotInTrash       = notFoundError("series not found in trash")
+)
+
+// notFoundError is a not found error with its own message that still matches
+// ErrNotFound
+type notFoundError string
+
+// Error returns the message of the error
+func (e notFoundError) Error() string {
+	return string(e)
+}
+
+// Is reports whether target is ErrNotFound
+func (e notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// Feeds opened by feed tokens
+const (
+	FeedCalendar = "calendar"
+	FeedActivity = "activity"
+)
+
+// feedPaths are the paths of the feeds tokens are minted for
+var feedPaths = map[string]string{
+	FeedCalendar: "/api/calendar.ics",
+	FeedActivity: "/api/activity.atom",
+}
+
+// Not found errors of feed tokens, they match ErrNotFound
+var (
+	ErrFeedTokenNotFound = notFoundError("feed token not found")
+	// ErrFeedNotFound is returned for unknown & revoked tokens alike so they can't
+	// be told apart
+	ErrFeedNotFound = notFoundError("feed not found")
+)
+
+// FeedTokenService defines all the methods to be implemented for the secret tokens
+// opening the feeds of an actor, taken from the RequestInfo carried by ctx
+type FeedTokenService interface {
+	// CreateFeedToken mints a token for a feed of the actor, revoking the previous one
+	CreateFeedToken(ctx context.Context, feed string) (*models.FeedToken, error)
+	// RevokeFeedToken revokes the token of a feed of the actor
+	RevokeFeedToken(ctx context.Context, feed string) error
+	// ResolveFeedToken returns the actor whose feed a token opens
+	ResolveFeedToken(ctx context.Context, feed, token string) (string, error)
+}
+
+// feedTokenService holds all the dependencies for the service
+type feedTokenService struct {
+	repo repositories.FeedTokenRepository
+}
+
+// NewFeedTokenService returns a feedTokenService with the given dependencies
+func NewFeedTokenService(repo repositories.FeedTokenRepository) FeedTokenService {
+	return &feedTokenService{
+		repo: repo,
+	}
+}
+
+// hashToken returns the hash feed & share tokens are stored & looked up by
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateFeedToken mints a random token, only its hash is stored
+func (s *feedTokenService) CreateFeedToken(ctx context.Context, feed string) (*models.FeedToken, error) {
+	path, ok := feedPaths[feed]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown feed %q", ErrInvalidInput, feed)
+	}
+
+	token := randomHex(32)
+	createdAt, err := s.repo.SetToken(RequestInfoFrom(ctx).Actor, feed, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FeedToken{
+		Feed:      feed,
+		Token:     token,
+		URL:       path + "?token=" + token,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// RevokeFeedToken deletes the token of a feed of the actor
+func (s *feedTokenService) RevokeFeedToken(ctx context.Context, feed string) error {
+	if _, ok := feedPaths[feed]; !ok {
+		return fmt.Errorf("%w: unknown feed %q", ErrInvalidInput, feed)
+	}
+
+	err := s.repo.DeleteToken(RequestInfoFrom(ctx).Actor, feed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrFeedTokenNotFound
+	}
+	return err
+}
+
+// ResolveFeedToken looks the token up by its hash
+func (s *feedTokenService) ResolveFeedToken(ctx context.Context, feed, token string) (string, error) {
+	if token == "" {
+		return "", ErrFeedNotFound
+	}
+
+	actor, err := s.repo.FindActor(feed, hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrFeedNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return actor, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// maxIdempotencyKeyLength is the longest idempotency key accepted
+const maxIdempotencyKeyLength = 255
+
+// Errors returned when a request can't go ahead under its idempotency key
+var (
+	// ErrIdempotencyKeyInUse is returned while the first request made with a key
+	// is still in progress
+	ErrIdempotencyKeyInUse = errors.New("a request with this idempotency key is in progress")
+	// ErrIdempotencyKeyReused is returned when a key is sent again with a different
+	// request than the first one
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+)
+
+// IdempotencyService defines all the methods to be implemented for idempotency key
+// handling
+type IdempotencyService interface {
+	// Begin claims a key for a request with the given fingerprint. It returns nil
+	// when the caller holds the key and has to Complete or Release it, or the
+	// finished record whose response has to be replayed.
+	Begin(ctx context.Context, key, fingerprint string) (*models.IdempotencyRecord, error)
+	// Complete stores the response of the request holding a key
+	Complete(ctx context.Context, key string, status int, contentType string, body []byte) error
+	// Release frees a key so the request can be retried with it
+	Release(ctx context.Context, key string) error
+	// PurgeExpired deletes the expired keys, returning how many
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// idempotencyService holds all the dependencies for the service
+type idempotencyService struct {
+	idempotencyRepo repositories.IdempotencyRepository
+	ttl             time.Duration
+}
+
+// NewIdempotencyService returns an idempotencyService with the given dependencies,
+// responses are replayed for ttl after the first request
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepository, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+	}
+}
+
+// Begin claims a key, or returns the record holding it when it's a repeat of the
+// same request
+func (s *idempotencyService) Begin(ctx context.Context, key, fingerprint string) (*models.IdempotencyRecord, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("%w: idempotency key must be between 1 and %d characters", ErrInvalidInput, maxIdempotencyKeyLength)
+	}
+
+	now := time.Now()
+	existing, claimed, err := s.idempotencyRepo.ClaimKey(models.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if existing.Status == 0 {
+		return nil, ErrIdempotencyKeyInUse
+	}
+	return existing, nil
+}
+
+// Complete stores the response of the request holding a key
+func (s *idempotencyService) Complete(ctx context.Context, key string, status int, contentType string, body []byte) error {
+	return s.idempotencyRepo.CompleteKey(key, status, contentType, body)
+}
+
+// Release frees a key
+func (s *idempotencyService) Release(ctx context.Context, key string) error {
+	return s.idempotencyRepo.DeleteKey(key)
+}
+
+// PurgeExpired deletes the keys whose TTL ran out
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.idempotencyRepo.DeleteExpiredKeys(time.Now())
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// Import actions
+const (
+	ImportCreate = "create"
+	ImportUpdate = "update"
+	ImportRename = "rename"
+	ImportSkip   = "skip"
+	ImportError  = "error"
+)
+
+// Import conflict policies, for rows whose title is already taken. Titles are
+// unique among every series, trashed ones included.
+const (
+	ConflictUpdate = "update" // Update the series holding the title, rows matching a trashed one are rejected
+	ConflictSkip   = "skip"   // Leave the series holding the title as is
+	ConflictRename = "rename" // Create a new series under the first free "<title> (n)"
+)
+
+// MaxImportRows caps how many rows a single import may hold
+const MaxImportRows = 10000
+
+// defaultImportStatus is given to created series whose row has no status
+const defaultImportStatus = "Plan to Watch"
+
+// titleIndex holds the series an import matches rows against by title, compared
+// ignoring case & surrounding spaces
+type titleIndex struct {
+	active  map[string]models.Serie
+	trashed map[string]models.Serie
+}
+
+// taken reports whether a title is held by any series
+func (t titleIndex) taken(title string) bool {
+	_, active := t.active[titleKey(title)]
+	_, trashed := t.trashed[titleKey(title)]
+	return active || trashed
+}
+
+// ImportSeries upserts series by title. Rows that can't be read or hold invalid
+// values are reported & left out while every other row is applied in a single
+// transaction. A dry run reports the same outcome without writing anything.
+func (s *seriesService) ImportSeries(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions) (*models.ImportReport, error) {
+	if len(rows) > MaxImportRows {
+		return nil, fmt.Errorf("%w: at most %d rows per import", ErrInvalidInput, MaxImportRows)
+	}
+	switch opts.OnConflict {
+	case "":
+		opts.OnConflict = ConflictUpdate
+	case ConflictUpdate, ConflictSkip, ConflictRename:
+	default:
+		return nil, fmt.Errorf("%w: unknown conflict policy %q", ErrInvalidInput, opts.OnConflict)
+	}
+
+	var report *models.ImportReport
+	err := s.uow.Do(func(repos *repositories.Repositories) error {
+		report = &models.ImportReport{DryRun: opts.DryRun, Results: make([]models.ImportResult, len(rows))}
+
+		index, err := loadTitleIndex(repos)
+		if err != nil {
+			return err
+		}
+
+		for i, row := range rows {
+			if opts.Progress != nil {
+				opts.Progress(i)
+			}
+			result := &report.Results[i]
+			*result = models.ImportResult{Line: row.Line, Title: row.Serie.Title}
+			serie, err := s.importRow(ctx, repos, index, row, opts, result)
+			if errors.Is(err, ErrInvalidInput) {
+				result.Action = ImportError
+				result.Error = err.Error()
+				report.Failed++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			result.Serie = serie
+			switch result.Action {
+			case ImportCreate:
+				report.Created++
+			case ImportRename:
+				report.Created++
+				report.Renamed++
+			case ImportUpdate:
+				report.Updated++
+			case ImportSkip:
+				report.Skipped++
+			}
+			if serie.DeletedAt == nil {
+				index.active[titleKey(serie.Title)] = *serie
+			}
+		}
+		if opts.Progress != nil {
+			opts.Progress(len(rows))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// loadTitleIndex indexes every series by title through the given transaction-bound
+// repositories
+func loadTitleIndex(repos *repositories.Repositories) (titleIndex, error) {
+	active, err := repos.Series.GetAllSeries()
+	if err != nil {
+		return titleIndex{}, err
+	}
+	trashed, err := repos.Series.GetTrashedSeries()
+	if err != nil {
+		return titleIndex{}, err
+	}
+
+	index := titleIndex{
+		active:  make(map[string]models.Serie, len(active)),
+		trashed: make(map[string]models.Serie, len(trashed)),
+	}
+	for _, serie := range active {
+		index.active[titleKey(serie.Title)] = serie
+	}
+	for _, serie := range trashed {
+		index.trashed[titleKey(serie.Title)] = serie
+	}
+	return index, nil
+}
+
+// importRow creates or updates the series matching a row through the given
+// transaction-bound repositories, setting the action taken on result. Rejected
+// rows are reported through ErrInvalidInput.
+func (s *seriesService) importRow(ctx context.Context, repos *repositories.Repositories, index titleIndex, row models.ImportRow, opts models.ImportOptions, result *models.ImportResult) (*models.Serie, error) {
+	if row.Err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, row.Err)
+	}
+
+	key := titleKey(row.Serie.Title)
+	existing, active := index.active[key]
+	trashed, inTrash := index.trashed[key]
+
+	switch {
+	case !active && !inTrash:
+		result.Action = ImportCreate
+		return s.createImported(ctx, repos, mergeImportRow(models.Serie{}, row, false), opts.DryRun)
+	case opts.OnConflict == ConflictSkip:
+		result.Action = ImportSkip
+		if active {
+			return &existing, nil
+		}
+		return &trashed, nil
+	case opts.OnConflict == ConflictRename:
+		result.Action = ImportRename
+		serie := mergeImportRow(models.Serie{}, row, false)
+		serie.Title = freeTitle(index, serie.Title)
+		return s.createImported(ctx, repos, serie, opts.DryRun)
+	case !active:
+		return nil, fmt.Errorf("%w: title %q is taken by a series in the trash, restore or purge it first", ErrInvalidInput, trashed.Title)
+	}
+
+	serie := mergeImportRow(existing, row, true)
+	if err := validateSerie(serie); err != nil {
+		return nil, err
+	}
+	if serie.Equal(existing) {
+		result.Action = ImportSkip
+		return &existing, nil
+	}
+
+	result.Action = ImportUpdate
+	if opts.DryRun {
+		return &serie, nil
+	}
+	return modifySerieIn(ctx, repos, ActionUpdate, existing.ID, replaceSerie(serie))
+}
+
+// createImported creates an imported series, or fills it in & validates it on a
+// dry run
+func (s *seriesService) createImported(ctx context.Context, repos *repositories.Repositories, serie models.Serie, dryRun bool) (*models.Serie, error) {
+	if !dryRun {
+		return s.createSerieIn(ctx, repos, serie)
+	}
+
+	if err := s.fillSerie(ctx, &serie); err != nil {
+		return nil, err
+	}
+	if err := validateSerie(serie); err != nil {
+		return nil, err
+	}
+	return &serie, nil
+}
+
+// freeTitle returns the first "<title> (n)" no series holds
+func freeTitle(index titleIndex, title string) string {
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)", title, n)
+		if !index.taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+// mergeImportRow returns the series a row results in, the fields it doesn't hold
+// are kept from the existing series or defaulted when creating one. The title of
+// an existing series is kept as is since it's what the row matched. Statuses are
+// matched ignoring case.
+func mergeImportRow(existing models.Serie, row models.ImportRow, found bool) models.Serie {
+	serie := existing
+	if !found {
+		serie = models.Serie{Title: strings.TrimSpace(row.Serie.Title), Status: defaultImportStatus}
+	}
+
+	if row.Fields["ranking"] {
+		serie.Ranking = row.Serie.Ranking
+	}
+	if row.Fields["status"] {
+		serie.Status = row.Serie.Status
+		for status := range validStatuses {
+			if strings.EqualFold(status, strings.TrimSpace(row.Serie.Status)) {
+				serie.Status = status
+			}
+		}
+	}
+	if row.Fields["lastEpisodeWatched"] {
+		serie.CurrentEpisode = row.Serie.CurrentEpisode
+	}
+	if row.Fields["totalEpisodes"] {
+		serie.TotalEpisodes = row.Serie.TotalEpisodes
+	}
+	if row.Fields["year"] {
+		serie.Year = row.Serie.Year
+	}
+	if row.Fields["genres"] {
+		serie.Genres = row.Serie.Genres
+	}
+	return serie
+}
+
+// titleKey returns the key series are matched by on import
+func titleKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
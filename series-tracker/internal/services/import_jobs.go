@@ -0,0 +1,186 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"series-tracker/internal/imports"
+	"series-tracker/internal/models"
+)
+
+// Import job statuses
+const (
+	ImportJobQueued    = "queued"
+	ImportJobRunning   = "running"
+	ImportJobSucceeded = "succeeded"
+	ImportJobFailed    = "failed"
+)
+
+// Not found errors of import jobs, they match ErrNotFound
+var (
+	ErrImportFormatNotFound = notFoundError("unknown import format")
+	ErrImportJobNotFound    = notFoundError("import job not found")
+)
+
+// ImportJobService defines all the methods to be implemented for running imports
+// in the background
+type ImportJobService interface {
+	// Formats returns the formats files can be imported from
+	Formats() []string
+	// StartImport queues the import of a file, settings holds the options of its
+	// format. The returned job can be polled with GetImportJob.
+	StartImport(ctx context.Context, format string, file []byte, settings map[string]string, opts models.ImportOptions) (*models.ImportJob, error)
+	// GetImportJob returns the current state of a job by its ID
+	GetImportJob(ctx context.Context, id string) (*models.ImportJob, error)
+}
+
+// importJobService holds all the dependencies for the service. Jobs are kept in
+// memory & run one at a time so imports never race each other over titles.
+type importJobService struct {
+	series    SeriesService
+	importers *imports.Registry
+	retention time.Duration
+	slot      chan struct{}
+
+	mu   sync.Mutex
+	jobs map[string]*models.ImportJob
+}
+
+// NewImportJobService returns an importJobService with the given dependencies,
+// finished jobs can be polled for retention
+func NewImportJobService(series SeriesService, importers *imports.Registry, retention time.Duration) ImportJobService {
+	return &importJobService{
+		series:    series,
+		importers: importers,
+		retention: retention,
+		slot:      make(chan struct{}, 1),
+		jobs:      map[string]*models.ImportJob{},
+	}
+}
+
+// Formats returns the formats of every registered importer
+func (s *importJobService) Formats() []string {
+	return s.importers.Formats()
+}
+
+// StartImport validates the import options & runs the import in the background.
+// The job outlives the request, keeping its actor & request ID for the audit log.
+func (s *importJobService) StartImport(ctx context.Context, format string, file []byte, settings map[string]string, opts models.ImportOptions) (*models.ImportJob, error) {
+	importer, ok := s.importers.Get(format)
+	if !ok {
+		return nil, fmt.Errorf("%w %q, expected one of %s", ErrImportFormatNotFound, format, strings.Join(s.Formats(), ", "))
+	}
+	switch opts.OnConflict {
+	case "", ConflictUpdate, ConflictSkip, ConflictRename:
+	default:
+		return nil, fmt.Errorf("%w: unknown conflict policy %q", ErrInvalidInput, opts.OnConflict)
+	}
+
+	job := &models.ImportJob{
+		ID:        randomHex(16),
+		Format:    format,
+		Status:    ImportJobQueued,
+		DryRun:    opts.DryRun,
+		CreatedAt: time.Now(),
+	}
+	s.mu.Lock()
+	s.pruneLocked(job.CreatedAt)
+	s.jobs[job.ID] = job
+	snapshot := *job
+	s.mu.Unlock()
+
+	go s.run(context.WithoutCancel(ctx), job, importer, file, settings, opts)
+	return &snapshot, nil
+}
+
+// run reads & imports a file once no other import is running, recording its
+// progress & outcome on the job
+func (s *importJobService) run(ctx context.Context, job *models.ImportJob, importer imports.Importer, file []byte, settings map[string]string, opts models.ImportOptions) {
+	s.slot <- struct{}{}
+	defer func() { <-s.slot }()
+
+	s.update(job, func(job *models.ImportJob) {
+		now := time.Now()
+		job.Status = ImportJobRunning
+		job.StartedAt = &now
+	})
+
+	report, err := s.importFile(ctx, job, importer, file, settings, opts)
+
+	s.update(job, func(job *models.ImportJob) {
+		now := time.Now()
+		job.FinishedAt = &now
+		if err != nil {
+			job.Status = ImportJobFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = ImportJobSucceeded
+		job.Report = report
+	})
+}
+
+// importFile reads the rows of a file & imports them, client mistakes are
+// reported as is & anything else only as an internal failure
+func (s *importJobService) importFile(ctx context.Context, job *models.ImportJob, importer imports.Importer, file []byte, settings map[string]string, opts models.ImportOptions) (*models.ImportReport, error) {
+	result, err := importer.Read(bytes.NewReader(file), settings)
+	if errors.Is(err, imports.ErrInvalidFile) {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("import %s: failed to read %s file: %v", job.ID, job.Format, err)
+		return nil, errors.New("could not read file")
+	}
+	s.update(job, func(job *models.ImportJob) { job.Total = len(result.Rows) })
+
+	opts.Progress = func(processed int) {
+		s.update(job, func(job *models.ImportJob) { job.Processed = processed })
+	}
+	report, err := s.series.ImportSeries(ctx, result.Rows, opts)
+	if errors.Is(err, ErrInvalidInput) {
+		return nil, err
+	}
+	if err != nil {
+		log.Printf("import %s: failed to import series: %v", job.ID, err)
+		return nil, errors.New("could not import series")
+	}
+
+	report.IgnoredColumns = result.IgnoredColumns
+	return report, nil
+}
+
+// update changes a job while holding the lock
+func (s *importJobService) update(job *models.ImportJob, change func(job *models.ImportJob)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(job)
+}
+
+// pruneLocked forgets the jobs that finished longer than the retention ago, the
+// lock must be held
+func (s *importJobService) pruneLocked(now time.Time) {
+	for id, job := range s.jobs {
+		if job.FinishedAt != nil && now.Sub(*job.FinishedAt) > s.retention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// GetImportJob returns a copy of a job's current state
+func (s *importJobService) GetImportJob(ctx context.Context, id string) (*models.ImportJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok || (job.FinishedAt != nil && time.Since(*job.FinishedAt) > s.retention) {
+		return nil, ErrImportJobNotFound
+	}
+	snapshot := *job
+	return &snapshot, nil
+}
@@ -1,12 +1,28 @@
 package services
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"series-tracker/internal/models"
+	"series-tracker/internal/patch"
 	"series-tracker/internal/repositories"
 )
 
+// PatchFormat identifies the kind of patch document handed to PatchSerie
+type PatchFormat string
+
+// Supported patch formats
+const (
+	PatchFormatMerge PatchFormat = "merge" // RFC 7396 JSON Merge Patch
+	PatchFormatJSON  PatchFormat = "json"  // RFC 6902 JSON Patch
+)
+
 // Set of valid statuses for the serie, used in various
 var validStatuses = map[string]bool{
 	"Watching":      true,
@@ -15,174 +31,461 @@ var validStatuses = map[string]bool{
 	"Completed":     true,
 }
 
-// SeriesService defines all the methods to be implemented for series management
+// IsValidStatus reports whether a series can have the given status
+func IsValidStatus(status string) bool {
+	return validStatuses[status]
+}
+
+// SeriesService defines all the methods to be implemented for series management,
+// every mutation is recorded in the audit log using the RequestInfo carried by ctx
 type SeriesService interface {
 	// GetSerieByID returns a series by its ID
-	GetSerieByID(id int) (*models.Serie, error)
+	GetSerieByID(ctx context.Context, id int) (*models.Serie, error)
 	// GetAllSeries returns a list of all series
-	GetAllSeries() ([]models.Serie, error)
+	GetAllSeries(ctx context.Context) ([]models.Serie, error)
 	// CreateSerie creates a new series
-	CreateSerie(models.Serie) (*models.Serie, error)
+	CreateSerie(ctx context.Context, serie models.Serie) (*models.Serie, error)
 	// UpdateSerie updates a series with all values detailed in a Serie struct based on its ID
-	UpdateSerie(models.Serie) (*models.Serie, error)
-	// DeleteSerie deletes a series by its ID
-	DeleteSerie(id int) error
+	UpdateSerie(ctx context.Context, serie models.Serie) (*models.Serie, error)
+	// PatchSerie applies a patch document of the given format to a series by its ID
+	PatchSerie(ctx context.Context, id int, format PatchFormat, document []byte) (*models.Serie, error)
+	// DeleteSerie moves a series to the trash by its ID
+	DeleteSerie(ctx context.Context, id int) error
+	// GetTrashedSeries returns a list of all series in the trash
+	GetTrashedSeries(ctx context.Context) ([]models.Serie, error)
+	// RestoreSerie takes a series out of the trash by its ID
+	RestoreSerie(ctx context.Context, id int) (*models.Serie, error)
+	// PurgeSerie permanently deletes a trashed series by its ID
+	PurgeSerie(ctx context.Context, id int) error
+	// PurgeExpiredTrash permanently deletes series that have been in the trash for
+	// longer than the retention period
+	PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int64, error)
 	// UpdateSerieStatus updates the status of a series by its ID
-	UpdateSerieStatus(id int, status string) (*models.Serie, error)
+	UpdateSerieStatus(ctx context.Context, id int, status string) (*models.Serie, error)
 	// UpvoteSerie increases the ranking score of a series by 1
-	UpvoteSerie(id int) (*models.Serie, error)
+	UpvoteSerie(ctx context.Context, id int) (*models.Serie, error)
 	// DownvoteSerie decreases the ranking score of a series by 1
-	DownvoteSerie(id int) (*models.Serie, error)
+	DownvoteSerie(ctx context.Context, id int) (*models.Serie, error)
 	// IncrementSerieEpisode increases the current episode of a series by 1
-	IncrementSerieEpisode(id int) (*models.Serie, error)
+	IncrementSerieEpisode(ctx context.Context, id int) (*models.Serie, error)
+	// RunBulk runs a list of operations, in one transaction when atomic and one
+	// transaction per operation otherwise
+	RunBulk(ctx context.Context, ops []models.BulkOperation, atomic bool) ([]models.BulkResult, error)
+	// ImportSeries creates or updates a series per row by title, only reporting
+	// what would change on a dry run
+	ImportSeries(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions) (*models.ImportReport, error)
+	// GetChanges returns the series created, updated & deleted since a sync token
+	// along with the next token, every series when the token is empty
+	GetChanges(ctx context.Context, token string) (*models.SyncChanges, error)
+	// PushChanges applies edits queued offline on top of a sync token, reporting a
+	// conflict for each edit of a series changed on the server since
+	PushChanges(ctx context.Context, push models.SyncPush) ([]models.SyncResult, error)
+}
+
+// ChangePublisher receives every committed mutation of a series, in commit order,
+// through the audit entry recording it
+type ChangePublisher interface {
+	Publish(entry models.AuditEntry)
 }
 
 // seriesService holds all the dependencies for the service
 type seriesService struct {
 	seriesRepo repositories.SeriesRepository
+	uow        repositories.UnitOfWork
+	catalog    CatalogService
 }
 
-// NewSeriesService returns a seriesService with the given dependencies
-func NewSeriesService(seriesRepo repositories.SeriesRepository) SeriesService {
+// NewSeriesService returns a seriesService with the given dependencies, changes
+// is notified once each mutation committed and may be nil, so may catalog when new
+// series aren't filled in
+func NewSeriesService(seriesRepo repositories.SeriesRepository, uow repositories.UnitOfWork, changes ChangePublisher, catalog CatalogService) SeriesService {
+	if changes != nil {
+		uow = repositories.NewNotifyingUnitOfWork(uow, changes.Publish)
+	}
 	return &seriesService{
 		seriesRepo: seriesRepo,
+		uow:        uow,
+		catalog:    catalog,
 	}
 }
 
-// GetAllSeries returns a list of all series
-func (s *seriesService) GetAllSeries() ([]models.Serie, error) {
-	// Get series by ID from repository
-	series, err := s.seriesRepo.GetAllSeries()
+// modifySerie runs a read-modify-write flow on a single series inside a transaction,
+// see modifySerieIn
+func (s *seriesService) modifySerie(ctx context.Context, action string, id int, modify func(serie *models.Serie) error) (*models.Serie, error) {
+	var updatedSerie *models.Serie
+	err := s.uow.Do(func(repos *repositories.Repositories) error {
+		var err error
+		updatedSerie, err = modifySerieIn(ctx, repos, action, id, modify)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	return series, nil
+	return updatedSerie, nil
 }
 
-// GetSerieByID returns a series by itsd ID
-func (s *seriesService) GetSerieByID(id int) (*models.Serie, error) {
-	// Get the series from the repository
-	serie, err := s.seriesRepo.GetSerieByID(id)
+// modifySerieIn runs a read-modify-write flow on a single series through the given
+// transaction-bound repositories, locking the row before calling modify so concurrent
+// requests can't lose updates. The change is recorded in the audit log under the
+// given action.
+func modifySerieIn(ctx context.Context, repos *repositories.Repositories, action string, id int, modify func(serie *models.Serie) error) (*models.Serie, error) {
+	// Get & lock series information
+	serie, err := repos.Series.GetSerieByIDForUpdate(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
+	before := *serie
 
-	// Return result
-	return serie, nil
+	// Apply the changes
+	if err := modify(serie); err != nil {
+		return nil, err
+	}
+
+	// Call repository to update
+	updatedSerie, err := repos.Series.UpdateSerie(*serie)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordAudit(ctx, repos, action, &before, updatedSerie); err != nil {
+		return nil, err
+	}
+	return updatedSerie, nil
 }
 
-// CreateSerie creates a new series
-func (s *seriesService) CreateSerie(serie models.Serie) (*models.Serie, error) {
+// fillSerie fills in what the catalog knows of a new series, if there's a catalog
+func (s *seriesService) fillSerie(ctx context.Context, serie *models.Serie) error {
+	if s.catalog == nil {
+		return nil
+	}
+	return s.catalog.FillSerie(ctx, serie)
+}
+
+// createSerieIn fills in & creates a series through the given transaction-bound
+// repositories, recording it in the audit log
+func (s *seriesService) createSerieIn(ctx context.Context, repos *repositories.Repositories, serie models.Serie) (*models.Serie, error) {
+	if err := s.fillSerie(ctx, &serie); err != nil {
+		return nil, err
+	}
+	if err := validateSerie(serie); err != nil {
+		return nil, err
+	}
+
 	// Create series in the repository
-	createdSerie, err := s.seriesRepo.CreateNewSerie(serie)
+	createdSerie, err := repos.Series.CreateNewSerie(serie)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := recordAudit(ctx, repos, ActionCreate, nil, createdSerie); err != nil {
+		return nil, err
+	}
 	return createdSerie, nil
 }
 
-// UpdateSerie updates a series with all values detailed in the struct based on the ID
-func (s *seriesService) UpdateSerie(serie models.Serie) (*models.Serie, error) {
-	updatedSerie, err := s.seriesRepo.UpdateSerie(serie)
+// deleteSerieIn moves a series to the trash through the given transaction-bound
+// repositories, recording it in the audit log
+func deleteSerieIn(ctx context.Context, repos *repositories.Repositories, id int) error {
+	// Get & lock series information for the audit log
+	serie, err := repos.Series.GetSerieByIDForUpdate(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return updatedSerie, nil
+	err = repos.Series.DeleteSerie(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(ctx, repos, ActionDelete, serie, nil)
 }
 
-// DeleteSerie deletes a serie by its ID
-func (s *seriesService) DeleteSerie(id int) error {
-	if err := s.seriesRepo.DeleteSerie(id); err != nil {
-		return err
+// replaceSerie returns a modifier replacing every value of a series with the given
+// ones, validating them first. Year & genres are kept when the given series has
+// none so clients unaware of them don't clear them, PatchSerie can.
+func replaceSerie(serie models.Serie) func(current *models.Serie) error {
+	return func(current *models.Serie) error {
+		if err := validateSerie(serie); err != nil {
+			return err
+		}
+
+		if serie.Year == 0 {
+			serie.Year = current.Year
+		}
+		if len(serie.Genres) == 0 {
+			serie.Genres = current.Genres
+		}
+
+		// Replace every value with the given ones
+		*current = serie
+		return nil
 	}
-	return nil
 }
 
-// UpdateSerieStatus updates the status of a serie by updating the information & updating via repository
-func (s *seriesService) UpdateSerieStatus(id int, status string) (*models.Serie, error) {
-	// Check validity of given status
-	if !validStatuses[status] {
-		return nil, errors.New("invalid status")
+// setStatus returns a modifier setting the status of a series, validating it first
+func setStatus(status string) func(serie *models.Serie) error {
+	return func(serie *models.Serie) error {
+		// Check validity of given status
+		if !validStatuses[status] {
+			return fmt.Errorf("%w: invalid status %q", ErrInvalidInput, status)
+		}
+
+		// Set the status to the updated one
+		serie.Status = status
+		return nil
 	}
+}
 
-	// Get series information from repository
-	serie, err := s.seriesRepo.GetSerieByID(id)
-	if err != nil {
-		return nil, err
+// incrementEpisode is the modifier increasing the current episode of a series by one
+func incrementEpisode(serie *models.Serie) error {
+	if serie.CurrentEpisode >= serie.TotalEpisodes {
+		return fmt.Errorf("%w: series hit max episodes", ErrInvalidInput)
 	}
 
-	// Set the status to the updated one
-	serie.Status = status
+	// Increment value by one
+	serie.CurrentEpisode += 1
+	return nil
+}
 
-	// Call repository to update
-	updatedSerie, err := s.seriesRepo.UpdateSerie(*serie)
-	if err != nil {
-		return nil, err
+// validateSerie checks the values of a series before they're stored
+func validateSerie(serie models.Serie) error {
+	switch {
+	case serie.Title == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidInput)
+	case !validStatuses[serie.Status]:
+		return fmt.Errorf("%w: invalid status %q", ErrInvalidInput, serie.Status)
+	case serie.Ranking < 0:
+		return fmt.Errorf("%w: ranking can't be negative", ErrInvalidInput)
+	case serie.TotalEpisodes < 0:
+		return fmt.Errorf("%w: total episodes can't be negative", ErrInvalidInput)
+	case serie.CurrentEpisode < 0 || serie.CurrentEpisode > serie.TotalEpisodes:
+		return fmt.Errorf("%w: last episode watched must be between 0 and total episodes", ErrInvalidInput)
+	case serie.Year < 0:
+		return fmt.Errorf("%w: year can't be negative", ErrInvalidInput)
 	}
-	return updatedSerie, nil
+	return nil
 }
 
-// UpvoteSerie updates the ranking of a serie incrementing by one
-func (s *seriesService) UpvoteSerie(id int) (*models.Serie, error) {
-	// Get series information from repository
-	serie, err := s.seriesRepo.GetSerieByID(id)
+// GetAllSeries returns a list of all series
+func (s *seriesService) GetAllSeries(ctx context.Context) ([]models.Serie, error) {
+	// Get series by ID from repository
+	series, err := s.seriesRepo.GetAllSeries()
 	if err != nil {
 		return nil, err
 	}
 
-	// Increment ranking score by 1
-	serie.Ranking += 1
+	return series, nil
+}
 
-	updatedSerie, err := s.seriesRepo.UpdateSerie(*serie)
+// GetSerieByID returns a series by itsd ID
+func (s *seriesService) GetSerieByID(ctx context.Context, id int) (*models.Serie, error) {
+	// Get the series from the repository
+	serie, err := s.seriesRepo.GetSerieByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
-	return updatedSerie, nil
+
+	// Return result
+	return serie, nil
 }
 
-// DownvoteSerie updates the ranking of a serie decreasing by one
-func (s *seriesService) DownvoteSerie(id int) (*models.Serie, error) {
-	// Get series information from repository
-	serie, err := s.seriesRepo.GetSerieByID(id)
+// CreateSerie creates a new series
+func (s *seriesService) CreateSerie(ctx context.Context, serie models.Serie) (*models.Serie, error) {
+	var createdSerie *models.Serie
+	err := s.uow.Do(func(repos *repositories.Repositories) error {
+		var err error
+		createdSerie, err = s.createSerieIn(ctx, repos, serie)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if serie.Ranking <= 0 {
-		return nil, errors.New("series can't be downvoted further")
+	return createdSerie, nil
+}
+
+// UpdateSerie updates a series with all values detailed in the struct based on the ID
+func (s *seriesService) UpdateSerie(ctx context.Context, serie models.Serie) (*models.Serie, error) {
+	return s.modifySerie(ctx, ActionUpdate, serie.ID, replaceSerie(serie))
+}
+
+// PatchSerie applies a patch document to the JSON representation of a series, the
+// series is locked while patching so concurrent changes to other fields are kept
+func (s *seriesService) PatchSerie(ctx context.Context, id int, format PatchFormat, document []byte) (*models.Serie, error) {
+	// Pick the patch implementation
+	var apply func(doc, patch []byte) ([]byte, error)
+	switch format {
+	case PatchFormatMerge:
+		apply = patch.MergePatch
+	case PatchFormatJSON:
+		apply = patch.JSONPatch
+	default:
+		return nil, fmt.Errorf("%w: unsupported patch format %q", ErrInvalidInput, format)
 	}
 
-	// Decrease value by one
-	serie.Ranking -= 1
+	return s.modifySerie(ctx, ActionUpdate, id, func(serie *models.Serie) error {
+		current, err := json.Marshal(serie)
+		if err != nil {
+			return err
+		}
+
+		patched, err := apply(current, document)
+		if errors.Is(err, patch.ErrInvalidPatch) {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Decode strictly so typos in field names aren't silently dropped
+		var updated models.Serie
+		decoder := json.NewDecoder(bytes.NewReader(patched))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&updated); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+
+		// ID and trash state aren't editable
+		if updated.ID != serie.ID {
+			return fmt.Errorf("%w: id can't be changed", ErrInvalidInput)
+		}
+		updated.DeletedAt = serie.DeletedAt
+
+		if err := validateSerie(updated); err != nil {
+			return err
+		}
 
-	updatedSerie, err := s.seriesRepo.UpdateSerie(*serie)
+		*serie = updated
+		return nil
+	})
+}
+
+// DeleteSerie moves a serie to the trash by its ID
+func (s *seriesService) DeleteSerie(ctx context.Context, id int) error {
+	return s.uow.Do(func(repos *repositories.Repositories) error {
+		return deleteSerieIn(ctx, repos, id)
+	})
+}
+
+// GetTrashedSeries returns a list of all series in the trash
+func (s *seriesService) GetTrashedSeries(ctx context.Context) ([]models.Serie, error) {
+	series, err := s.seriesRepo.GetTrashedSeries()
 	if err != nil {
 		return nil, err
 	}
-	return updatedSerie, nil
+
+	return series, nil
 }
 
-// IncrementSerieEpisode incrementes the current episode by one
-func (s *seriesService) IncrementSerieEpisode(id int) (*models.Serie, error) {
-	// Get series information from repository
-	serie, err := s.seriesRepo.GetSerieByID(id)
+// RestoreSerie takes a serie out of the trash by its ID
+func (s *seriesService) RestoreSerie(ctx context.Context, id int) (*models.Serie, error) {
+	var restoredSerie *models.Serie
+	err := s.uow.Do(func(repos *repositories.Repositories) error {
+		var err error
+		restoredSerie, err = repos.Series.RestoreSerie(id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotInTrash
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, ActionRestore, nil, restoredSerie)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if serie.CurrentEpisode >= serie.TotalEpisodes {
-		return nil, errors.New("series hit max episodes")
+	return restoredSerie, nil
+}
+
+// PurgeSerie permanently deletes a trashed serie by its ID
+func (s *seriesService) PurgeSerie(ctx context.Context, id int) error {
+	return s.uow.Do(func(repos *repositories.Repositories) error {
+		purgedSerie, err := repos.Series.PurgeSerie(id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotInTrash
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, repos, ActionPurge, purgedSerie, nil)
+	})
+}
+
+// PurgeExpiredTrash permanently deletes series trashed longer than retention ago
+func (s *seriesService) PurgeExpiredTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention < 0 {
+		return 0, errors.New("invalid retention period")
 	}
 
-	// Increment value by one
-	serie.CurrentEpisode += 1
+	var purged int64
+	err := s.uow.Do(func(repos *repositories.Repositories) error {
+		purgedSeries, err := repos.Series.PurgeTrashedBefore(time.Now().Add(-retention))
+		if err != nil {
+			return err
+		}
 
-	updatedSerie, err := s.seriesRepo.UpdateSerie(*serie)
+		// Record every purged series individually
+		for i := range purgedSeries {
+			if err := recordAudit(ctx, repos, ActionPurge, &purgedSeries[i], nil); err != nil {
+				return err
+			}
+		}
+		purged = int64(len(purgedSeries))
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return updatedSerie, nil
+
+	return purged, nil
+}
+
+// UpdateSerieStatus updates the status of a serie by updating the information & updating via repository
+func (s *seriesService) UpdateSerieStatus(ctx context.Context, id int, status string) (*models.Serie, error) {
+	return s.modifySerie(ctx, ActionStatus, id, setStatus(status))
+}
+
+// UpvoteSerie updates the ranking of a serie incrementing by one
+func (s *seriesService) UpvoteSerie(ctx context.Context, id int) (*models.Serie, error) {
+	return s.modifySerie(ctx, ActionUpvote, id, func(serie *models.Serie) error {
+		// Increment ranking score by 1
+		serie.Ranking += 1
+		return nil
+	})
+}
+
+// DownvoteSerie updates the ranking of a serie decreasing by one
+func (s *seriesService) DownvoteSerie(ctx context.Context, id int) (*models.Serie, error) {
+	return s.modifySerie(ctx, ActionDownvote, id, func(serie *models.Serie) error {
+		if serie.Ranking <= 0 {
+			return fmt.Errorf("%w: series can't be downvoted further", ErrInvalidInput)
+		}
+
+		// Decrease value by one
+		serie.Ranking -= 1
+		return nil
+	})
+}
+
+// IncrementSerieEpisode incrementes the current episode by one
+func (s *seriesService) IncrementSerieEpisode(ctx context.Context, id int) (*models.Serie, error) {
+	return s.modifySerie(ctx, ActionEpisode, id, incrementEpisode)
 }
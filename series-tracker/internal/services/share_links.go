@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// sharePath is the path of the shared views, followed by their token
+const sharePath = "/share/"
+
+// Not found errors of share links, they match ErrNotFound
+var (
+	ErrShareLinkNotFound = notFoundError("share link not found")
+	// ErrShareNotFound is returned for unknown, revoked & expired tokens alike so
+	// they can't be told apart
+	ErrShareNotFound = notFoundError("share not found")
+)
+
+// ShareService defines all the methods to be implemented for the public, read-only
+// views of the series list, managed by the actor taken from the RequestInfo carried
+// by ctx
+type ShareService interface {
+	// CreateShareLink mints a share link of the actor, the returned one holds its token
+	CreateShareLink(ctx context.Context, input models.ShareLinkInput) (*models.ShareLink, error)
+	// GetShareLinks returns the share links of the actor, without tokens
+	GetShareLinks(ctx context.Context) ([]models.ShareLink, error)
+	// RevokeShareLink revokes a share link of the actor by its ID
+	RevokeShareLink(ctx context.Context, id int) error
+	// GetSharedList returns the view a token opens
+	GetSharedList(ctx context.Context, token string) (*models.SharedList, error)
+}
+
+// shareService holds all the dependencies for the service
+type shareService struct {
+	series SeriesService
+	repo   repositories.ShareLinkRepository
+}
+
+// NewShareService returns a shareService with the given dependencies
+func NewShareService(series SeriesService, repo repositories.ShareLinkRepository) ShareService {
+	return &shareService{
+		series: series,
+		repo:   repo,
+	}
+}
+
+// CreateShareLink validates the filter & mints a random token, only its hash is stored
+func (s *shareService) CreateShareLink(ctx context.Context, input models.ShareLinkInput) (*models.ShareLink, error) {
+	statuses := []string{}
+	for _, status := range input.Statuses {
+		if !IsValidStatus(status) {
+			return nil, fmt.Errorf("%w: invalid status %q", ErrInvalidInput, status)
+		}
+		statuses = append(statuses, status)
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expiresAt must be in the future", ErrInvalidInput)
+	}
+
+	token := randomHex(32)
+	link, err := s.repo.CreateShareLink(RequestInfoFrom(ctx).Actor, hashToken(token), models.ShareLink{
+		Statuses:     statuses,
+		HideRankings: input.HideRankings,
+		ExpiresAt:    input.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link.Token = token
+	link.URL = sharePath + token
+	return link, nil
+}
+
+// GetShareLinks returns the links of the actor, expired ones included
+func (s *shareService) GetShareLinks(ctx context.Context) ([]models.ShareLink, error) {
+	return s.repo.GetShareLinks(RequestInfoFrom(ctx).Actor)
+}
+
+// RevokeShareLink deletes a link of the actor, links of other actors aren't found
+func (s *shareService) RevokeShareLink(ctx context.Context, id int) error {
+	err := s.repo.DeleteShareLink(RequestInfoFrom(ctx).Actor, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrShareLinkNotFound
+	}
+	return err
+}
+
+// GetSharedList looks the token up by its hash & filters the live series through
+// the link
+func (s *shareService) GetSharedList(ctx context.Context, token string) (*models.SharedList, error) {
+	if token == "" {
+		return nil, ErrShareNotFound
+	}
+
+	link, err := s.repo.FindShareLink(hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrShareNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if link.ExpiresAt != nil && !link.ExpiresAt.After(time.Now()) {
+		return nil, ErrShareNotFound
+	}
+
+	series, err := s.series.GetAllSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]bool{}
+	for _, status := range link.Statuses {
+		statuses[status] = true
+	}
+	// Sorting by ranking would give hidden rankings away
+	sort.Slice(series, func(i, j int) bool {
+		if !link.HideRankings && series[i].Ranking != series[j].Ranking {
+			return series[i].Ranking > series[j].Ranking
+		}
+		return series[i].Title < series[j].Title
+	})
+
+	shared := &models.SharedList{Series: []models.SharedSerie{}, ExpiresAt: link.ExpiresAt}
+	for _, serie := range series {
+		if len(statuses) > 0 && !statuses[serie.Status] {
+			continue
+		}
+		view := models.SharedSerie{
+			Title:          serie.Title,
+			Status:         serie.Status,
+			CurrentEpisode: serie.CurrentEpisode,
+			TotalEpisodes:  serie.TotalEpisodes,
+		}
+		if !link.HideRankings {
+			view.Ranking = &serie.Ranking
+		}
+		shared.Series = append(shared.Series, view)
+	}
+	return shared, nil
+}
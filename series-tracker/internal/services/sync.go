@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// Outcomes of queued edits
+const (
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+	SyncFailed   = "failed"
+)
+
+// parseSyncToken returns the change sequence number a sync token stands for, 0 for
+// an empty token
+func parseSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("%w: invalid sync token", ErrInvalidInput)
+	}
+	return seq, nil
+}
+
+// GetChanges returns the series changed since the token, tokens being the change
+// sequence number they were handed out at
+func (s *seriesService) GetChanges(ctx context.Context, token string) (*models.SyncChanges, error) {
+	since, err := parseSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.seriesRepo.GetChangesSince(since)
+	if err != nil {
+		return nil, err
+	}
+
+	changes.Token = strconv.FormatInt(changes.Seq, 10)
+	return changes, nil
+}
+
+// PushChanges applies every edit in its own transaction. An edit of a series changed
+// on the server after the token, other than by an earlier edit of the same push, is
+// a conflict and isn't applied, so is one giving a series a title taken on the server.
+func (s *seriesService) PushChanges(ctx context.Context, push models.SyncPush) ([]models.SyncResult, error) {
+	if push.Token == "" {
+		return nil, fmt.Errorf("%w: token is required", ErrInvalidInput)
+	}
+	if len(push.Edits) == 0 {
+		return nil, fmt.Errorf("%w: no edits given", ErrInvalidInput)
+	}
+	if len(push.Edits) > MaxBulkOperations {
+		return nil, fmt.Errorf("%w: at most %d edits per push", ErrInvalidInput, MaxBulkOperations)
+	}
+
+	since, err := parseSyncToken(push.Token)
+	if err != nil {
+		return nil, err
+	}
+	// Edits made on top of a token that can't be synced from can't be checked
+	syncable, err := s.seriesRepo.IsSyncable(since)
+	if err != nil {
+		return nil, err
+	}
+	if !syncable {
+		return nil, fmt.Errorf("%w: token can't be synced from anymore, sync again before pushing", ErrInvalidInput)
+	}
+
+	// Change number of the latest edit of each series pushed so far
+	edited := map[int]int64{}
+	results := make([]models.SyncResult, len(push.Edits))
+	for i, edit := range push.Edits {
+		result := models.SyncResult{Index: i, Op: edit.Op, ID: edit.ID}
+		var editedID int
+		var editedSeq int64
+		err := s.uow.Do(func(repos *repositories.Repositories) error {
+			if edit.Op != BulkOpCreate {
+				base, ok := edited[edit.ID]
+				if !ok {
+					base = since
+				}
+				current, conflict, err := syncConflict(repos, edit.ID, base)
+				if err != nil {
+					return err
+				}
+				if conflict {
+					result.Outcome = SyncConflict
+					result.Serie = current
+					return nil
+				}
+			}
+
+			serie, err := s.runBulkOperation(ctx, repos, edit)
+			if err != nil {
+				return err
+			}
+			result.Outcome = SyncApplied
+			result.Serie = serie
+
+			editedID = edit.ID
+			if serie != nil {
+				editedID = serie.ID
+			}
+			editedSeq, err = repos.Series.GetChangeSeq(editedID)
+			return err
+		})
+		switch {
+		case errors.Is(err, ErrConflict):
+			// The title was taken on the server in the meantime
+			result.Outcome = SyncConflict
+			result.Serie = nil
+			result.Err = err
+		case err != nil:
+			result.Outcome = SyncFailed
+			result.Serie = nil
+			result.Err = err
+		case result.Outcome == SyncApplied:
+			edited[editedID] = editedSeq
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// syncConflict locks a series & reports whether it changed after base, along with its
+// current version, nil once deleted. Series that never existed aren't conflicts,
+// editing them fails on its own.
+func syncConflict(repos *repositories.Repositories, id int, base int64) (*models.Serie, bool, error) {
+	current, err := repos.Series.GetSerieByIDForUpdate(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		current = nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	seq, err := repos.Series.GetChangeSeq(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return current, seq > base, nil
+}
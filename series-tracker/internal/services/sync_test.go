@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+)
+
+// takenTitleRepository syncs from any token & holds every title already, reading
+// the changes themselves panics
+type takenTitleRepository struct {
+	repositories.SeriesRepository
+}
+
+func (takenTitleRepository) IsSyncable(since int64) (bool, error) {
+	return since > 0, nil
+}
+
+func (takenTitleRepository) CreateNewSerie(models.Serie) (*models.Serie, error) {
+	return nil, errors.Join(repositories.ErrConflict, errors.New("title taken"))
+}
+
+func TestPushCreateWithTakenTitleIsConflict(t *testing.T) {
+	repo := takenTitleRepository{}
+	uow := memoryUnitOfWork{&repositories.Repositories{Series: repo, Audit: &memoryAuditRepository{}}}
+	service := NewSeriesService(repo, uow, nil, nil)
+
+	serie := &models.Serie{Title: "Dark", Status: "Watching", Ranking: 1, TotalEpisodes: 26}
+	results, err := service.PushChanges(context.Background(), models.SyncPush{
+		Token: "12",
+		Edits: []models.BulkOperation{{Op: BulkOpCreate, Serie: serie}},
+	})
+	if err != nil {
+		t.Fatalf("PushChanges: %v", err)
+	}
+	if got := results[0]; got.Outcome != SyncConflict || got.Serie != nil || !errors.Is(got.Err, ErrConflict) {
+		t.Errorf("result = %+v, want a conflict", got)
+	}
+
+	_, err = service.PushChanges(context.Background(), models.SyncPush{
+		Token: "0",
+		Edits: []models.BulkOperation{{Op: BulkOpCreate, Serie: serie}},
+	})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("push from an unsyncable token = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+	"series-tracker/internal/webhooks"
+)
+
+// Webhook events, a change can raise more than one
+const (
+	EventSeriesCreated       = "series.created"
+	EventSeriesUpdated       = "series.updated"
+	EventSeriesDeleted       = "series.deleted"
+	EventSeriesRestored      = "series.restored"
+	EventSeriesPurged        = "series.purged"
+	EventSeriesStatusChanged = "series.status_changed"
+	EventSeriesCompleted     = "series.completed" // Raised alongside any change that completes a series
+	EventSeriesUpvoted       = "series.upvoted"
+	EventSeriesDownvoted     = "series.downvoted"
+	EventEpisodeIncremented  = "episode.incremented"
+	EventAll                 = "*" // Subscribes a webhook to every event
+)
+
+// Webhook event raised for each audited action
+var actionEvents = map[string]string{
+	ActionCreate:   EventSeriesCreated,
+	ActionUpdate:   EventSeriesUpdated,
+	ActionDelete:   EventSeriesDeleted,
+	ActionRestore:  EventSeriesRestored,
+	ActionPurge:    EventSeriesPurged,
+	ActionStatus:   EventSeriesStatusChanged,
+	ActionUpvote:   EventSeriesUpvoted,
+	ActionDownvote: EventSeriesDownvoted,
+	ActionEpisode:  EventEpisodeIncremented,
+}
+
+// Set of events webhooks can subscribe to
+var validEvents = map[string]bool{EventSeriesCompleted: true, EventAll: true}
+
+func init() {
+	for _, event := range actionEvents {
+		validEvents[event] = true
+	}
+}
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// maxDeliveryLimit caps how many deliveries are returned by a single query
+const maxDeliveryLimit = 500
+
+// WebhookPolicy configures how deliveries are attempted
+type WebhookPolicy struct {
+	MaxAttempts int           // Attempts before a delivery is marked failed
+	Backoff     time.Duration // Wait before the first retry, doubled on every further one
+	MaxBackoff  time.Duration // Longest wait between two attempts
+	Timeout     time.Duration // How long the receiver has to answer an attempt
+	BatchSize   int           // Deliveries attempted concurrently by a single DeliverDue call
+}
+
+// DefaultWebhookPolicy retries for a little over four hours before giving up
+var DefaultWebhookPolicy = WebhookPolicy{
+	MaxAttempts: 10,
+	Backoff:     30 * time.Second,
+	MaxBackoff:  6 * time.Hour,
+	Timeout:     10 * time.Second,
+	BatchSize:   20,
+}
+
+// WebhookService defines all the methods to be implemented for webhook management
+// & delivery
+type WebhookService interface {
+	// CreateWebhook registers a webhook, the returned one holds its signing secret
+	CreateWebhook(ctx context.Context, input models.WebhookInput) (*models.Webhook, error)
+	// GetWebhooks returns every webhook, without secrets
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	// GetWebhook returns a webhook by its ID, without secret
+	GetWebhook(ctx context.Context, id int) (*models.Webhook, error)
+	// DeleteWebhook removes a webhook & its delivery log by its ID
+	DeleteWebhook(ctx context.Context, id int) error
+	// GetDeliveries returns the latest deliveries to a webhook, most recent first
+	GetDeliveries(ctx context.Context, webhookID, limit int) ([]models.WebhookDelivery, error)
+	// ReplayDelivery queues a new delivery of the same event to the same webhook
+	ReplayDelivery(ctx context.Context, id int) (*models.WebhookDelivery, error)
+	// DeliverDue attempts every due delivery, returning how many were attempted
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+// webhookService holds all the dependencies for the service
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	client      *http.Client
+	policy      WebhookPolicy
+}
+
+// NewWebhookService returns a webhookService with the given dependencies
+func NewWebhookService(webhookRepo repositories.WebhookRepository, client *http.Client, policy WebhookPolicy) WebhookService {
+	return &webhookService{
+		webhookRepo: webhookRepo,
+		client:      client,
+		policy:      policy,
+	}
+}
+
+// randomHex returns n random bytes hex encoded
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// CreateWebhook validates & registers a webhook with a freshly generated secret
+func (s *webhookService) CreateWebhook(ctx context.Context, input models.WebhookInput) (*models.Webhook, error) {
+	target, err := url.Parse(input.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidInput)
+	}
+	if len(input.Events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrInvalidInput)
+	}
+	for _, event := range input.Events {
+		if !validEvents[event] {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidInput, event)
+		}
+	}
+
+	return s.webhookRepo.CreateWebhook(models.Webhook{
+		URL:    input.URL,
+		Secret: "whsec_" + randomHex(32),
+		Events: input.Events,
+	})
+}
+
+// GetWebhooks returns every webhook with their secrets left out
+func (s *webhookService) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	list, err := s.webhookRepo.GetWebhooks()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list {
+		list[i].Secret = ""
+	}
+	return list, nil
+}
+
+// GetWebhook returns a webhook by its ID with its secret left out
+func (s *webhookService) GetWebhook(ctx context.Context, id int) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.GetWebhookByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook by its ID
+func (s *webhookService) DeleteWebhook(ctx context.Context, id int) error {
+	err := s.webhookRepo.DeleteWebhook(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrWebhookNotFound
+	}
+	return err
+}
+
+// GetDeliveries returns the latest deliveries to an existing webhook
+func (s *webhookService) GetDeliveries(ctx context.Context, webhookID, limit int) ([]models.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+	return s.webhookRepo.GetDeliveries(webhookID, limit)
+}
+
+// ReplayDelivery queues a copy of a delivery, its payload is kept as is so receivers
+// recognize the event by its ID
+func (s *webhookService) ReplayDelivery(ctx context.Context, id int) (*models.WebhookDelivery, error) {
+	original, err := s.webhookRepo.GetDeliveryByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.CreateDelivery(models.WebhookDelivery{
+		WebhookID: original.WebhookID,
+		Event:     original.Event,
+		Payload:   original.Payload,
+		ReplayOf:  &original.ID,
+	})
+}
+
+// entryEvents returns the events raised by an audited change
+func entryEvents(entry models.AuditEntry) []string {
+	events := []string{}
+	if event, ok := actionEvents[entry.Action]; ok {
+		events = append(events, event)
+	}
+
+	// Completing a series is interesting no matter how it happened
+	completed := entry.After != nil && entry.After.Status == "Completed" &&
+		(entry.Before == nil || entry.Before.Status != "Completed")
+	if completed && entry.Action != ActionRestore {
+		events = append(events, EventSeriesCompleted)
+	}
+	return events
+}
+
+// StageWebhookDeliveries queues the deliveries of every event raised by an audited
+// change through the repositories of its transaction, so they're committed along
+// with the change or not at all. It's meant for NewOutboxUnitOfWork.
+func StageWebhookDeliveries(repos *repositories.Repositories, entry models.AuditEntry) error {
+	for _, event := range entryEvents(entry) {
+		payload, err := json.Marshal(models.WebhookEvent{
+			ID:         randomHex(16),
+			Event:      event,
+			OccurredAt: entry.CreatedAt,
+			Actor:      entry.Actor,
+			RequestID:  entry.RequestID,
+			Serie:      entry.After,
+			Previous:   entry.Before,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := repos.Webhooks.EnqueueDeliveries(event, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeliverDue claims a batch of due deliveries & attempts them concurrently
+func (s *webhookService) DeliverDue(ctx context.Context) (int, error) {
+	// Claimed deliveries stay leased until well after their attempt timed out
+	now := time.Now()
+	due, err := s.webhookRepo.ClaimDueDeliveries(now, now.Add(s.policy.Timeout+time.Minute), s.policy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(due))
+	for i := range due {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.attempt(ctx, due[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return len(due), errors.Join(errs...)
+}
+
+// attempt POSTs a delivery to its webhook & records the outcome, scheduling a
+// retry with exponential backoff until the attempts run out
+func (s *webhookService) attempt(ctx context.Context, d models.WebhookDelivery) error {
+	webhook, err := s.webhookRepo.GetWebhookByID(d.WebhookID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Deleted since, its deliveries went with it
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	code, err := s.post(ctx, webhook, d)
+
+	now := time.Now()
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.ResponseCode = code
+	d.Error = ""
+	d.NextAttemptAt = nil
+	switch {
+	case err == nil:
+		d.Status = DeliverySucceeded
+	case d.Attempts >= s.policy.MaxAttempts:
+		d.Status = DeliveryFailed
+		d.Error = err.Error()
+	default:
+		d.Error = err.Error()
+		backoff := s.policy.Backoff << (d.Attempts - 1)
+		if backoff > s.policy.MaxBackoff || backoff <= 0 {
+			backoff = s.policy.MaxBackoff
+		}
+		next := now.Add(backoff)
+		d.NextAttemptAt = &next
+	}
+
+	return s.webhookRepo.UpdateDelivery(d)
+}
+
+// post sends a signed delivery, any answer other than 2xx is an error
+func (s *webhookService) post(ctx context.Context, webhook *models.Webhook, d models.WebhookDelivery) (*int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.policy.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "series-tracker-webhooks")
+	req.Header.Set(webhooks.HeaderEvent, d.Event)
+	req.Header.Set(webhooks.HeaderDelivery, strconv.Itoa(d.ID))
+	req.Header.Set(webhooks.HeaderSignature, webhooks.Sign(webhook.Secret, time.Now(), d.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &res.StatusCode, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return &res.StatusCode, nil
+}
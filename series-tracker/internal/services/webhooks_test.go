@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"series-tracker/internal/models"
+	"series-tracker/internal/repositories"
+	"series-tracker/internal/webhooks"
+)
+
+// memoryWebhookRepository keeps webhooks & deliveries in memory
+type memoryWebhookRepository struct {
+	mu         sync.Mutex
+	webhooks   map[int]models.Webhook
+	deliveries map[int]*models.WebhookDelivery
+	nextID     int
+}
+
+func newMemoryWebhookRepository() *memoryWebhookRepository {
+	return &memoryWebhookRepository{webhooks: map[int]models.Webhook{}, deliveries: map[int]*models.WebhookDelivery{}}
+}
+
+func (r *memoryWebhookRepository) CreateWebhook(w models.Webhook) (*models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	w.ID, w.CreatedAt = r.nextID, time.Now()
+	r.webhooks[w.ID] = w
+	return &w, nil
+}
+
+func (r *memoryWebhookRepository) GetWebhooks() ([]models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []models.Webhook{}
+	for _, w := range r.webhooks {
+		list = append(list, w)
+	}
+	return list, nil
+}
+
+func (r *memoryWebhookRepository) GetWebhookByID(id int) (*models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.webhooks[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &w, nil
+}
+
+func (r *memoryWebhookRepository) DeleteWebhook(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.webhooks[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.webhooks, id)
+	return nil
+}
+
+func (r *memoryWebhookRepository) EnqueueDeliveries(event string, payload []byte) (int64, error) {
+	r.mu.Lock()
+	var ids []int
+	for id, w := range r.webhooks {
+		for _, subscribed := range w.Events {
+			if subscribed == event || subscribed == EventAll {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	for _, id := range ids {
+		if _, err := r.CreateDelivery(models.WebhookDelivery{WebhookID: id, Event: event, Payload: payload}); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(ids)), nil
+}
+
+func (r *memoryWebhookRepository) CreateDelivery(d models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	now := time.Now()
+	d.ID, d.Status, d.CreatedAt, d.NextAttemptAt = r.nextID, DeliveryPending, now, &now
+	r.deliveries[d.ID] = &d
+	copied := d
+	return &copied, nil
+}
+
+func (r *memoryWebhookRepository) GetDeliveryByID(id int) (*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (r *memoryWebhookRepository) GetDeliveries(webhookID, limit int) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := []models.WebhookDelivery{}
+	for id := r.nextID; id > 0 && len(list) < limit; id-- {
+		if d, ok := r.deliveries[id]; ok && d.WebhookID == webhookID {
+			list = append(list, *d)
+		}
+	}
+	return list, nil
+}
+
+func (r *memoryWebhookRepository) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := []models.WebhookDelivery{}
+	for id := 1; id <= r.nextID && len(due) < limit; id++ {
+		d, ok := r.deliveries[id]
+		if !ok || d.Status != DeliveryPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+		lease := leaseUntil
+		d.NextAttemptAt = &lease
+		due = append(due, *d)
+	}
+	return due, nil
+}
+
+func (r *memoryWebhookRepository) UpdateDelivery(d models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[d.ID] = &d
+	return nil
+}
+
+// makeDue moves the next attempt of a delivery to now, as if its backoff elapsed
+func (r *memoryWebhookRepository) makeDue(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.deliveries[id].NextAttemptAt = &now
+}
+
+// receivedDelivery is a request the test receiver got
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a receiver answering with the given status codes in turn, then
+// 200 once they run out
+func newReceiver(t *testing.T, codes ...int) (*httptest.Server, <-chan receivedDelivery) {
+	t.Helper()
+	received := make(chan receivedDelivery, 16)
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedDelivery{header: r.Header.Clone(), body: body}
+
+		mu.Lock()
+		code := http.StatusOK
+		if len(codes) > 0 {
+			code, codes = codes[0], codes[1:]
+		}
+		mu.Unlock()
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+// testPolicy retries quickly & gives up after three attempts
+var testPolicy = WebhookPolicy{
+	MaxAttempts: 3,
+	Backoff:     time.Minute,
+	MaxBackoff:  time.Hour,
+	Timeout:     5 * time.Second,
+	BatchSize:   10,
+}
+
+// setupWebhook registers a webhook to the receiver & queues a delivery of a change
+func setupWebhook(t *testing.T, repo *memoryWebhookRepository, url string) (WebhookService, *models.Webhook, *models.WebhookDelivery) {
+	t.Helper()
+	service := NewWebhookService(repo, &http.Client{}, testPolicy)
+	webhook, err := service.CreateWebhook(context.Background(), models.WebhookInput{URL: url, Events: []string{EventSeriesCreated}})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+
+	entry := models.AuditEntry{
+		Action:    ActionCreate,
+		SerieID:   7,
+		Actor:     "tester",
+		RequestID: "req-1",
+		After:     &models.Serie{ID: 7, Title: "Dark", Status: "Watching", TotalEpisodes: 26},
+		CreatedAt: time.Now(),
+	}
+	if err := StageWebhookDeliveries(&repositories.Repositories{Webhooks: repo}, entry); err != nil {
+		t.Fatalf("StageWebhookDeliveries: %v", err)
+	}
+	deliveries, _ := repo.GetDeliveries(webhook.ID, 10)
+	if len(deliveries) != 1 {
+		t.Fatalf("queued %d deliveries, want 1", len(deliveries))
+	}
+	return service, webhook, &deliveries[0]
+}
+
+func TestWebhookDeliverySigned(t *testing.T) {
+	server, received := newReceiver(t)
+	repo := newMemoryWebhookRepository()
+	service, webhook, delivery := setupWebhook(t, repo, server.URL)
+
+	attempted, err := service.DeliverDue(context.Background())
+	if err != nil || attempted != 1 {
+		t.Fatalf("DeliverDue = %d, %v, want 1 attempt", attempted, err)
+	}
+
+	got := <-received
+	if err := webhooks.Verify(webhook.Secret, got.header.Get(webhooks.HeaderSignature), got.body, time.Now(), time.Minute); err != nil {
+		t.Errorf("signature doesn't verify: %v", err)
+	}
+	if err := webhooks.Verify("whsec_other", got.header.Get(webhooks.HeaderSignature), got.body, time.Now(), time.Minute); err == nil {
+		t.Error("signature verifies with another secret")
+	}
+	if event := got.header.Get(webhooks.HeaderEvent); event != EventSeriesCreated {
+		t.Errorf("event header = %q, want %q", event, EventSeriesCreated)
+	}
+	if id := got.header.Get(webhooks.HeaderDelivery); id != strconv.Itoa(delivery.ID) {
+		t.Errorf("delivery header = %q, want %d", id, delivery.ID)
+	}
+
+	var event models.WebhookEvent
+	if err := json.Unmarshal(got.body, &event); err != nil {
+		t.Fatalf("payload isn't a webhook event: %v", err)
+	}
+	if event.Event != EventSeriesCreated || event.Serie == nil || event.Serie.Title != "Dark" || event.Actor != "tester" {
+		t.Errorf("payload = %+v", event)
+	}
+
+	logged, _ := repo.GetDeliveryByID(delivery.ID)
+	if logged.Status != DeliverySucceeded || logged.Attempts != 1 || logged.ResponseCode == nil || *logged.ResponseCode != http.StatusOK {
+		t.Errorf("delivery log = %+v", logged)
+	}
+}
+
+func TestWebhookDeliveryRetriedWithBackoff(t *testing.T) {
+	server, received := newReceiver(t, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	repo := newMemoryWebhookRepository()
+	service, _, delivery := setupWebhook(t, repo, server.URL)
+
+	for attempt, code := range []int{http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		if attempted, err := service.DeliverDue(context.Background()); err != nil || attempted != 1 {
+			t.Fatalf("attempt %d: DeliverDue = %d, %v, want 1 attempt", attempt+1, attempted, err)
+		}
+		<-received
+
+		logged, _ := repo.GetDeliveryByID(delivery.ID)
+		if logged.Status != DeliveryPending || logged.Attempts != attempt+1 {
+			t.Fatalf("attempt %d: delivery log = %+v", attempt+1, logged)
+		}
+		if logged.ResponseCode == nil || *logged.ResponseCode != code {
+			t.Errorf("attempt %d: response code = %v, want %d", attempt+1, logged.ResponseCode, code)
+		}
+		backoff := logged.NextAttemptAt.Sub(*logged.LastAttemptAt)
+		if want := testPolicy.Backoff << attempt; backoff != want {
+			t.Errorf("attempt %d: backoff = %s, want %s", attempt+1, backoff, want)
+		}
+
+		// Not due until the backoff elapsed
+		if attempted, _ := service.DeliverDue(context.Background()); attempted != 0 {
+			t.Fatalf("attempt %d: retried before the backoff elapsed", attempt+1)
+		}
+		repo.makeDue(delivery.ID)
+	}
+
+	if _, err := service.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("third attempt: %v", err)
+	}
+	<-received
+	logged, _ := repo.GetDeliveryByID(delivery.ID)
+	if logged.Status != DeliverySucceeded || logged.Attempts != 3 || logged.Error != "" || logged.NextAttemptAt != nil {
+		t.Errorf("delivery log = %+v", logged)
+	}
+}
+
+func TestWebhookDeliveryGivesUp(t *testing.T) {
+	server, received := newReceiver(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	repo := newMemoryWebhookRepository()
+	service, _, delivery := setupWebhook(t, repo, server.URL)
+
+	for i := 0; i < testPolicy.MaxAttempts; i++ {
+		service.DeliverDue(context.Background())
+		<-received
+		repo.makeDue(delivery.ID)
+	}
+
+	logged, _ := repo.GetDeliveryByID(delivery.ID)
+	if logged.Status != DeliveryFailed || logged.Attempts != testPolicy.MaxAttempts || logged.Error == "" {
+		t.Errorf("delivery log = %+v", logged)
+	}
+	if attempted, _ := service.DeliverDue(context.Background()); attempted != 0 {
+		t.Error("failed delivery attempted again")
+	}
+}
+
+func TestWebhookReplayResends(t *testing.T) {
+	server, received := newReceiver(t)
+	repo := newMemoryWebhookRepository()
+	service, webhook, delivery := setupWebhook(t, repo, server.URL)
+
+	service.DeliverDue(context.Background())
+	first := <-received
+
+	replay, err := service.ReplayDelivery(context.Background(), delivery.ID)
+	if err != nil {
+		t.Fatalf("ReplayDelivery: %v", err)
+	}
+	if replay.ReplayOf == nil || *replay.ReplayOf != delivery.ID || replay.ID == delivery.ID {
+		t.Errorf("replay = %+v", replay)
+	}
+
+	if attempted, err := service.DeliverDue(context.Background()); err != nil || attempted != 1 {
+		t.Fatalf("DeliverDue = %d, %v, want 1 attempt", attempted, err)
+	}
+	again := <-received
+	if string(again.body) != string(first.body) {
+		t.Errorf("replayed payload = %s, want %s", again.body, first.body)
+	}
+	if id := again.header.Get(webhooks.HeaderDelivery); id != strconv.Itoa(replay.ID) {
+		t.Errorf("delivery header = %q, want %d", id, replay.ID)
+	}
+	if err := webhooks.Verify(webhook.Secret, again.header.Get(webhooks.HeaderSignature), again.body, time.Now(), time.Minute); err != nil {
+		t.Errorf("replay signature doesn't verify: %v", err)
+	}
+
+	deliveries, _ := service.GetDeliveries(context.Background(), webhook.ID, 0)
+	if len(deliveries) != 2 || deliveries[0].Status != DeliverySucceeded || deliveries[1].Status != DeliverySucceeded {
+		t.Errorf("delivery log = %+v", deliveries)
+	}
+
+	if _, err := service.ReplayDelivery(context.Background(), 999); !errors.Is(err, ErrNotFound) {
+		t.Errorf("replay of an unknown delivery = %v, want ErrNotFound", err)
+	}
+}
+
+// memoryUnitOfWork runs fn with fixed repositories, without a transaction
+type memoryUnitOfWork struct {
+	repos *repositories.Repositories
+}
+
+func (u memoryUnitOfWork) Do(fn func(repos *repositories.Repositories) error) error {
+	return fn(u.repos)
+}
+
+// memoryAuditRepository hands out increasing IDs to the entries it creates
+type memoryAuditRepository struct {
+	repositories.AuditRepository
+	entries []models.AuditEntry
+}
+
+func (r *memoryAuditRepository) CreateAuditEntry(e models.AuditEntry) (*models.AuditEntry, error) {
+	e.ID, e.CreatedAt = len(r.entries)+1, time.Now()
+	r.entries = append(r.entries, e)
+	return &e, nil
+}
+
+func TestOutboxStagesDeliveriesWithTheChange(t *testing.T) {
+	repo := newMemoryWebhookRepository()
+	repo.CreateWebhook(models.Webhook{URL: "http://example.com", Secret: "s", Events: []string{EventAll}})
+	audit := &memoryAuditRepository{}
+	uow := repositories.NewOutboxUnitOfWork(memoryUnitOfWork{&repositories.Repositories{Audit: audit, Webhooks: repo}}, StageWebhookDeliveries)
+
+	ctx := WithRequestInfo(context.Background(), RequestInfo{Actor: "tester"})
+	before := &models.Serie{ID: 3, Title: "Dark", Status: "Watching", CurrentEpisode: 25, TotalEpisodes: 26}
+	after := &models.Serie{ID: 3, Title: "Dark", Status: "Completed", CurrentEpisode: 26, TotalEpisodes: 26}
+	err := uow.Do(func(repos *repositories.Repositories) error {
+		return recordAudit(ctx, repos, ActionEpisode, before, after)
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	events := map[string]bool{}
+	for _, d := range repo.deliveries {
+		events[d.Event] = true
+	}
+	if len(repo.deliveries) != 2 || !events[EventEpisodeIncremented] || !events[EventSeriesCompleted] {
+		t.Errorf("staged events = %v, want %s & %s", events, EventEpisodeIncremented, EventSeriesCompleted)
+	}
+}
@@ -2,10 +2,21 @@ package main
 
 import (
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
 
 	"series-tracker/internal/api"
+	"series-tracker/internal/api/gql"
 	"series-tracker/internal/api/handlers"
+	"series-tracker/internal/api/rpc"
+	v2 "series-tracker/internal/api/v2"
+	"series-tracker/internal/broker"
 	"series-tracker/internal/database"
+	"series-tracker/internal/imports"
+	"series-tracker/internal/jobs"
 	"series-tracker/internal/repositories"
 	"series-tracker/internal/services"
 
@@ -18,35 +29,200 @@ import (
 func main() {
 	dbConn, err := database.NewDatabaseConnection()
 	if err != nil {
-		log.Fatalf("FATAL: No db")
+		log.Fatalf("FATAL: No db: %v", err)
 	}
 	defer dbConn.Close()
 
-	seriesRepo := repositories.NewSeriesRepository(dbConn)
-	seriesService := services.NewSeriesService(seriesRepo)
+	// SERIES_STORAGE picks how series are stored, "events" keeps them in an
+	// append-only event store with the series table as a projection, anything else
+	// uses the series table directly
+	var seriesRepo repositories.SeriesRepository
+	var unitOfWork repositories.UnitOfWork
+	var backupRepo repositories.BackupRepository
+	if os.Getenv("SERIES_STORAGE") == "events" {
+		eventRepo := repositories.NewEventSourcedSeriesRepository(dbConn)
+		bootstrapped, err := eventRepo.Bootstrap()
+		if err != nil {
+			log.Fatalf("FATAL: failed to bootstrap event store: %v", err)
+		}
+		if bootstrapped > 0 {
+			log.Printf("event store: recorded %d existing series", bootstrapped)
+		}
+		seriesRepo = eventRepo
+		unitOfWork = repositories.NewEventSourcedUnitOfWork(dbConn)
+		backupRepo = repositories.NewEventSourcedBackupRepository(dbConn)
+	} else {
+		seriesRepo = repositories.NewSeriesRepository(dbConn)
+		unitOfWork = repositories.NewUnitOfWork(dbConn)
+		backupRepo = repositories.NewBackupRepository(dbConn)
+	}
+
+	// Reads are served from an in-process LRU holding up to SERIES_CACHE_SIZE entries,
+	// defaulting to 1000, 0 disables it
+	cacheSize := 1000
+	if value := os.Getenv("SERIES_CACHE_SIZE"); value != "" {
+		cacheSize, err = strconv.Atoi(value)
+		if err != nil || cacheSize < 0 {
+			log.Fatalf("FATAL: invalid SERIES_CACHE_SIZE %q", value)
+		}
+	}
+	var cacheHandler *handlers.CacheHandler
+	var clearCache func()
+	if cacheSize > 0 {
+		cachedRepo := repositories.NewCachedSeriesRepository(seriesRepo, cacheSize)
+		seriesRepo = cachedRepo
+		unitOfWork = cachedRepo.WrapUnitOfWork(unitOfWork)
+		cacheHandler = handlers.NewCacheHandler(cachedRepo)
+		clearCache = cachedRepo.Clear
+	}
+	// Webhook deliveries are queued within the transaction of the change raising
+	// them, a crash right after a commit can't lose them
+	unitOfWork = repositories.NewOutboxUnitOfWork(unitOfWork, services.StageWebhookDeliveries)
+	// Committed changes are fanned out to gRPC watchers & SSE clients, the most
+	// recent ones are kept so SSE clients can resume after reconnecting
+	changes := broker.New(1000)
+	// New series are filled in from the catalog ingested by cmd/catalog
+	catalogService := services.NewCatalogService(repositories.NewCatalogRepository(dbConn))
+	seriesService := services.NewSeriesService(seriesRepo, unitOfWork, changes, catalogService)
 	seriesHandler := handlers.NewSeriesHandler(seriesService)
+	catalogHandler := handlers.NewCatalogHandler(catalogService)
+	eventsHandler := handlers.NewEventsHandler(changes, 15*time.Second)
+
+	// Requests are accepted from localhost / localhost:80, this is the default port
+	// nginx is set up to run on & just making it more accessible
+	allowedOrigins := []string{
+		"http://localhost",
+		"http://localhost:80",
+	}
+	wsHandler := handlers.NewWSHandler(seriesService, changes, allowedOrigins)
+	v2Handler := v2.NewSeriesHandler(seriesService)
+
+	auditRepo := repositories.NewAuditRepository(dbConn)
+	auditService := services.NewAuditService(auditRepo)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	graphqlHandler, err := gql.NewHandler(seriesService, auditService)
+	if err != nil {
+		log.Fatalf("FATAL: invalid GraphQL schema: %v", err)
+	}
+
+	// Queued webhook deliveries are attempted every few seconds, failed attempts are
+	// retried with backoff
+	webhookRepo := repositories.NewWebhookRepository(dbConn)
+	webhookService := services.NewWebhookService(webhookRepo, &http.Client{}, services.DefaultWebhookPolicy)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	stopWebhooks := jobs.NewWebhookDispatcher(webhookService, 5*time.Second).Start()
+	defer stopWebhooks()
+
+	// Trashed series are purged for good once they've been in the trash for longer
+	// than TRASH_RETENTION (a Go duration such as "720h"), defaulting to 30 days
+	trashRetention := 30 * 24 * time.Hour
+	if value := os.Getenv("TRASH_RETENTION"); value != "" {
+		trashRetention, err = time.ParseDuration(value)
+		if err != nil || trashRetention < 0 {
+			log.Fatalf("FATAL: invalid TRASH_RETENTION %q", value)
+		}
+	}
+	stopTrashRetention := jobs.NewTrashRetention(seriesService, trashRetention, time.Hour).Start()
+	defer stopTrashRetention()
+
+	// Responses to POST, PATCH & DELETE requests sent with an Idempotency-Key are
+	// replayed to repeats for IDEMPOTENCY_TTL (a Go duration), defaulting to 24 hours
+	idempotencyTTL := 24 * time.Hour
+	if value := os.Getenv("IDEMPOTENCY_TTL"); value != "" {
+		idempotencyTTL, err = time.ParseDuration(value)
+		if err != nil || idempotencyTTL <= 0 {
+			log.Fatalf("FATAL: invalid IDEMPOTENCY_TTL %q", value)
+		}
+	}
+	idempotencyRepo := repositories.NewIdempotencyRepository(dbConn)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, idempotencyTTL)
+	stopIdempotencyCleanup := jobs.NewIdempotencyCleanup(idempotencyService, time.Hour).Start()
+	defer stopIdempotencyCleanup()
+
+	// Files POSTed to /api/import/:format are imported in the background, jobs can
+	// be polled for an hour once finished
+	importJobService := services.NewImportJobService(seriesService, imports.Default(), time.Hour)
+	importHandler := handlers.NewImportJobHandler(importJobService)
+
+	// The whole tracker can be backed up & restored, restores replace every series
+	// behind the cache's back
+	backupService := services.NewBackupService(backupRepo, clearCache)
+	backupHandler := handlers.NewBackupHandler(backupService)
+
+	// Airing schedules & watch sessions make up a calendar feed, subscribed to through
+	// a secret URL per actor
+	feedTokenService := services.NewFeedTokenService(repositories.NewFeedTokenRepository(dbConn))
+	calendarService := services.NewCalendarService(seriesService, repositories.NewCalendarRepository(dbConn))
+	calendarHandler := handlers.NewCalendarHandler(calendarService, feedTokenService)
+
+	// Friends follow an actor's progress through an Atom feed behind a secret URL
+	activityHandler := handlers.NewActivityHandler(services.NewActivityService(auditService), feedTokenService)
+
+	// Anyone holding a share link gets a read-only, filtered view of the series list
+	shareService := services.NewShareService(seriesService, repositories.NewShareLinkRepository(dbConn))
+	shareHandler := handlers.NewShareHandler(shareService)
+
+	// API_V1_SUNSET overrides when the v1 series routes are announced to go away,
+	// as an RFC 3339 timestamp
+	var v1Sunset time.Time
+	if value := os.Getenv("API_V1_SUNSET"); value != "" {
+		v1Sunset, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			log.Fatalf("FATAL: invalid API_V1_SUNSET %q", value)
+		}
+	}
 
 	routerConfig := &api.RouterConfig{
-		SeriesHandler: seriesHandler,
+		SeriesHandler:   seriesHandler,
+		AuditHandler:    auditHandler,
+		EventsHandler:   eventsHandler,
+		WSHandler:       wsHandler,
+		WebhookHandler:  webhookHandler,
+		ImportHandler:   importHandler,
+		BackupHandler:   backupHandler,
+		CalendarHandler: calendarHandler,
+		ActivityHandler: activityHandler,
+		ShareHandler:    shareHandler,
+		CatalogHandler:  catalogHandler,
+		CacheHandler:    cacheHandler,
+		V2Handler:       v2Handler,
+		GraphQL:         graphqlHandler,
+		V1Sunset:        v1Sunset,
 	}
 
 	e := echo.New()
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	e.Use(middleware.RequestID())
+	e.Use(api.RequestInfo())
 
-	// CORS is set up to be able to receive requests from localhost / localhost:80,
-	// this is the default port nginx is set up to run on & just making it more
-	// accessible
+	// CORS is set up to be able to receive requests from the allowed origins
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{
-			"http://localhost",
-			"http://localhost:80",
-		},
-		AllowMethods: []string{"GET", "HEAD", "PUT", "PATCH", "POST", "DELETE"},
-		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowOrigins:  allowedOrigins,
+		AllowMethods:  []string{"GET", "HEAD", "PUT", "PATCH", "POST", "DELETE"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", api.HeaderActor, "Last-Event-ID", api.HeaderIdempotencyKey},
+		ExposeHeaders: []string{"Deprecation", "Sunset", "Link", api.HeaderIdempotentReplayed},
 	}))
+	e.Use(api.Idempotency(idempotencyService))
 	api.SetupRoutes(e, routerConfig)
 
+	// The gRPC API listens on its own port, GRPC_ADDR, defaulting to ":9090"
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
+	}
+	listener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("FATAL: failed to listen on %s for gRPC: %v", grpcAddr, err)
+	}
+	grpcServer := rpc.NewGRPCServer(rpc.NewServer(seriesService, changes))
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("FATAL: gRPC server stopped: %v", err)
+		}
+	}()
+	defer grpcServer.Stop()
+
 	e.Logger.Fatal(e.Start(":8080"))
 
 	e.Logger.Print("hola")